@@ -0,0 +1,55 @@
+package scenclibase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	scenexec "github.com/multiversx/mx-chain-scenario-go/scenario/executor"
+	scenio "github.com/multiversx/mx-chain-scenario-go/scenario/io"
+	worldmock "github.com/multiversx/mx-chain-scenario-go/worldmock"
+)
+
+// GenerateCheckState runs a scenario, then writes a checkState step matching the resulting world.
+// If a baseline world snapshot is given, the accounts that did not change since are left out.
+// Nothing is written if the scenario fails.
+func GenerateCheckState(
+	scenarioPath string,
+	outputPath string,
+	baselinePath string,
+	checkOptions scenexec.CheckStateOptions,
+	options CLIRunOptions,
+) error {
+	if !strings.HasSuffix(scenarioPath, ".scen.json") {
+		return errors.New("checkState steps can only be generated from scenario files")
+	}
+	err := checkSingleScenarioOptions(options)
+	if err != nil {
+		return err
+	}
+
+	if len(baselinePath) > 0 {
+		snapshot, err := worldmock.ReadWorldSnapshotFile(baselinePath)
+		if err != nil {
+			return err
+		}
+		checkOptions.Baseline, err = snapshot.AccountMap()
+		if err != nil {
+			return fmt.Errorf("bad world snapshot file %s: %w", baselinePath, err)
+		}
+	}
+
+	executor := scenexec.NewScenarioExecutor(options.VMBuilder)
+	controller := scenio.NewScenarioController(executor, scenio.NewDefaultFileResolver(), options.VMBuilder.GetVMType())
+	err = controller.RunSingleJSONScenario(scenarioPath, options.RunOptions)
+	if err != nil {
+		return fmt.Errorf("checkState step not written: %w", err)
+	}
+
+	err = executor.WriteCheckStateStep(outputPath, checkOptions)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("checkState step written to: %s\n", outputPath)
+	return nil
+}
@@ -0,0 +1,224 @@
+package scenclibase
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/TwiN/go-color"
+
+	scenexec "github.com/multiversx/mx-chain-scenario-go/scenario/executor"
+	scenio "github.com/multiversx/mx-chain-scenario-go/scenario/io"
+)
+
+const debugHelp = `commands:
+  s, step                    run the next step
+  c, continue                run until a breakpoint, a failed step or the end
+  b, break [spec]            add a breakpoint: step index, id:<step id> or fn:<function>; list them if no spec
+  clear                      remove all breakpoints
+  l, list                    list the steps; "->" marks the next step, "*" the breakpoints
+  a, account <address>       show an account, e.g. account address:owner
+  storage <address> [key]    show the storage of an account, or a single key, e.g. storage sc:adder str:sum
+  esdt <address>             show the ESDT tokens of an account
+  o, output                  show the output of the last transaction
+  save <file>                save the world to a snapshot file, which run --load-world can start from
+  h, help                    show this help
+  q, quit                    stop debugging
+`
+
+// scenarioDebugSession reads debugger commands and prints their results.
+type scenarioDebugSession struct {
+	debugger *scenexec.ScenarioDebugger
+	out      io.Writer
+}
+
+// DebugScenario runs a scenario step by step, driven by commands read from the standard input.
+// The initial breakpoints are given in the same form as for the break command.
+func DebugScenario(scenarioPath string, breakpoints []string, options CLIRunOptions) error {
+	if !strings.HasSuffix(scenarioPath, ".scen.json") {
+		return errors.New("only scenario files can be debugged")
+	}
+
+	executor := scenexec.NewScenarioExecutor(options.VMBuilder)
+	controller := scenio.NewScenarioController(executor, scenio.NewDefaultFileResolver(), options.VMBuilder.GetVMType())
+	scenario, err := scenio.ParseScenariosScenario(controller.Parser, scenarioPath)
+	if err != nil {
+		return err
+	}
+
+	debugger, err := scenexec.NewScenarioDebugger(executor, scenario, controller.Parser.ExprInterpreter.FileResolver)
+	if err != nil {
+		return err
+	}
+	for _, spec := range breakpoints {
+		breakpoint, err := scenexec.ParseBreakpoint(spec)
+		if err != nil {
+			return err
+		}
+		debugger.AddBreakpoint(breakpoint)
+	}
+
+	session := &scenarioDebugSession{
+		debugger: debugger,
+		out:      os.Stdout,
+	}
+	session.printf("Debugging %s, %d step(s). Type help for the list of commands.\n", scenarioPath, len(debugger.Steps()))
+	session.run(os.Stdin)
+	return nil
+}
+
+func (s *scenarioDebugSession) run(in io.Reader) {
+	scanner := bufio.NewScanner(in)
+	s.printNextStep()
+	for {
+		s.printf("(debug) ")
+		if !scanner.Scan() {
+			s.printf("\n")
+			return
+		}
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if !s.execute(fields[0], fields[1:]) {
+			return
+		}
+	}
+}
+
+// execute runs a single command, and yields false when the session should end.
+func (s *scenarioDebugSession) execute(command string, args []string) bool {
+	switch command {
+	case "s", "step":
+		s.printStepResult(s.debugger.Step())
+	case "c", "continue":
+		s.printStepResult(s.debugger.Continue())
+	case "b", "break":
+		s.addBreakpoint(args)
+	case "clear":
+		s.debugger.ClearBreakpoints()
+		s.printf("all breakpoints removed\n")
+	case "l", "list":
+		s.listSteps()
+	case "a", "account":
+		s.describeAccount(args, s.debugger.DescribeAccount)
+	case "storage":
+		s.describeStorage(args)
+	case "esdt":
+		s.describeAccount(args, s.debugger.DescribeESDT)
+	case "o", "output":
+		s.printf("%s", s.debugger.DescribeLastOutput())
+	case "save":
+		s.saveWorld(args)
+	case "h", "help":
+		s.printf("%s", debugHelp)
+	case "q", "quit":
+		return false
+	default:
+		s.printf("unknown command %s, type help for the list of commands\n", command)
+	}
+	return true
+}
+
+func (s *scenarioDebugSession) printStepResult(err error) {
+	if err != nil {
+		s.printf("%s %s\n", color.Ize(color.Red, "FAIL:"), err.Error())
+	}
+	s.printNextStep()
+}
+
+func (s *scenarioDebugSession) printNextStep() {
+	if s.debugger.Finished() {
+		s.printf("scenario finished\n")
+		return
+	}
+	s.printf("next: %s\n", s.debugger.DescribeStep(s.debugger.NextStepIndex()))
+}
+
+func (s *scenarioDebugSession) addBreakpoint(args []string) {
+	if len(args) == 0 {
+		for _, breakpoint := range s.debugger.Breakpoints() {
+			s.printf("%s\n", breakpoint.String())
+		}
+		return
+	}
+
+	breakpoint, err := scenexec.ParseBreakpoint(strings.Join(args, " "))
+	if err != nil {
+		s.printf("%s\n", err.Error())
+		return
+	}
+	s.debugger.AddBreakpoint(breakpoint)
+	s.printf("breakpoint added: %s\n", breakpoint.String())
+}
+
+func (s *scenarioDebugSession) listSteps() {
+	for stepIndex := range s.debugger.Steps() {
+		marker := "  "
+		if stepIndex == s.debugger.NextStepIndex() {
+			marker = "->"
+		}
+		breakpointMarker := " "
+		if s.debugger.HasBreakpoint(stepIndex) {
+			breakpointMarker = "*"
+		}
+		s.printf("%s%s %s\n", marker, breakpointMarker, s.debugger.DescribeStep(stepIndex))
+	}
+}
+
+func (s *scenarioDebugSession) describeAccount(args []string, describe func([]byte) (string, error)) {
+	if len(args) != 1 {
+		s.printf("one account address required\n")
+		return
+	}
+	address, err := s.debugger.ParseValue(args[0])
+	if err != nil {
+		s.printf("bad address: %s\n", err.Error())
+		return
+	}
+	description, err := describe(address)
+	if err != nil {
+		s.printf("%s\n", err.Error())
+		return
+	}
+	s.printf("%s", description)
+}
+
+func (s *scenarioDebugSession) describeStorage(args []string) {
+	if len(args) == 0 || len(args) > 2 {
+		s.printf("an account address and an optional storage key required\n")
+		return
+	}
+	var key []byte
+	if len(args) == 2 {
+		var err error
+		key, err = s.debugger.ParseValue(args[1])
+		if err != nil {
+			s.printf("bad storage key: %s\n", err.Error())
+			return
+		}
+	}
+	s.describeAccount(args[:1], func(address []byte) (string, error) {
+		return s.debugger.DescribeStorage(address, key)
+	})
+}
+
+func (s *scenarioDebugSession) saveWorld(args []string) {
+	if len(args) != 1 {
+		s.printf("one snapshot file path required\n")
+		return
+	}
+	err := s.debugger.SaveWorld(args[0])
+	if err != nil {
+		s.printf("%s\n", err.Error())
+		return
+	}
+	s.printf("world saved to %s\n", args[0])
+}
+
+func (s *scenarioDebugSession) printf(format string, args ...interface{}) {
+	_, _ = fmt.Fprintf(s.out, format, args...)
+}
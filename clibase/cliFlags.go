@@ -0,0 +1,272 @@
+package scenclibase
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	scenio "github.com/multiversx/mx-chain-scenario-go/scenario/io"
+
+	cli "github.com/urfave/cli/v2"
+)
+
+const (
+	parallelFlagName           = "parallel"
+	reportFormatFlagName       = "report-format"
+	reportFileFlagName         = "report-file"
+	continueOnFailureFlagName  = "continue-on-failure"
+	includeFlagName            = "include"
+	excludeFlagName            = "exclude"
+	nameFlagName               = "name"
+	stepFlagName               = "step"
+	dryRunFlagName             = "dry-run"
+	intervalFlagName           = "interval"
+	profileFlagName            = "profile"
+	profileFileFlagName        = "profile-file"
+	gasTraceFileFlagName       = "gas-trace-file"
+	gasTraceFoldedFlagName     = "gas-trace-folded"
+	gasBaselineFlagName        = "gas-baseline"
+	gasToleranceFlagName       = "gas-tolerance"
+	gasBaselineWarnFlagName    = "gas-baseline-warn"
+	outputFlagName             = "output"
+	updateExpectationsFlagName = "update-expectations"
+	breakFlagName              = "break"
+	loadWorldFlagName          = "load-world"
+	saveWorldFlagName          = "save-world"
+	moreAccountsFlagName       = "more-accounts"
+	moreStorageFlagName        = "more-storage"
+	sinceFlagName              = "since"
+	decodeABIFlagName          = "decode-abi"
+)
+
+// runFlags are the flags of the run command that do not depend on the VM.
+func runFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.IntFlag{
+			Name:  parallelFlagName,
+			Usage: "number of scenarios to run concurrently, each with its own world",
+			Value: 1,
+		},
+		&cli.StringFlag{
+			Name:  reportFormatFlagName,
+			Usage: "format of the test report: junit or json",
+			Value: string(scenio.ReportFormatJUnit),
+		},
+		&cli.StringFlag{
+			Name:  reportFileFlagName,
+			Usage: "file where the test report is written; no report is written if missing",
+		},
+		&cli.BoolFlag{
+			Name:  continueOnFailureFlagName,
+			Usage: "keep running a scenario after failed checks and report all of them at the end",
+		},
+		&cli.StringSliceFlag{
+			Name:  includeFlagName,
+			Usage: "only run scenarios matching the glob, relative to the path argument, or by file name; can be repeated",
+		},
+		&cli.StringSliceFlag{
+			Name:  excludeFlagName,
+			Usage: "skip scenarios matching the glob, relative to the path argument, or by file name; can be repeated",
+		},
+		&cli.StringFlag{
+			Name:  nameFlagName,
+			Usage: "only run scenarios whose name matches the regular expression",
+		},
+		&cli.StringFlag{
+			Name:  stepFlagName,
+			Usage: "only run scenarios with a step whose id matches the regular expression",
+		},
+		&cli.StringFlag{
+			Name:  profileFlagName,
+			Usage: "print the time and gas of transactions per scenario, contract and endpoint, as a table or json",
+		},
+		&cli.StringFlag{
+			Name:  profileFileFlagName,
+			Usage: "file where the profile is written, instead of the standard output",
+		},
+		&cli.StringFlag{
+			Name:  gasTraceFileFlagName,
+			Usage: "trace gas in all scenarios and write the traces of all transactions to a JSON file",
+		},
+		&cli.StringFlag{
+			Name:  gasTraceFoldedFlagName,
+			Usage: "trace gas in all scenarios and write the traces as folded stacks, for flame graph tools",
+		},
+		&cli.BoolFlag{
+			Name:  updateExpectationsFlagName,
+			Usage: "rewrite the scenario files with the actual values of mismatched tx and checkState expectations",
+		},
+		&cli.StringFlag{
+			Name:  loadWorldFlagName,
+			Usage: "world snapshot file to start every scenario from, instead of an empty world",
+		},
+		&cli.StringFlag{
+			Name:  saveWorldFlagName,
+			Usage: "file where the world is saved after running a single scenario, to be loaded with --load-world",
+		},
+		&cli.BoolFlag{
+			Name:  decodeABIFlagName,
+			Usage: "decode values in failed checks with the contract ABIs, from .mxsc.json or .abi.json files",
+		},
+		&cli.BoolFlag{
+			Name:  dryRunFlagName,
+			Usage: "only list the scenarios that would run",
+		},
+	}
+}
+
+// watchFlags are the flags specific to the watch command.
+func watchFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.DurationFlag{
+			Name:  intervalFlagName,
+			Usage: "how often to check the scenarios and their dependencies for changes",
+			Value: time.Second,
+		},
+	}
+}
+
+// debugFlags are the flags specific to the debug command.
+func debugFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:  breakFlagName,
+			Usage: "initial breakpoint: step index, id:<step id> or fn:<function>; can be repeated",
+		},
+	}
+}
+
+// gasBaselineFlags are the flags of the run command comparing the gas used to a snapshot.
+func gasBaselineFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  gasBaselineFlagName,
+			Usage: "gas snapshot file, created with the gas-snapshot command, to check the gas used against",
+		},
+		&cli.Float64Flag{
+			Name:  gasToleranceFlagName,
+			Usage: "allowed gas increase compared to the baseline, in percent",
+			Value: 0,
+		},
+		&cli.BoolFlag{
+			Name:  gasBaselineWarnFlagName,
+			Usage: "only print the gas regressions, without failing the run",
+		},
+	}
+}
+
+// gasSnapshotFlags are the flags specific to the gas-snapshot command.
+func gasSnapshotFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    outputFlagName,
+			Aliases: []string{"o"},
+			Usage:   "file where the gas snapshot is written",
+			Value:   "gas-snapshot.json",
+		},
+	}
+}
+
+// checkStateFlags are the flags specific to the check-state command.
+func checkStateFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    outputFlagName,
+			Aliases: []string{"o"},
+			Usage:   "file where the checkState step is written",
+			Value:   "check-state.steps.json",
+		},
+		&cli.BoolFlag{
+			Name:  moreAccountsFlagName,
+			Usage: "allow accounts that are not in the generated step",
+		},
+		&cli.BoolFlag{
+			Name:  moreStorageFlagName,
+			Usage: "allow storage keys that are not in the generated step",
+		},
+		&cli.StringFlag{
+			Name:  sinceFlagName,
+			Usage: "world snapshot file, created with --save-world; accounts unchanged since are left out",
+		},
+	}
+}
+
+// applyGasBaselineFlags reads the gas baseline flags into the run options.
+func applyGasBaselineFlags(cCtx *cli.Context, options *CLIRunOptions) error {
+	options.GasBaselineFile = cCtx.String(gasBaselineFlagName)
+	options.GasTolerancePercent = cCtx.Float64(gasToleranceFlagName)
+	options.GasBaselineWarnOnly = cCtx.Bool(gasBaselineWarnFlagName)
+	if options.GasTolerancePercent < 0 {
+		return fmt.Errorf("--%s cannot be negative", gasToleranceFlagName)
+	}
+	return nil
+}
+
+// applyRunFlags reads the VM-independent flags of the run command into the run options.
+func applyRunFlags(cCtx *cli.Context, options *CLIRunOptions) error {
+	if options.RunOptions == nil {
+		options.RunOptions = scenio.DefaultRunScenarioOptions()
+	}
+	options.RunOptions.Parallelism = cCtx.Int(parallelFlagName)
+	options.RunOptions.ContinueOnCheckFailure = cCtx.Bool(continueOnFailureFlagName)
+	options.RunOptions.UpdateExpectations = cCtx.Bool(updateExpectationsFlagName)
+	options.RunOptions.LoadWorldFile = cCtx.String(loadWorldFlagName)
+	options.RunOptions.SaveWorldFile = cCtx.String(saveWorldFlagName)
+	options.RunOptions.DecodeWithABI = cCtx.Bool(decodeABIFlagName)
+	options.DryRun = cCtx.Bool(dryRunFlagName)
+
+	options.RunOptions.IncludePatterns = cCtx.StringSlice(includeFlagName)
+	options.RunOptions.ExcludePatterns = cCtx.StringSlice(excludeFlagName)
+	err := scenio.ValidateFilePatterns(options.RunOptions.IncludePatterns)
+	if err != nil {
+		return err
+	}
+	err = scenio.ValidateFilePatterns(options.RunOptions.ExcludePatterns)
+	if err != nil {
+		return err
+	}
+
+	if cCtx.IsSet(nameFlagName) {
+		nameFilter, err := regexp.Compile(cCtx.String(nameFlagName))
+		if err != nil {
+			return fmt.Errorf("bad --%s expression: %w", nameFlagName, err)
+		}
+		options.RunOptions.NameFilter = nameFilter
+	}
+	if cCtx.IsSet(stepFlagName) {
+		stepIDFilter, err := regexp.Compile(cCtx.String(stepFlagName))
+		if err != nil {
+			return fmt.Errorf("bad --%s expression: %w", stepFlagName, err)
+		}
+		options.RunOptions.StepIDFilter = stepIDFilter
+	}
+
+	options.ReportFormat, err = scenio.ParseReportFormat(cCtx.String(reportFormatFlagName))
+	if err != nil {
+		return err
+	}
+	options.ReportFile = cCtx.String(reportFileFlagName)
+	if cCtx.IsSet(reportFormatFlagName) && len(options.ReportFile) == 0 {
+		return errors.New("--report-format requires --report-file")
+	}
+
+	if cCtx.IsSet(profileFlagName) {
+		options.ProfileFormat, err = scenio.ParseProfileFormat(cCtx.String(profileFlagName))
+		if err != nil {
+			return err
+		}
+	}
+	options.ProfileFile = cCtx.String(profileFileFlagName)
+	if len(options.ProfileFile) > 0 && len(options.ProfileFormat) == 0 {
+		return fmt.Errorf("--%s requires --%s", profileFileFlagName, profileFlagName)
+	}
+
+	options.GasTraceFile = cCtx.String(gasTraceFileFlagName)
+	options.GasTraceFoldedFile = cCtx.String(gasTraceFoldedFlagName)
+	if len(options.GasTraceFile) > 0 || len(options.GasTraceFoldedFile) > 0 {
+		options.RunOptions.ForceTraceGas = true
+	}
+
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"log"
 	"os"
 
+	scenexec "github.com/multiversx/mx-chain-scenario-go/scenario/executor"
 	scenio "github.com/multiversx/mx-chain-scenario-go/scenario/io"
 
 	cli "github.com/urfave/cli/v2"
@@ -28,7 +29,7 @@ func ScenariosCLI(version string, vmFlags CLIRunConfig) {
 		{
 			Name:  "run",
 			Usage: "complete a task on the list",
-			Flags: vmFlags.GetFlags(),
+			Flags: append(append(vmFlags.GetFlags(), runFlags()...), gasBaselineFlags()...),
 			Action: func(cCtx *cli.Context) error {
 				args := cCtx.Args()
 				if args.Len() != 1 {
@@ -36,7 +37,95 @@ func ScenariosCLI(version string, vmFlags CLIRunConfig) {
 				}
 				path := cCtx.Args().First()
 
-				return RunScenariosAtPath(path, vmFlags.ParseFlags(cCtx))
+				options := vmFlags.ParseFlags(cCtx)
+				err := applyRunFlags(cCtx, &options)
+				if err != nil {
+					return err
+				}
+				err = applyGasBaselineFlags(cCtx, &options)
+				if err != nil {
+					return err
+				}
+				return RunScenariosAtPath(path, options)
+			},
+		},
+		{
+			Name:  "gas-snapshot",
+			Usage: "run scenarios and save the gas used by every transaction, as a baseline for run --gas-baseline",
+			Flags: append(append(vmFlags.GetFlags(), runFlags()...), gasSnapshotFlags()...),
+			Action: func(cCtx *cli.Context) error {
+				args := cCtx.Args()
+				if args.Len() != 1 {
+					return errors.New("one path argument required to snapshot gas")
+				}
+				path := cCtx.Args().First()
+
+				options := vmFlags.ParseFlags(cCtx)
+				err := applyRunFlags(cCtx, &options)
+				if err != nil {
+					return err
+				}
+				return GasSnapshotAtPath(path, cCtx.String(outputFlagName), options)
+			},
+		},
+		{
+			Name:  "check-state",
+			Usage: "run a scenario, then write a checkState step that the resulting world passes",
+			Flags: append(append(vmFlags.GetFlags(), runFlags()...), checkStateFlags()...),
+			Action: func(cCtx *cli.Context) error {
+				args := cCtx.Args()
+				if args.Len() != 1 {
+					return errors.New("one scenario file argument required to generate a checkState step")
+				}
+
+				options := vmFlags.ParseFlags(cCtx)
+				err := applyRunFlags(cCtx, &options)
+				if err != nil {
+					return err
+				}
+				checkOptions := scenexec.CheckStateOptions{
+					MoreAccountsAllowed: cCtx.Bool(moreAccountsFlagName),
+					MoreStorageAllowed:  cCtx.Bool(moreStorageFlagName),
+				}
+				return GenerateCheckState(args.First(), cCtx.String(outputFlagName), cCtx.String(sinceFlagName), checkOptions, options)
+			},
+		},
+		{
+			Name:  "debug",
+			Usage: "run a scenario step by step, with breakpoints, inspecting the world in between",
+			Flags: append(vmFlags.GetFlags(), debugFlags()...),
+			Action: func(cCtx *cli.Context) error {
+				args := cCtx.Args()
+				if args.Len() != 1 {
+					return errors.New("one scenario file argument required to debug")
+				}
+				options := vmFlags.ParseFlags(cCtx)
+				return DebugScenario(args.First(), cCtx.StringSlice(breakFlagName), options)
+			},
+		},
+		{
+			Name:  "watch",
+			Usage: "run all scenarios in a folder, then rerun the ones affected by file changes",
+			Flags: append(append(vmFlags.GetFlags(), runFlags()...), watchFlags()...),
+			Action: func(cCtx *cli.Context) error {
+				args := cCtx.Args()
+				if args.Len() != 1 {
+					return errors.New("one directory path argument required to watch scenarios")
+				}
+				path := cCtx.Args().First()
+
+				options := vmFlags.ParseFlags(cCtx)
+				err := applyRunFlags(cCtx, &options)
+				if err != nil {
+					return err
+				}
+				if options.DryRun {
+					return fmt.Errorf("--%s is not supported in watch mode", dryRunFlagName)
+				}
+				if len(options.RunOptions.SaveWorldFile) > 0 {
+					return fmt.Errorf("--%s is not supported in watch mode", saveWorldFlagName)
+				}
+				return WatchScenariosAtPath(path, cCtx.Duration(intervalFlagName), options)
 			},
 		},
 		{
@@ -3,9 +3,13 @@ package scenclibase
 import (
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"strings"
 
+	"github.com/TwiN/go-color"
+
 	scenexec "github.com/multiversx/mx-chain-scenario-go/scenario/executor"
 	scenio "github.com/multiversx/mx-chain-scenario-go/scenario/io"
 	scenjparse "github.com/multiversx/mx-chain-scenario-go/scenario/json/parse"
@@ -20,34 +24,283 @@ func RunScenariosAtPath(path string, options CLIRunOptions) error {
 		return err
 	}
 
-	executor := scenexec.NewScenarioExecutor(options.VMBuilder)
-	controller := &scenio.ScenarioController{
-		Executor: executor,
-		Parser: scenjparse.NewParser(
-			scenio.NewDefaultFileResolver(),
-			options.VMBuilder.GetVMType()),
+	if fi.IsDir() && len(options.RunOptions.SaveWorldFile) > 0 {
+		return fmt.Errorf("--%s requires a single scenario file", saveWorldFlagName)
+	}
+	if !fi.IsDir() {
+		err = checkSingleScenarioOptions(options)
+		if err != nil {
+			return err
+		}
+	}
+
+	controller := newScenarioController(options)
+
+	if options.DryRun {
+		return listScenariosAtPath(controller, path, fi.IsDir(), options)
+	}
+
+	report, err := runScenariosWithReport(controller, path, fi.IsDir(), options)
+	if report != nil {
+		reportErr := writeReport(report, options)
+		if reportErr != nil {
+			return reportErr
+		}
+		profileErr := writeProfile(report, options)
+		if profileErr != nil {
+			return profileErr
+		}
+		gasTraceErr := writeGasTraces(report, options)
+		if gasTraceErr != nil {
+			return gasTraceErr
+		}
+		gasBaselineErr := checkGasBaseline(report, snapshotBasePath(path, fi.IsDir()), options)
+		if err == nil {
+			err = gasBaselineErr
+		}
+	}
+
+	// print result
+	if err == nil {
+		fmt.Println("SUCCESS")
+	} else {
+		fmt.Printf("ERROR: %s\n", err.Error())
+	}
+
+	return err
+}
+
+// GasSnapshotAtPath runs the scenarios at path, like RunScenariosAtPath,
+// then saves the gas used by every transaction to a snapshot file.
+// The snapshot is not written if any scenario fails.
+func GasSnapshotAtPath(path string, snapshotPath string, options CLIRunOptions) error {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	if !fi.IsDir() {
+		err = checkSingleScenarioOptions(options)
+		if err != nil {
+			return err
+		}
 	}
 
+	controller := newScenarioController(options)
+	report, err := runScenariosWithReport(controller, path, fi.IsDir(), options)
+	if err != nil {
+		fmt.Printf("ERROR: %s\n", err.Error())
+		return fmt.Errorf("gas snapshot not written: %w", err)
+	}
+
+	snapshot := scenio.NewGasSnapshot(report, snapshotBasePath(path, fi.IsDir()))
+	err = scenio.WriteGasSnapshotFile(snapshot, snapshotPath)
+	if err != nil {
+		return fmt.Errorf("could not write gas snapshot: %w", err)
+	}
+	fmt.Printf("Gas snapshot written to: %s\n", snapshotPath)
+	return nil
+}
+
+func runScenariosWithReport(
+	controller *scenio.ScenarioController,
+	path string,
+	isDir bool,
+	options CLIRunOptions) (*scenio.ScenariosReport, error) {
+
 	switch {
-	case fi.IsDir():
-		err = controller.RunAllJSONScenariosInDirectory(
+	case isDir:
+		report, err := controller.RunAllJSONScenariosInDirectoryWithReport(
 			path,
 			"",
 			".scen.json",
 			[]string{},
 			options.RunOptions)
+		if err != nil {
+			return nil, err
+		}
+		return report, report.Err()
 	case strings.HasSuffix(path, ".scen.json"):
-		err = controller.RunSingleJSONScenario(path, options.RunOptions)
+		result := controller.RunSingleJSONScenarioWithResult(path, options.RunOptions)
+		report := &scenio.ScenariosReport{
+			Results:  []*scenio.ScenarioResult{result},
+			Duration: result.Duration,
+		}
+		return report, result.Err
 	default:
-		err = errors.New("only directories and scenario files accepted as path")
+		return nil, errors.New("only directories and scenario files accepted as path")
 	}
+}
 
-	// print result
-	if err == nil {
-		fmt.Println("SUCCESS")
-	} else {
-		fmt.Printf("ERROR: %s\n", err.Error())
+// snapshotBasePath is the directory gas snapshot keys are relative to.
+func snapshotBasePath(path string, isDir bool) string {
+	if isDir {
+		return path
 	}
+	return filepath.Dir(path)
+}
 
-	return err
+// checkGasBaseline compares the gas used with the baseline snapshot, if one was given, and prints all regressions,
+// as well as the baseline scenarios and transactions that did not run.
+// Regressions only produce an error if not in warning mode.
+// A baseline that matches no transaction at all is always an error, since it was likely made from another path.
+func checkGasBaseline(report *scenio.ScenariosReport, basePath string, options CLIRunOptions) error {
+	if len(options.GasBaselineFile) == 0 {
+		return nil
+	}
+
+	baseline, err := scenio.LoadGasSnapshot(options.GasBaselineFile)
+	if err != nil {
+		return err
+	}
+
+	current := scenio.NewGasSnapshot(report, basePath)
+	unmatched, matchedTxs := scenio.UnmatchedGasBaseline(baseline, current)
+	for _, missing := range unmatched {
+		fmt.Printf("%s %s\n", color.Ize(color.Yellow, "baseline not matched:"), missing)
+	}
+	if len(baseline) > 0 && matchedTxs == 0 {
+		return fmt.Errorf("no transaction of the gas baseline %s ran; scenario paths are relative to %s",
+			options.GasBaselineFile, basePath)
+	}
+
+	regressions := scenio.FindGasRegressions(baseline, current, options.GasTolerancePercent)
+	if len(regressions) == 0 {
+		fmt.Printf("No gas regressions compared to %s.\n", options.GasBaselineFile)
+		return nil
+	}
+
+	label := color.Ize(color.Red, "gas regression:")
+	if options.GasBaselineWarnOnly {
+		label = color.Ize(color.Yellow, "gas warning:")
+	}
+	for _, regression := range regressions {
+		fmt.Printf("%s %s\n", label, regression.String())
+	}
+	if options.GasBaselineWarnOnly {
+		return nil
+	}
+	return fmt.Errorf("%d transaction(s) exceed the gas baseline by more than %g%%",
+		len(regressions), options.GasTolerancePercent)
+}
+
+// checkSingleScenarioOptions rejects the options that select scenarios in a directory,
+// since they would be ignored when running a single scenario file.
+func checkSingleScenarioOptions(options CLIRunOptions) error {
+	switch {
+	case len(options.RunOptions.IncludePatterns) > 0:
+		return fmt.Errorf("--%s requires a directory path", includeFlagName)
+	case len(options.RunOptions.ExcludePatterns) > 0:
+		return fmt.Errorf("--%s requires a directory path", excludeFlagName)
+	case options.RunOptions.NameFilter != nil:
+		return fmt.Errorf("--%s requires a directory path", nameFlagName)
+	case options.RunOptions.StepIDFilter != nil:
+		return fmt.Errorf("--%s requires a directory path", stepFlagName)
+	default:
+		return nil
+	}
+}
+
+func newScenarioController(options CLIRunOptions) *scenio.ScenarioController {
+	return &scenio.ScenarioController{
+		Executor: scenexec.NewScenarioExecutor(options.VMBuilder),
+		RunnerFactory: func() scenio.ScenarioRunner {
+			return scenexec.NewScenarioExecutor(options.VMBuilder)
+		},
+		Parser: scenjparse.NewParser(
+			scenio.NewDefaultFileResolver(),
+			options.VMBuilder.GetVMType()),
+	}
+}
+
+func writeReport(report *scenio.ScenariosReport, options CLIRunOptions) error {
+	if len(options.ReportFile) == 0 {
+		return nil
+	}
+
+	err := scenio.WriteScenariosReportFile(report, options.ReportFormat, options.ReportFile)
+	if err != nil {
+		return fmt.Errorf("could not write test report: %w", err)
+	}
+	fmt.Printf("Report written to: %s\n", options.ReportFile)
+	return nil
+}
+
+func writeProfile(report *scenio.ScenariosReport, options CLIRunOptions) error {
+	if len(options.ProfileFormat) == 0 {
+		return nil
+	}
+
+	profile := scenio.NewScenariosProfile(report)
+	if len(options.ProfileFile) == 0 {
+		return scenio.WriteScenariosProfile(profile, options.ProfileFormat, os.Stdout)
+	}
+
+	err := writeFile(options.ProfileFile, func(writer io.Writer) error {
+		return scenio.WriteScenariosProfile(profile, options.ProfileFormat, writer)
+	})
+	if err != nil {
+		return fmt.Errorf("could not write profile: %w", err)
+	}
+	fmt.Printf("Profile written to: %s\n", options.ProfileFile)
+	return nil
+}
+
+func writeGasTraces(report *scenio.ScenariosReport, options CLIRunOptions) error {
+	if len(options.GasTraceFile) > 0 {
+		err := writeFile(options.GasTraceFile, func(writer io.Writer) error {
+			return scenio.WriteGasTracesJSON(report, writer)
+		})
+		if err != nil {
+			return fmt.Errorf("could not write gas traces: %w", err)
+		}
+		fmt.Printf("Gas traces written to: %s\n", options.GasTraceFile)
+	}
+
+	if len(options.GasTraceFoldedFile) > 0 {
+		err := writeFile(options.GasTraceFoldedFile, func(writer io.Writer) error {
+			return scenio.WriteGasTracesFolded(report, writer)
+		})
+		if err != nil {
+			return fmt.Errorf("could not write folded gas traces: %w", err)
+		}
+		fmt.Printf("Folded gas traces written to: %s\n", options.GasTraceFoldedFile)
+	}
+
+	return nil
+}
+
+func writeFile(toPath string, write func(io.Writer) error) error {
+	file, err := os.Create(toPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	return write(file)
+}
+
+func listScenariosAtPath(controller *scenio.ScenarioController, path string, isDir bool, options CLIRunOptions) error {
+	if !isDir {
+		fmt.Println(path)
+		return nil
+	}
+
+	scenarioPaths, err := controller.ListJSONScenariosInDirectory(
+		path,
+		"",
+		".scen.json",
+		[]string{},
+		options.RunOptions)
+	if err != nil {
+		return err
+	}
+
+	for _, scenarioPath := range scenarioPaths {
+		fmt.Println(scenarioPath)
+	}
+	fmt.Printf("%d scenario(s) would run.\n", len(scenarioPaths))
+	return nil
 }
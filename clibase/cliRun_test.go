@@ -0,0 +1,28 @@
+package scenclibase
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	scenio "github.com/multiversx/mx-chain-scenario-go/scenario/io"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunSingleScenarioFiltersErr(t *testing.T) {
+	scenarioPath := filepath.Join(t.TempDir(), "test.scen.json")
+	require.Nil(t, os.WriteFile(scenarioPath, []byte(`{"steps": []}`), 0644))
+
+	options := CLIRunOptions{RunOptions: scenio.DefaultRunScenarioOptions(), DryRun: true}
+	options.RunOptions.NameFilter = regexp.MustCompile("transfer")
+	require.EqualError(t, RunScenariosAtPath(scenarioPath, options), "--name requires a directory path")
+
+	options = CLIRunOptions{RunOptions: scenio.DefaultRunScenarioOptions()}
+	options.RunOptions.StepIDFilter = regexp.MustCompile("tx-1")
+	require.EqualError(t, RunScenariosAtPath(scenarioPath, options), "--step requires a directory path")
+
+	options = CLIRunOptions{RunOptions: scenio.DefaultRunScenarioOptions()}
+	options.RunOptions.IncludePatterns = []string{"*.scen.json"}
+	require.EqualError(t, GasSnapshotAtPath(scenarioPath, "gas.json", options), "--include requires a directory path")
+}
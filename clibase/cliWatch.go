@@ -0,0 +1,211 @@
+package scenclibase
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	scenio "github.com/multiversx/mx-chain-scenario-go/scenario/io"
+)
+
+// scenarioWatcher keeps track of the files each scenario in a directory depends on,
+// so that only the scenarios affected by a change get rerun.
+type scenarioWatcher struct {
+	controller   *scenio.ScenarioController
+	dirPath      string
+	options      CLIRunOptions
+	dependencies map[string][]string
+	modTimes     map[string]time.Time
+}
+
+func newScenarioWatcher(dirPath string, options CLIRunOptions) *scenarioWatcher {
+	return &scenarioWatcher{
+		controller:   newScenarioController(options),
+		dirPath:      dirPath,
+		options:      options,
+		dependencies: make(map[string][]string),
+		modTimes:     make(map[string]time.Time),
+	}
+}
+
+// WatchScenariosAtPath runs all scenarios in a directory,
+// then polls their dependencies and reruns the scenarios whose inputs changed.
+// It only returns if the directory cannot be read.
+func WatchScenariosAtPath(dirPath string, interval time.Duration, options CLIRunOptions) error {
+	fi, err := os.Stat(dirPath)
+	if err != nil {
+		return err
+	}
+	if !fi.IsDir() {
+		return errors.New("watch mode requires a directory path")
+	}
+
+	watcher := newScenarioWatcher(dirPath, options)
+	fmt.Printf("Watching %s for changes ...\n", dirPath)
+	for {
+		err = watcher.cycle()
+		if err != nil {
+			return err
+		}
+		time.Sleep(interval)
+	}
+}
+
+// cycle reruns the scenarios that are new, or whose dependencies changed since the last cycle.
+func (w *scenarioWatcher) cycle() error {
+	scenarioPaths, err := w.controller.ListJSONScenariosInDirectory(
+		w.dirPath,
+		"",
+		".scen.json",
+		[]string{},
+		w.options.RunOptions)
+	if err != nil {
+		return err
+	}
+
+	currentModTimes := make(map[string]time.Time, len(w.modTimes))
+	var changedFiles []string
+	for filePath, modTime := range w.modTimes {
+		currentModTimes[filePath] = fileModTime(filePath)
+		if !currentModTimes[filePath].Equal(modTime) {
+			changedFiles = append(changedFiles, filePath)
+		}
+	}
+	sort.Strings(changedFiles)
+
+	affected := w.affectedScenarios(scenarioPaths, changedFiles)
+	if len(affected) == 0 {
+		return nil
+	}
+
+	if len(changedFiles) > 0 {
+		fmt.Printf("\n[%s] changed: %s\n", time.Now().Format("15:04:05"), w.describeFiles(changedFiles))
+	}
+	rerunDependencies := w.readDependencies(affected, currentModTimes)
+	report, err := w.controller.RunJSONScenariosWithReport(w.dirPath, affected, w.options.RunOptions)
+	if err != nil {
+		return err
+	}
+	reportErr := writeReport(report, w.options)
+	if reportErr != nil {
+		fmt.Printf("ERROR: %s\n", reportErr.Error())
+	}
+	profileErr := writeProfile(report, w.options)
+	if profileErr != nil {
+		fmt.Printf("ERROR: %s\n", profileErr.Error())
+	}
+	gasTraceErr := writeGasTraces(report, w.options)
+	if gasTraceErr != nil {
+		fmt.Printf("ERROR: %s\n", gasTraceErr.Error())
+	}
+	fmt.Printf("[%s] reran %d of %d scenario(s): %d passed, %d failed.\n",
+		time.Now().Format("15:04:05"),
+		len(affected),
+		len(scenarioPaths),
+		report.CountStatus(scenio.ScenarioPassed),
+		report.CountStatus(scenio.ScenarioFailed))
+
+	w.updateDependencies(scenarioPaths, rerunDependencies, currentModTimes)
+	return nil
+}
+
+// affectedScenarios selects the scenarios not seen before, and the ones depending on any of the changed files.
+func (w *scenarioWatcher) affectedScenarios(scenarioPaths []string, changedFiles []string) []string {
+	changed := make(map[string]struct{}, len(changedFiles))
+	for _, filePath := range changedFiles {
+		changed[filePath] = struct{}{}
+	}
+
+	var affected []string
+	for _, scenarioPath := range scenarioPaths {
+		dependencies, known := w.dependencies[scenarioPath]
+		if !known {
+			affected = append(affected, scenarioPath)
+			continue
+		}
+		for _, dependency := range dependencies {
+			if _, isChanged := changed[dependency]; isChanged {
+				affected = append(affected, scenarioPath)
+				break
+			}
+		}
+	}
+	return affected
+}
+
+// readDependencies finds the dependencies of the scenarios about to be rerun,
+// and reads the modification times of the ones not tracked yet into modTimes.
+// This happens before the run, so that changes made during the run are picked up in the next cycle.
+func (w *scenarioWatcher) readDependencies(rerun []string, modTimes map[string]time.Time) map[string][]string {
+	rerunDependencies := make(map[string][]string, len(rerun))
+	for _, scenarioPath := range rerun {
+		// on parse errors the partial dependencies are enough: the scenario reruns once any of them is fixed
+		dependencies, _ := w.controller.ScenarioDependencies(scenarioPath)
+		rerunDependencies[scenarioPath] = dependencies
+		for _, dependency := range dependencies {
+			if _, alreadyRead := modTimes[dependency]; !alreadyRead {
+				modTimes[dependency] = fileModTime(dependency)
+			}
+		}
+	}
+	return rerunDependencies
+}
+
+// updateDependencies replaces the dependencies of the rerun scenarios, forgets the removed scenarios,
+// and tracks the modification times of all remaining dependencies, as read before the run.
+func (w *scenarioWatcher) updateDependencies(
+	scenarioPaths []string,
+	rerunDependencies map[string][]string,
+	modTimes map[string]time.Time) {
+
+	for scenarioPath, dependencies := range rerunDependencies {
+		w.dependencies[scenarioPath] = dependencies
+	}
+
+	present := make(map[string]struct{}, len(scenarioPaths))
+	for _, scenarioPath := range scenarioPaths {
+		present[scenarioPath] = struct{}{}
+	}
+
+	w.modTimes = make(map[string]time.Time)
+	for scenarioPath, dependencies := range w.dependencies {
+		if _, isPresent := present[scenarioPath]; !isPresent {
+			delete(w.dependencies, scenarioPath)
+			continue
+		}
+		for _, dependency := range dependencies {
+			w.modTimes[dependency] = modTimes[dependency]
+		}
+	}
+}
+
+// describeFiles lists file paths relative to the watched directory, where possible.
+func (w *scenarioWatcher) describeFiles(filePaths []string) string {
+	absDirPath, err := filepath.Abs(w.dirPath)
+	if err != nil {
+		return strings.Join(filePaths, ", ")
+	}
+
+	var names []string
+	for _, filePath := range filePaths {
+		relPath, err := filepath.Rel(absDirPath, filePath)
+		if err != nil {
+			relPath = filePath
+		}
+		names = append(names, relPath)
+	}
+	return strings.Join(names, ", ")
+}
+
+// fileModTime yields the zero time for missing files, so that creating them also counts as a change.
+func fileModTime(filePath string) time.Time {
+	fi, err := os.Stat(filePath)
+	if err != nil {
+		return time.Time{}
+	}
+	return fi.ModTime()
+}
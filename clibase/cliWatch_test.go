@@ -0,0 +1,101 @@
+package scenclibase
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	scenio "github.com/multiversx/mx-chain-scenario-go/scenario/io"
+	"github.com/stretchr/testify/require"
+)
+
+const watchTestScenario = `{
+    "steps": [
+        {
+            "step": "setState",
+            "accounts": {
+                "sc:contract": {
+                    "nonce": "0",
+                    "balance": "0",
+                    "code": "file:contract.wasm"
+                }
+            }
+        }
+    ]
+}`
+
+func newTestScenarioWatcher(dirPath string) *scenarioWatcher {
+	return &scenarioWatcher{
+		controller:   scenio.NewScenarioController(nil, scenio.NewDefaultFileResolver(), []byte{0, 0}),
+		dirPath:      dirPath,
+		options:      CLIRunOptions{},
+		dependencies: make(map[string][]string),
+		modTimes:     make(map[string]time.Time),
+	}
+}
+
+func TestWatchAffectedScenarios(t *testing.T) {
+	watcher := newTestScenarioWatcher("")
+	watcher.dependencies["a.scen.json"] = []string{"/a.scen.json", "/shared.wasm"}
+	watcher.dependencies["b.scen.json"] = []string{"/b.scen.json"}
+
+	scenarioPaths := []string{"a.scen.json", "b.scen.json", "c.scen.json"}
+	require.Equal(t, []string{"c.scen.json"}, watcher.affectedScenarios(scenarioPaths, nil))
+	require.Equal(t,
+		[]string{"a.scen.json", "c.scen.json"},
+		watcher.affectedScenarios(scenarioPaths, []string{"/shared.wasm"}))
+	require.Equal(t,
+		[]string{"a.scen.json", "b.scen.json", "c.scen.json"},
+		watcher.affectedScenarios(scenarioPaths, []string{"/b.scen.json", "/shared.wasm"}))
+}
+
+func TestWatchUpdateDependencies(t *testing.T) {
+	modTime := time.Unix(1000, 0)
+	watcher := newTestScenarioWatcher("")
+	watcher.dependencies["a.scen.json"] = []string{"/a.scen.json", "/old.wasm"}
+	watcher.dependencies["removed.scen.json"] = []string{"/removed.scen.json"}
+	watcher.dependencies["b.scen.json"] = []string{"/b.scen.json"}
+
+	watcher.updateDependencies(
+		[]string{"a.scen.json", "b.scen.json"},
+		map[string][]string{"a.scen.json": {"/a.scen.json", "/new.wasm"}},
+		map[string]time.Time{
+			"/a.scen.json":       modTime,
+			"/b.scen.json":       modTime,
+			"/new.wasm":          modTime,
+			"/old.wasm":          modTime,
+			"/removed.scen.json": modTime,
+		})
+
+	require.Equal(t, map[string][]string{
+		"a.scen.json": {"/a.scen.json", "/new.wasm"},
+		"b.scen.json": {"/b.scen.json"},
+	}, watcher.dependencies)
+	require.Equal(t, map[string]time.Time{
+		"/a.scen.json": modTime,
+		"/b.scen.json": modTime,
+		"/new.wasm":    modTime,
+	}, watcher.modTimes)
+}
+
+func TestWatchChangeDuringRun(t *testing.T) {
+	dirPath := t.TempDir()
+	scenarioPath := filepath.Join(dirPath, "a.scen.json")
+	codePath := filepath.Join(dirPath, "contract.wasm")
+	require.Nil(t, os.WriteFile(scenarioPath, []byte(watchTestScenario), 0644))
+	require.Nil(t, os.WriteFile(codePath, []byte("code"), 0644))
+	require.Nil(t, os.Chtimes(codePath, time.Unix(1000, 0), time.Unix(1000, 0)))
+
+	watcher := newTestScenarioWatcher(dirPath)
+	modTimes := make(map[string]time.Time)
+	rerunDependencies := watcher.readDependencies([]string{scenarioPath}, modTimes)
+	require.Contains(t, rerunDependencies[scenarioPath], codePath)
+
+	// the contract is rebuilt while the scenario runs
+	require.Nil(t, os.Chtimes(codePath, time.Unix(2000, 0), time.Unix(2000, 0)))
+
+	watcher.updateDependencies([]string{scenarioPath}, rerunDependencies, modTimes)
+	require.Equal(t, time.Unix(1000, 0), watcher.modTimes[codePath])
+	require.NotEqual(t, fileModTime(codePath), watcher.modTimes[codePath])
+}
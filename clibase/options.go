@@ -11,6 +11,30 @@ import (
 type CLIRunOptions struct {
 	RunOptions *scenio.RunScenarioOptions
 	VMBuilder  scenexec.VMBuilder
+
+	// ReportFormat and ReportFile configure the structured test report. No report is written if ReportFile is empty.
+	ReportFormat scenio.ReportFormat
+	ReportFile   string
+
+	// ProfileFormat enables the transaction profile, when not empty.
+	// The profile goes to ProfileFile, or to the standard output if ProfileFile is empty.
+	ProfileFormat scenio.ProfileFormat
+	ProfileFile   string
+
+	// GasTraceFile and GasTraceFoldedFile receive the VM gas traces, as JSON and as folded stacks.
+	// Setting either of them turns gas tracing on for all scenarios.
+	GasTraceFile       string
+	GasTraceFoldedFile string
+
+	// GasBaselineFile is a gas snapshot to compare the gas used against, if not empty.
+	// Transactions using more than GasTolerancePercent above the baseline fail the run,
+	// or are only reported, if GasBaselineWarnOnly is set.
+	GasBaselineFile     string
+	GasTolerancePercent float64
+	GasBaselineWarnOnly bool
+
+	// DryRun only lists the scenarios that would run.
+	DryRun bool
 }
 
 // CLIRunConfig prepares and interprets CLI flags required to run scenarios at a path.
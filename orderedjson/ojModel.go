@@ -50,6 +50,38 @@ func (j *OJsonMap) Put(key string, value OJsonObject) {
 	}
 }
 
+// Get yields the value of a key, or nil if the key is not in the map.
+func (j *OJsonMap) Get(key string) OJsonObject {
+	for _, kv := range j.OrderedKV {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return nil
+}
+
+// Set replaces the value of a key, keeping its position. New keys are added at the end.
+func (j *OJsonMap) Set(key string, value OJsonObject) {
+	for _, kv := range j.OrderedKV {
+		if kv.Key == key {
+			kv.Value = value
+			return
+		}
+	}
+	j.Put(key, value)
+}
+
+// Remove deletes a key from the map. Does nothing if the key is not in the map.
+func (j *OJsonMap) Remove(key string) {
+	for i, kv := range j.OrderedKV {
+		if kv.Key == key {
+			j.OrderedKV = append(j.OrderedKV[:i], j.OrderedKV[i+1:]...)
+			delete(j.KeySet, key)
+			return
+		}
+	}
+}
+
 // Size yields the size of ordered map.
 func (j *OJsonMap) Size() int {
 	return len(j.OrderedKV)
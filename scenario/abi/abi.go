@@ -0,0 +1,185 @@
+package scenabi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ABI describes the endpoints, events and custom types of a contract, as in the .abi.json files.
+// Only the parts needed to encode and decode values are kept.
+type ABI struct {
+	Name        string                      `json:"name"`
+	Constructor *Endpoint                   `json:"constructor"`
+	Endpoints   []*Endpoint                 `json:"endpoints"`
+	Events      []*Event                    `json:"events"`
+	Types       map[string]*TypeDescription `json:"types"`
+}
+
+// Endpoint is a contract endpoint, or the constructor.
+type Endpoint struct {
+	Name       string   `json:"name"`
+	Mutability string   `json:"mutability"`
+	Inputs     []*Param `json:"inputs"`
+	Outputs    []*Param `json:"outputs"`
+}
+
+// Param is an endpoint input or output.
+type Param struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	MultiArg    bool   `json:"multi_arg"`
+	MultiResult bool   `json:"multi_result"`
+}
+
+// Event is an event the contract can emit.
+// Indexed inputs go to the log topics, the others to the log data.
+type Event struct {
+	Identifier string        `json:"identifier"`
+	Inputs     []*EventInput `json:"inputs"`
+}
+
+// EventInput is one of the values of an event.
+type EventInput struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Indexed bool   `json:"indexed"`
+}
+
+// TypeDescription describes a custom struct or enum type.
+type TypeDescription struct {
+	Type     string     `json:"type"`
+	Fields   []*Field   `json:"fields"`
+	Variants []*Variant `json:"variants"`
+}
+
+// Field is a struct field, or a field of an enum variant.
+type Field struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// Variant is an enum variant. Variants without fields are the only ones allowed in explicit enums.
+type Variant struct {
+	Name         string   `json:"name"`
+	Discriminant int      `json:"discriminant"`
+	Fields       []*Field `json:"fields"`
+}
+
+const (
+	// TypeStruct is the TypeDescription type of structs.
+	TypeStruct = "struct"
+
+	// TypeEnum is the TypeDescription type of enums.
+	TypeEnum = "enum"
+
+	// TypeExplicitEnum is the TypeDescription type of enums encoded by variant name.
+	TypeExplicitEnum = "explicit-enum"
+)
+
+// ParseABI parses the contents of an .abi.json file.
+func ParseABI(data []byte) (*ABI, error) {
+	abi := &ABI{}
+	err := json.Unmarshal(data, abi)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ABI: %w", err)
+	}
+	for typeName, typeDescription := range abi.Types {
+		if typeDescription.Type != TypeStruct &&
+			typeDescription.Type != TypeEnum &&
+			typeDescription.Type != TypeExplicitEnum {
+			return nil, fmt.Errorf("invalid ABI: type %s has unknown kind \"%s\"", typeName, typeDescription.Type)
+		}
+	}
+	return abi, nil
+}
+
+// ParseMxscABI extracts the ABI from the contents of an .mxsc.json file.
+// Returns nil without an error if the file contains no ABI.
+func ParseMxscABI(data []byte) (*ABI, error) {
+	mxsc := struct {
+		ABI json.RawMessage `json:"abi"`
+	}{}
+	err := json.Unmarshal(data, &mxsc)
+	if err != nil {
+		return nil, err
+	}
+	if len(mxsc.ABI) == 0 || string(mxsc.ABI) == "null" {
+		return nil, nil
+	}
+	return ParseABI(mxsc.ABI)
+}
+
+// Endpoint yields the endpoint with the given name, or nil if there is none.
+func (abi *ABI) Endpoint(name string) *Endpoint {
+	for _, endpoint := range abi.Endpoints {
+		if endpoint.Name == name {
+			return endpoint
+		}
+	}
+	return nil
+}
+
+// Event yields the event with the given identifier, or nil if there is none.
+func (abi *ABI) Event(identifier string) *Event {
+	for _, event := range abi.Events {
+		if event.Identifier == identifier {
+			return event
+		}
+	}
+	return nil
+}
+
+// CustomType yields the description of a custom type, or nil if it is not a custom type.
+// Works on nil ABIs too, which have no custom types.
+func (abi *ABI) CustomType(name string) *TypeDescription {
+	if abi == nil {
+		return nil
+	}
+	return abi.Types[name]
+}
+
+// VariantByDiscriminant yields the enum variant with the given discriminant.
+func (td *TypeDescription) VariantByDiscriminant(discriminant int) (*Variant, error) {
+	for _, variant := range td.Variants {
+		if variant.Discriminant == discriminant {
+			return variant, nil
+		}
+	}
+	return nil, fmt.Errorf("no variant with discriminant %d", discriminant)
+}
+
+// VariantByName yields the enum variant with the given name.
+func (td *TypeDescription) VariantByName(name string) (*Variant, error) {
+	for _, variant := range td.Variants {
+		if variant.Name == name {
+			return variant, nil
+		}
+	}
+	return nil, fmt.Errorf("no variant named \"%s\"", name)
+}
+
+// IndexedInputs yields the event inputs that go to the log topics.
+func (event *Event) IndexedInputs() []*Param {
+	return event.inputs(true)
+}
+
+// DataInputs yields the event inputs that go to the log data.
+func (event *Event) DataInputs() []*Param {
+	return event.inputs(false)
+}
+
+func (event *Event) inputs(indexed bool) []*Param {
+	var params []*Param
+	for _, input := range event.Inputs {
+		if input.Indexed == indexed {
+			params = append(params, &Param{
+				Name: input.Name,
+				Type: input.Type,
+			})
+		}
+	}
+	return params
+}
+
+var errEmptyType = errors.New("empty type")
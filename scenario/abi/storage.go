@@ -0,0 +1,53 @@
+package scenabi
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// StorageView is a view that reads a storage entry, used to find out the type of stored values.
+type StorageView struct {
+	// View is the endpoint returning the stored value.
+	View *Endpoint
+
+	// KeyArgs are the bytes of the storage key after the storage name, normally the nested view inputs.
+	KeyArgs []byte
+}
+
+// StorageViews yields the views that could read a storage key, the most specific first.
+// ABIs do not describe the storage layout, so storage entries are matched by convention:
+// a readonly endpoint "getFoo" or "foo", with a single output, reads the values stored under "foo",
+// followed by its inputs.
+func (abi *ABI) StorageViews(key []byte) []*StorageView {
+	var views []*StorageView
+	for _, endpoint := range abi.Endpoints {
+		if endpoint.Mutability != "readonly" || len(endpoint.Outputs) != 1 {
+			continue
+		}
+		for _, storageName := range storageNames(endpoint.Name) {
+			if !strings.HasPrefix(string(key), storageName) {
+				continue
+			}
+			views = append(views, &StorageView{
+				View:    endpoint,
+				KeyArgs: key[len(storageName):],
+			})
+			break
+		}
+	}
+	sort.SliceStable(views, func(i, j int) bool {
+		return len(views[i].KeyArgs) < len(views[j].KeyArgs)
+	})
+	return views
+}
+
+// storageNames yields the storage names a view might read.
+func storageNames(viewName string) []string {
+	names := []string{viewName}
+	trimmed := strings.TrimPrefix(viewName, "get")
+	if len(trimmed) < len(viewName) && len(trimmed) > 0 && unicode.IsUpper(rune(trimmed[0])) {
+		names = append(names, strings.ToLower(trimmed[:1])+trimmed[1:])
+	}
+	return names
+}
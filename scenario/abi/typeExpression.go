@@ -0,0 +1,182 @@
+package scenabi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// TypeExpression is a parsed ABI type name, e.g. "List<Option<u32>>" or "tuple<u8,BigUint>".
+type TypeExpression struct {
+	Name string
+	Args []*TypeExpression
+}
+
+// ParseTypeExpression parses an ABI type name.
+func ParseTypeExpression(typeName string) (*TypeExpression, error) {
+	expr, rest, err := parseTypeExpression(typeName)
+	if err != nil {
+		return nil, fmt.Errorf("bad type \"%s\": %w", typeName, err)
+	}
+	if len(strings.TrimSpace(rest)) > 0 {
+		return nil, fmt.Errorf("bad type \"%s\": unexpected \"%s\"", typeName, rest)
+	}
+	err = expr.checkArgs()
+	if err != nil {
+		return nil, fmt.Errorf("bad type \"%s\": %w", typeName, err)
+	}
+	return expr, nil
+}
+
+// checkArgs makes sure that the generic types have as many type arguments as they need.
+func (te *TypeExpression) checkArgs() error {
+	_, isArray := te.ArrayLength()
+	switch {
+	case isArray, singleArgTypes[te.Name]:
+		if len(te.Args) != 1 {
+			return fmt.Errorf("%s needs 1 type argument", te.Name)
+		}
+	case te.Name == "tuple" || te.Name == "multi":
+		if len(te.Args) == 0 {
+			return fmt.Errorf("%s needs type arguments", te.Name)
+		}
+	}
+	for _, arg := range te.Args {
+		err := arg.checkArgs()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var singleArgTypes = map[string]bool{
+	"List":             true,
+	"Vec":              true,
+	"ManagedVec":       true,
+	"Option":           true,
+	"variadic":         true,
+	"optional":         true,
+	"counted-variadic": true,
+}
+
+func parseTypeExpression(s string) (*TypeExpression, string, error) {
+	s = strings.TrimSpace(s)
+	end := strings.IndexAny(s, "<>,")
+	if end < 0 {
+		end = len(s)
+	}
+	name := strings.TrimSpace(s[:end])
+	if len(name) == 0 {
+		return nil, s, errEmptyType
+	}
+
+	expr := &TypeExpression{Name: name}
+	rest := s[end:]
+	if !strings.HasPrefix(rest, "<") {
+		return expr, rest, nil
+	}
+
+	rest = rest[1:]
+	for {
+		arg, afterArg, err := parseTypeExpression(rest)
+		if err != nil {
+			return nil, rest, err
+		}
+		expr.Args = append(expr.Args, arg)
+		afterArg = strings.TrimSpace(afterArg)
+		switch {
+		case strings.HasPrefix(afterArg, ","):
+			rest = afterArg[1:]
+		case strings.HasPrefix(afterArg, ">"):
+			return expr, afterArg[1:], nil
+		default:
+			return nil, afterArg, fmt.Errorf("missing \">\" after %s", name)
+		}
+	}
+}
+
+// String yields the ABI type name.
+func (te *TypeExpression) String() string {
+	if len(te.Args) == 0 {
+		return te.Name
+	}
+	args := make([]string, len(te.Args))
+	for i, arg := range te.Args {
+		args[i] = arg.String()
+	}
+	return te.Name + "<" + strings.Join(args, ",") + ">"
+}
+
+// ArrayLength yields the length of fixed size array types, e.g. 32 for "array32<u8>".
+func (te *TypeExpression) ArrayLength() (int, bool) {
+	if !strings.HasPrefix(te.Name, "array") || len(te.Args) != 1 {
+		return 0, false
+	}
+	length, err := strconv.Atoi(te.Name[len("array"):])
+	if err != nil {
+		return 0, false
+	}
+	return length, true
+}
+
+// IsMultiValue is true for types that span several arguments or results, such as variadic<T>.
+func (te *TypeExpression) IsMultiValue() bool {
+	switch te.Name {
+	case "variadic", "optional", "multi", "counted-variadic":
+		return true
+	}
+	return false
+}
+
+// NumberWidth yields the size of fixed width numbers and whether they are signed.
+// The last result is false for all other types.
+func (te *TypeExpression) NumberWidth() (int, bool, bool) {
+	switch te.Name {
+	case "u8":
+		return 1, false, true
+	case "u16":
+		return 2, false, true
+	case "u32", "usize":
+		return 4, false, true
+	case "u64":
+		return 8, false, true
+	case "i8":
+		return 1, true, true
+	case "i16":
+		return 2, true, true
+	case "i32", "isize":
+		return 4, true, true
+	case "i64":
+		return 8, true, true
+	}
+	return 0, false, false
+}
+
+// IsBytes is true for the types encoded as byte arrays of variable length.
+func (te *TypeExpression) IsBytes() bool {
+	switch te.Name {
+	case "ManagedBuffer", "BoxedBytes", "bytes", "utf-8 string", "TokenIdentifier", "EgldOrEsdtTokenIdentifier":
+		return true
+	}
+	return te.IsList() && te.Args[0].Name == "u8"
+}
+
+// IsAddress is true for the 32 byte address types.
+func (te *TypeExpression) IsAddress() bool {
+	return te.Name == "Address" || te.Name == "ManagedAddress"
+}
+
+// IsList is true for the list types, which are all encoded the same way.
+func (te *TypeExpression) IsList() bool {
+	return te.Name == "List" || te.Name == "Vec" || te.Name == "ManagedVec"
+}
+
+// Repeat yields the type the given number of times, for the items of lists and arrays.
+func (te *TypeExpression) Repeat(count int) []*TypeExpression {
+	types := make([]*TypeExpression, count)
+	for i := range types {
+		types[i] = te
+	}
+	return types
+}
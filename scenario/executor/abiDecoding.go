@@ -0,0 +1,131 @@
+package scenexec
+
+import (
+	"fmt"
+
+	scenabi "github.com/multiversx/mx-chain-scenario-go/scenario/abi"
+	scenmodel "github.com/multiversx/mx-chain-scenario-go/scenario/model"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+// decodingABI yields the ABI of the contract at an address, if ABI decoding is enabled.
+func (ae *ScenarioExecutor) decodingABI(address []byte) *scenabi.ABI {
+	if !ae.decodeWithABI {
+		return nil
+	}
+	return ae.contractABI(address)
+}
+
+// decodedStorage describes a storage entry of a contract as typed values, e.g. "balance(address:alice) = 5".
+// Yields an empty string if the entry cannot be decoded.
+func (ae *ScenarioExecutor) decodedStorage(address []byte, key []byte, value []byte) string {
+	abi := ae.decodingABI(address)
+	if abi == nil {
+		return ""
+	}
+	for _, storageView := range abi.StorageViews(key) {
+		decodedValue, err := ae.exprReconstructor.ReconstructTyped(value, storageView.View.Outputs[0].Type, abi)
+		if err != nil {
+			continue
+		}
+		storageName := string(key[:len(key)-len(storageView.KeyArgs)])
+		if len(storageView.View.Inputs) == 0 {
+			if len(storageView.KeyArgs) > 0 {
+				continue
+			}
+			return fmt.Sprintf("%s = %s", storageName, decodedValue)
+		}
+		decodedArgs, err := ae.exprReconstructor.ReconstructTypedNested(storageView.KeyArgs, storageView.View.Inputs, abi)
+		if err != nil {
+			continue
+		}
+		return fmt.Sprintf("%s(%s) = %s", storageName, decodedArgs, decodedValue)
+	}
+	return ""
+}
+
+// decodedResults describes the data returned by a transaction as typed values, based on the endpoint outputs.
+func (ae *ScenarioExecutor) decodedResults(tx *scenmodel.Transaction, returnData [][]byte) string {
+	if !ae.decodeWithABI {
+		return ""
+	}
+	abi, endpoint := ae.txEndpoint(tx)
+	if endpoint == nil {
+		return ""
+	}
+
+	decoded, err := ae.exprReconstructor.ReconstructTypedList(returnData, endpoint.Outputs, abi)
+	if err != nil {
+		return ""
+	}
+	return decoded
+}
+
+// decodedArguments describes the arguments of a call to a contract as typed values, based on the endpoint inputs.
+func (ae *ScenarioExecutor) decodedArguments(to []byte, function string, arguments [][]byte) string {
+	abi := ae.decodingABI(to)
+	if abi == nil {
+		return ""
+	}
+	endpoint := abi.Endpoint(function)
+	if endpoint == nil {
+		return ""
+	}
+
+	decoded, err := ae.exprReconstructor.ReconstructTypedList(arguments, endpoint.Inputs, abi)
+	if err != nil {
+		return ""
+	}
+	return decoded
+}
+
+// decodedLog describes an event as typed values, e.g. "deposit, topics: [caller: address:alice], data: [amount: 5]".
+// Event data is decoded either as one value per data entry, or as a single entry with all the values nested.
+func (ae *ScenarioExecutor) decodedLog(outputLog *vmcommon.LogEntry) string {
+	abi := ae.decodingABI(outputLog.Address)
+	if abi == nil {
+		return ""
+	}
+	event := abi.Event(string(outputLog.Identifier))
+	if event == nil {
+		return ""
+	}
+
+	decodedTopics, err := ae.exprReconstructor.ReconstructTypedList(outputLog.Topics, event.IndexedInputs(), abi)
+	if err != nil {
+		return ""
+	}
+
+	dataInputs := event.DataInputs()
+	var decodedData string
+	switch {
+	case len(outputLog.Data) == len(dataInputs):
+		decodedData, err = ae.exprReconstructor.ReconstructTypedList(outputLog.Data, dataInputs, abi)
+	case len(outputLog.Data) == 1:
+		decodedData, err = ae.exprReconstructor.ReconstructTypedNested(outputLog.Data[0], dataInputs, abi)
+		decodedData = "[" + decodedData + "]"
+	default:
+		return ""
+	}
+	if err != nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%s, topics: %s, data: %s", event.Identifier, decodedTopics, decodedData)
+}
+
+// decodedSuffix adds decoded values to an error message, if there are any.
+func decodedSuffix(decoded string) string {
+	if len(decoded) == 0 {
+		return ""
+	}
+	return ". Decoded: " + decoded
+}
+
+// decodedLine adds decoded values to an error message ending in a JSON block, if there are any.
+func decodedLine(decoded string) string {
+	if len(decoded) == 0 {
+		return ""
+	}
+	return "\nDecoded: " + decoded
+}
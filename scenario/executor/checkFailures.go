@@ -0,0 +1,98 @@
+package scenexec
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	scenio "github.com/multiversx/mx-chain-scenario-go/scenario/io"
+)
+
+// checkFailure marks errors caused by expectations not being met,
+// as opposed to errors that prevent the scenario from being executed further.
+type checkFailure struct {
+	err error
+}
+
+func (cf *checkFailure) Error() string {
+	return cf.err.Error()
+}
+
+func (cf *checkFailure) Unwrap() error {
+	return cf.err
+}
+
+func newCheckFailure(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &checkFailure{err: err}
+}
+
+func isCheckFailure(err error) bool {
+	var cf *checkFailure
+	return errors.As(err, &cf)
+}
+
+// CheckFailuresError combines all check failures collected while running a scenario
+// in continue-after-failure mode.
+type CheckFailuresError struct {
+	Failures []*scenio.StepError
+}
+
+// Error lists all failures, one per line, each with the step where it occurred.
+func (cfe *CheckFailuresError) Error() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%d check failure(s):", len(cfe.Failures)))
+	for _, failure := range cfe.Failures {
+		sb.WriteString("\n  ")
+		sb.WriteString(describeStep(failure))
+		sb.WriteString(": ")
+		sb.WriteString(strings.ReplaceAll(failure.Error(), "\n", "\n  "))
+	}
+	return sb.String()
+}
+
+func describeStep(stepErr *scenio.StepError) string {
+	if len(stepErr.StepID) > 0 {
+		return fmt.Sprintf("step %d (%s \"%s\")", stepErr.StepIndex, stepErr.StepType, stepErr.StepID)
+	}
+	return fmt.Sprintf("step %d (%s)", stepErr.StepIndex, stepErr.StepType)
+}
+
+// CheckFailures yields the check failures collected so far in continue-after-failure mode.
+func (ae *ScenarioExecutor) CheckFailures() []*scenio.StepError {
+	return ae.checkFailures
+}
+
+// handleStepError decides whether the scenario can go on after a step error.
+// Only check failures are recorded and skipped, and only in continue-after-failure mode.
+func (ae *ScenarioExecutor) handleStepError(stepErr *scenio.StepError) (shouldContinue bool) {
+	if ae.continueOnCheckFailure && isCheckFailure(stepErr.Err) {
+		ae.checkFailures = append(ae.checkFailures, stepErr)
+		return true
+	}
+	return false
+}
+
+// collectedCheckFailuresError combines the recorded check failures with the error that stopped execution, if any.
+func (ae *ScenarioExecutor) collectedCheckFailuresError(fatalErr *scenio.StepError) error {
+	failures := ae.checkFailures
+	if fatalErr != nil {
+		failures = append(failures, fatalErr)
+	}
+	if len(failures) == 0 {
+		return nil
+	}
+	if len(failures) == 1 {
+		return failures[0]
+	}
+
+	first := failures[0]
+	return &scenio.StepError{
+		StepIndex: first.StepIndex,
+		StepID:    first.StepID,
+		StepType:  first.StepType,
+		Err:       &CheckFailuresError{Failures: failures},
+	}
+}
@@ -0,0 +1,239 @@
+package scenexec
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	er "github.com/multiversx/mx-chain-scenario-go/scenario/expression/reconstructor"
+	scenio "github.com/multiversx/mx-chain-scenario-go/scenario/io"
+	scenmodel "github.com/multiversx/mx-chain-scenario-go/scenario/model"
+	worldmock "github.com/multiversx/mx-chain-scenario-go/worldmock"
+)
+
+var codeFilePrefixes = []string{"mxsc:", "file:"}
+
+// CheckStateOptions configures how a checkState step is generated from the world.
+type CheckStateOptions struct {
+	// MoreAccountsAllowed adds the "+" wildcard to the accounts.
+	MoreAccountsAllowed bool
+
+	// MoreStorageAllowed adds the "+" wildcard to the storage of each account.
+	MoreStorageAllowed bool
+
+	// IgnoreESDT checks no ESDT tokens, instead of all of them.
+	IgnoreESDT bool
+
+	// CodeBase is the directory that code file paths are relative to.
+	// Code loaded from files is written as a file path only if set, and as hex otherwise.
+	CodeBase string
+
+	// Baseline, if set, holds the accounts of a previous state.
+	// Accounts that have not changed since are omitted, which implies MoreAccountsAllowed.
+	Baseline worldmock.AccountMap
+}
+
+// recordCodePath remembers which file some code was loaded from, so it can be referenced when generating checks.
+func (ae *ScenarioExecutor) recordCodePath(code scenmodel.JSONBytesFromString) {
+	if len(code.Value) == 0 || ae.fileResolver == nil {
+		return
+	}
+	for _, prefix := range codeFilePrefixes {
+		if strings.HasPrefix(code.Original, prefix) {
+			codePath := ae.fileResolver.ResolveAbsolutePath(code.Original[len(prefix):])
+			ae.codePaths[string(code.Value)] = prefix + codePath
+			return
+		}
+	}
+}
+
+// GenerateCheckStateStep converts the current state of the world into a checkState step, which the world passes.
+// The system account is never included.
+func (ae *ScenarioExecutor) GenerateCheckStateStep(options CheckStateOptions) (*scenmodel.CheckStateStep, error) {
+	checkAccounts := &scenmodel.CheckAccounts{
+		MoreAccountsAllowed: options.MoreAccountsAllowed || options.Baseline != nil,
+	}
+	worldAccounts, err := ae.dumpedAccounts(nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, worldAccount := range worldAccounts {
+		if options.Baseline != nil {
+			unchanged, err := worldmock.SameAccountState(worldAccount.Address, options.Baseline, ae.World.AcctMap)
+			if err != nil {
+				return nil, err
+			}
+			if unchanged {
+				continue
+			}
+		}
+
+		checkAccount, err := ae.checkAccountFromWorld(worldAccount, options)
+		if err != nil {
+			return nil, err
+		}
+		checkAccounts.Accounts = append(checkAccounts.Accounts, checkAccount)
+	}
+
+	return &scenmodel.CheckStateStep{CheckAccounts: checkAccounts}, nil
+}
+
+// WriteCheckStateStep generates a checkState step from the current world and writes it to a scenario file.
+// Code file paths are written relative to the generated file.
+func (ae *ScenarioExecutor) WriteCheckStateStep(toPath string, options CheckStateOptions) error {
+	absPath, err := filepath.Abs(toPath)
+	if err != nil {
+		return err
+	}
+	options.CodeBase = filepath.Dir(absPath)
+
+	step, err := ae.GenerateCheckStateStep(options)
+	if err != nil {
+		return err
+	}
+	scenario := &scenmodel.Scenario{
+		CheckGas:    true,
+		GasSchedule: scenmodel.GasScheduleDefault,
+		Steps:       []scenmodel.Step{step},
+	}
+	err = scenio.WriteScenariosScenario(scenario, toPath)
+	if err != nil {
+		return fmt.Errorf("could not write checkState step to %s: %w", toPath, err)
+	}
+	return nil
+}
+
+// checkAccountFromWorld converts a world account to a checkState account, checking its nonce, balance, username,
+// storage, code, owner and, unless ignored, its ESDT tokens.
+func (ae *ScenarioExecutor) checkAccountFromWorld(worldAccount *worldmock.Account, options CheckStateOptions) (*scenmodel.CheckAccount, error) {
+	account, err := ae.convertFullMockAccountToScenarioFormat(worldAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	checkAccount := &scenmodel.CheckAccount{
+		Address: account.Address,
+		Nonce: scenmodel.JSONCheckUint64{
+			Value:    account.Nonce.Value,
+			Original: account.Nonce.Original,
+		},
+		Balance: scenmodel.JSONCheckBigInt{
+			Value:    account.Balance.Value,
+			Original: account.Balance.Original,
+		},
+		Username:           checkBytesFromExpression(account.Username),
+		ExplicitStorage:    true,
+		MoreStorageAllowed: options.MoreStorageAllowed,
+		Code:               checkBytesFromExpression(ae.codeExpression(worldAccount.Code, options.CodeBase)),
+		CodeMetadata:       scenmodel.JSONCheckBytesUnspecified(),
+		Owner:              checkBytesFromExpression(account.Owner),
+		AsyncCallData:      scenmodel.JSONCheckBytesUnspecified(),
+		IgnoreESDT:         options.IgnoreESDT,
+		DeveloperReward:    scenmodel.JSONCheckBigIntUnspecified(),
+	}
+	for _, storageEntry := range account.Storage {
+		checkAccount.CheckStorage = append(checkAccount.CheckStorage, &scenmodel.CheckStorageKeyValuePair{
+			Key: storageEntry.Key,
+			CheckValue: scenmodel.JSONCheckBytes{
+				Value:    storageEntry.Value.Value,
+				Original: storageEntry.Value.Original,
+			},
+		})
+	}
+	if !options.IgnoreESDT {
+		for _, esdtData := range account.ESDTData {
+			checkAccount.CheckESDTData = append(checkAccount.CheckESDTData, checkESDTFromScenarioFormat(esdtData))
+		}
+	}
+	return checkAccount, nil
+}
+
+// codeExpression references the file the code was loaded from, relative to the code base, if known.
+func (ae *ScenarioExecutor) codeExpression(code []byte, codeBase string) scenmodel.JSONBytesFromString {
+	codePath, known := ae.codePaths[string(code)]
+	if len(codeBase) == 0 || !known {
+		return ae.bytesExpression(code, er.CodeHint)
+	}
+
+	for _, prefix := range codeFilePrefixes {
+		if !strings.HasPrefix(codePath, prefix) {
+			continue
+		}
+		relativePath, err := filepath.Rel(codeBase, codePath[len(prefix):])
+		if err != nil {
+			break
+		}
+		return scenmodel.JSONBytesFromString{
+			Value:    code,
+			Original: prefix + filepath.ToSlash(relativePath),
+		}
+	}
+	return ae.bytesExpression(code, er.CodeHint)
+}
+
+// checkESDTFromScenarioFormat converts the ESDT data of a setState account into a check of all its fields.
+// Fungible tokens with no roles are written in the compact form.
+func checkESDTFromScenarioFormat(esdtData *scenmodel.ESDTData) *scenmodel.CheckESDTData {
+	checkESDT := &scenmodel.CheckESDTData{
+		TokenIdentifier: esdtData.TokenIdentifier,
+		LastNonce:       scenmodel.JSONCheckUint64Unspecified(),
+		Roles:           esdtData.Roles,
+		Frozen:          scenmodel.JSONCheckUint64Unspecified(),
+	}
+	if esdtData.LastNonce.Value > 0 {
+		checkESDT.LastNonce = scenmodel.JSONCheckUint64{
+			Value:    esdtData.LastNonce.Value,
+			Original: esdtData.LastNonce.Original,
+		}
+	}
+
+	isCompact := len(esdtData.Instances) == 1 &&
+		esdtData.Instances[0].Nonce.Value == 0 &&
+		len(esdtData.Roles) == 0 &&
+		esdtData.LastNonce.Value == 0
+	for _, instance := range esdtData.Instances {
+		checkInstance := scenmodel.NewCheckESDTInstance()
+		checkInstance.Nonce = instance.Nonce
+		if isCompact {
+			checkInstance.Nonce.Original = ""
+		}
+		checkInstance.Balance = scenmodel.JSONCheckBigInt{
+			Value:    instance.Balance.Value,
+			Original: instance.Balance.Original,
+		}
+		if len(instance.Creator.Value) > 0 {
+			checkInstance.Creator = checkBytesFromExpression(instance.Creator)
+		}
+		if instance.Royalties.Value > 0 {
+			checkInstance.Royalties = scenmodel.JSONCheckUint64{
+				Value:    instance.Royalties.Value,
+				Original: instance.Royalties.Original,
+			}
+		}
+		if len(instance.Hash.Value) > 0 {
+			checkInstance.Hash = checkBytesFromExpression(instance.Hash)
+		}
+		if len(instance.Uris.Values) > 0 {
+			checkInstance.Uris = scenmodel.JSONCheckValueList{}
+			for _, uri := range instance.Uris.Values {
+				checkInstance.Uris.Values = append(checkInstance.Uris.Values,
+					scenmodel.JSONCheckBytesReconstructed(uri.Value, uri.Original))
+			}
+		}
+		if len(instance.Attributes.Value) > 0 {
+			checkInstance.Attributes = scenmodel.JSONCheckBytes{
+				Value:    instance.Attributes.Value,
+				Original: instance.Attributes.Original,
+			}
+		}
+		checkESDT.Instances = append(checkESDT.Instances, checkInstance)
+	}
+	return checkESDT
+}
+
+func checkBytesFromExpression(value scenmodel.JSONBytesFromString) scenmodel.JSONCheckBytes {
+	if len(value.Value) == 0 {
+		return scenmodel.JSONCheckBytesUnspecified()
+	}
+	return scenmodel.JSONCheckBytesReconstructed(value.Value, value.Original)
+}
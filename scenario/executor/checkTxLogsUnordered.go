@@ -0,0 +1,156 @@
+package scenexec
+
+import (
+	"fmt"
+	"strings"
+
+	scenjwrite "github.com/multiversx/mx-chain-scenario-go/scenario/json/write"
+	scenmodel "github.com/multiversx/mx-chain-scenario-go/scenario/model"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+// checkUnorderedTxLogs checks logs regardless of their order.
+// Entries with a count check how many logs match them. Every other entry needs a log of its own.
+// Unless in contains mode, every log must be matched by some entry.
+func (ae *ScenarioExecutor) checkUnorderedTxLogs(
+	txIndex string,
+	expectedLogs scenmodel.LogList,
+	actualLogs []*vmcommon.LogEntry,
+) error {
+	var errs []string
+	accountedFor := make([]bool, len(actualLogs))
+
+	var singleEntries []*scenmodel.LogEntry
+	for _, expectedLog := range expectedLogs.List {
+		if !expectedLog.HasCount() {
+			singleEntries = append(singleEntries, expectedLog)
+			continue
+		}
+
+		matchCount := uint64(0)
+		for i, actualLog := range actualLogs {
+			if logEntryMatches(expectedLog, actualLog) {
+				matchCount++
+				accountedFor[i] = true
+			}
+		}
+		if !expectedLog.Count.Check(matchCount) {
+			errs = append(errs, fmt.Sprintf("bad log count. Want: \"%s\". Have: %d. Log:\n%s",
+				expectedLog.Count.Original,
+				matchCount,
+				scenjwrite.LogToString(expectedLog)))
+		}
+	}
+
+	assignment := matchLogEntries(singleEntries, actualLogs)
+	for entryIndex, expectedLog := range singleEntries {
+		actualIndex, found := assignment[entryIndex]
+		if found {
+			accountedFor[actualIndex] = true
+			continue
+		}
+		errs = append(errs, fmt.Sprintf("log not found. Want:\n%s\nClosest:\n%s",
+			scenjwrite.LogToString(expectedLog),
+			ae.closestLogString(expectedLog, actualLogs)))
+	}
+
+	if expectedLogs.Mode == scenmodel.LogCheckUnordered {
+		for i, actualLog := range actualLogs {
+			if !accountedFor[i] {
+				errs = append(errs, fmt.Sprintf("unexpected log. Log index: %d. Log:\n%s",
+					i,
+					scenjwrite.LogToString(ae.convertLogToTestFormat(actualLog))))
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("log mismatch. Tx '%s'.\n%s", txIndex, strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
+// matchLogEntries gives each expected entry a different matching log, if possible, as a maximum bipartite matching.
+// It yields the index of the log of each matched entry.
+func matchLogEntries(expectedLogs []*scenmodel.LogEntry, actualLogs []*vmcommon.LogEntry) map[int]int {
+	entryOfLog := make([]int, len(actualLogs))
+	for i := range entryOfLog {
+		entryOfLog[i] = -1
+	}
+
+	var assign func(entryIndex int, visited []bool) bool
+	assign = func(entryIndex int, visited []bool) bool {
+		for logIndex, actualLog := range actualLogs {
+			if visited[logIndex] || !logEntryMatches(expectedLogs[entryIndex], actualLog) {
+				continue
+			}
+			visited[logIndex] = true
+			// the log is free, or the entry holding it can move to another log
+			if entryOfLog[logIndex] < 0 || assign(entryOfLog[logIndex], visited) {
+				entryOfLog[logIndex] = entryIndex
+				return true
+			}
+		}
+		return false
+	}
+	for entryIndex := range expectedLogs {
+		assign(entryIndex, make([]bool, len(actualLogs)))
+	}
+
+	assignment := make(map[int]int)
+	for logIndex, entryIndex := range entryOfLog {
+		if entryIndex >= 0 {
+			assignment[entryIndex] = logIndex
+		}
+	}
+	return assignment
+}
+
+func logEntryMatches(expectedLog *scenmodel.LogEntry, actualLog *vmcommon.LogEntry) bool {
+	return expectedLog.Address.Check(actualLog.Address) &&
+		expectedLog.Endpoint.Check(actualLog.Identifier) &&
+		expectedLog.Topics.CheckList(actualLog.Topics) &&
+		expectedLog.Data.CheckList(actualLog.Data)
+}
+
+// logSimilarity scores how close a log is to an expected entry, to point at the likely culprit when none matches.
+// The identifier weighs the most, since it names the event.
+func logSimilarity(expectedLog *scenmodel.LogEntry, actualLog *vmcommon.LogEntry) int {
+	similarity := 0
+	if expectedLog.Endpoint.Check(actualLog.Identifier) {
+		similarity += 2
+	}
+	if expectedLog.Address.Check(actualLog.Address) {
+		similarity++
+	}
+	if expectedLog.Topics.IsStar {
+		similarity++
+	}
+	for i, topic := range expectedLog.Topics.Values {
+		if i < len(actualLog.Topics) && topic.Check(actualLog.Topics[i]) {
+			similarity++
+		}
+	}
+	if expectedLog.Data.CheckList(actualLog.Data) {
+		similarity++
+	}
+	return similarity
+}
+
+func (ae *ScenarioExecutor) closestLogString(expectedLog *scenmodel.LogEntry, actualLogs []*vmcommon.LogEntry) string {
+	if len(actualLogs) == 0 {
+		return "no logs"
+	}
+
+	closest := actualLogs[0]
+	closestSimilarity := logSimilarity(expectedLog, closest)
+	for _, actualLog := range actualLogs[1:] {
+		similarity := logSimilarity(expectedLog, actualLog)
+		if similarity > closestSimilarity {
+			closest = actualLog
+			closestSimilarity = similarity
+		}
+	}
+	return scenjwrite.LogToString(ae.convertLogToTestFormat(closest))
+}
@@ -0,0 +1,223 @@
+package scenexec
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/multiversx/mx-chain-core-go/data/vm"
+	er "github.com/multiversx/mx-chain-scenario-go/scenario/expression/reconstructor"
+	scenmodel "github.com/multiversx/mx-chain-scenario-go/scenario/model"
+	"github.com/multiversx/mx-chain-vm-common-go/parsers"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+// sentTransfer is an output transfer of a transaction, with the token transfers decoded from its data.
+type sentTransfer struct {
+	index         uint32
+	from          []byte
+	to            []byte
+	egldValue     *big.Int
+	esdtTransfers []*vmcommon.ESDTTransfer
+	function      string
+	arguments     [][]byte
+	callType      vm.CallType
+	gasLimit      uint64
+	gasLocked     uint64
+}
+
+// sentTransfers collects the output transfers of all accounts, in the order of their index.
+// Value changes without an output transfer are not transfers, as far as this check goes.
+func sentTransfers(output *vmcommon.VMOutput) []*sentTransfer {
+	addresses := make([]string, 0, len(output.OutputAccounts))
+	for address := range output.OutputAccounts {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	var transfers []*sentTransfer
+	for _, address := range addresses {
+		outputAccount := output.OutputAccounts[address]
+		for _, outputTransfer := range outputAccount.OutputTransfers {
+			transfers = append(transfers, decodeSentTransfer(outputAccount.Address, outputTransfer))
+		}
+	}
+	sort.SliceStable(transfers, func(i, j int) bool {
+		return transfers[i].index < transfers[j].index
+	})
+	return transfers
+}
+
+func decodeSentTransfer(receiver []byte, outputTransfer vmcommon.OutputTransfer) *sentTransfer {
+	transfer := &sentTransfer{
+		index:     outputTransfer.Index,
+		from:      outputTransfer.SenderAddress,
+		to:        receiver,
+		egldValue: bigIntOrZero(outputTransfer.Value),
+		function:  "",
+		arguments: make([][]byte, 0),
+		callType:  outputTransfer.CallType,
+		gasLimit:  outputTransfer.GasLimit,
+		gasLocked: outputTransfer.GasLocked,
+	}
+	if len(outputTransfer.Data) == 0 {
+		return transfer
+	}
+
+	if outputTransfer.CallType == vm.AsynchronousCallBack {
+		arguments, err := parseCallbackArguments(string(outputTransfer.Data))
+		if err == nil {
+			transfer.arguments = arguments
+		}
+		return transfer
+	}
+
+	function, arguments, err := parsers.NewCallArgsParser().ParseData(string(outputTransfer.Data))
+	if err != nil {
+		// not a call, the data is kept as it is
+		transfer.function = string(outputTransfer.Data)
+		return transfer
+	}
+	transfer.function = function
+	transfer.arguments = arguments
+
+	if isESDTTransferFunction(function) {
+		parsedTransfer, err := newESDTTransferParser().ParseESDTTransfers(transfer.from, receiver, function, arguments)
+		if err == nil {
+			transfer.to = parsedTransfer.RcvAddr
+			transfer.esdtTransfers = parsedTransfer.ESDTTransfers
+			transfer.function = parsedTransfer.CallFunction
+			transfer.arguments = parsedTransfer.CallArgs
+		}
+	}
+	return transfer
+}
+
+// checkTxTransfers checks the transfers of each expected destination, in order.
+// Unless more are allowed, transfers to other destinations are not accepted.
+func (ae *ScenarioExecutor) checkTxTransfers(
+	txIndex string,
+	expectedTransfers scenmodel.CheckTransfers,
+	output *vmcommon.VMOutput,
+) error {
+	if expectedTransfers.IsStar {
+		return nil
+	}
+
+	transfers := sentTransfers(output)
+	transfersByDestination := make(map[string][]*sentTransfer)
+	for _, transfer := range transfers {
+		transfersByDestination[string(transfer.to)] = append(transfersByDestination[string(transfer.to)], transfer)
+	}
+
+	expectedDestinations := make(map[string]bool)
+	for _, destination := range expectedTransfers.Destinations {
+		expectedDestinations[string(destination.To.Value)] = true
+		actualTransfers := transfersByDestination[string(destination.To.Value)]
+		if len(actualTransfers) != len(destination.Transfers) {
+			return fmt.Errorf("wrong number of transfers. Tx '%s'. Destination: %s. Want: %d. Have: %d",
+				txIndex,
+				destination.To.Original,
+				len(destination.Transfers),
+				len(actualTransfers))
+		}
+		for i, expectedTransfer := range destination.Transfers {
+			err := ae.checkTxTransfer(expectedTransfer, actualTransfers[i])
+			if err != nil {
+				return fmt.Errorf("bad transfer. Tx '%s'. Destination: %s. Transfer index: %d. %w",
+					txIndex,
+					destination.To.Original,
+					i,
+					err)
+			}
+		}
+	}
+
+	if !expectedTransfers.MoreDestinationsAllowed {
+		for _, transfer := range transfers {
+			if !expectedDestinations[string(transfer.to)] {
+				return fmt.Errorf("unexpected transfer. Tx '%s'. Destination: %s",
+					txIndex,
+					ae.exprReconstructor.Reconstruct(transfer.to, er.AddressHint))
+			}
+		}
+	}
+
+	return nil
+}
+
+func (ae *ScenarioExecutor) checkTxTransfer(expected *scenmodel.CheckTransfer, actual *sentTransfer) error {
+	if !expected.From.IsUnspecified() && !expected.From.Check(actual.from) {
+		return fmt.Errorf("bad sender. Want: %s. Have: \"%s\"",
+			objectStringOrDefault(expected.From.Original),
+			ae.exprReconstructor.Reconstruct(actual.from, er.AddressHint))
+	}
+	if !expected.EGLDValue.IsUnspecified() && !expected.EGLDValue.Check(actual.egldValue) {
+		return fmt.Errorf("bad EGLD value. Want: \"%s\". Have: \"%s\"",
+			expected.EGLDValue.Original,
+			ae.exprReconstructor.ReconstructFromBigInt(actual.egldValue))
+	}
+	if expected.ESDTValue != nil {
+		err := ae.checkTxTransferESDT(expected.ESDTValue, actual.esdtTransfers)
+		if err != nil {
+			return err
+		}
+	}
+	if !expected.Function.IsUnspecified() && !expected.Function.Check([]byte(actual.function)) {
+		return fmt.Errorf("bad function. Want: %s. Have: \"%s\"",
+			objectStringOrDefault(expected.Function.Original),
+			ae.exprReconstructor.Reconstruct([]byte(actual.function), er.StrHint))
+	}
+	if !expected.Arguments.IsUnspecified() && !expected.Arguments.CheckList(actual.arguments) {
+		return fmt.Errorf("bad arguments. Want: %s. Have: %s%s",
+			checkBytesListPretty(expected.Arguments),
+			ae.exprReconstructor.ReconstructList(actual.arguments, er.NoHint),
+			decodedSuffix(ae.decodedArguments(actual.to, actual.function, actual.arguments)))
+	}
+	if len(expected.CallType) > 0 && expected.CallType != actual.callType.ToString() {
+		return fmt.Errorf("bad call type. Want: \"%s\". Have: \"%s\"",
+			expected.CallType,
+			actual.callType.ToString())
+	}
+	if !expected.GasLimit.IsUnspecified() && !expected.GasLimit.Check(actual.gasLimit) {
+		return fmt.Errorf("bad gas limit. Want: \"%s\". Have: \"%d\"",
+			expected.GasLimit.Original,
+			actual.gasLimit)
+	}
+	if !expected.GasLocked.IsUnspecified() && !expected.GasLocked.Check(actual.gasLocked) {
+		return fmt.Errorf("bad gas locked. Want: \"%s\". Have: \"%d\"",
+			expected.GasLocked.Original,
+			actual.gasLocked)
+	}
+	return nil
+}
+
+func (ae *ScenarioExecutor) checkTxTransferESDT(expected []*scenmodel.CheckESDTTransfer, actual []*vmcommon.ESDTTransfer) error {
+	if len(expected) != len(actual) {
+		return fmt.Errorf("wrong number of tokens. Want: %d. Have: %d", len(expected), len(actual))
+	}
+	for i, expectedESDT := range expected {
+		actualESDT := actual[i]
+		if !bytes.Equal(expectedESDT.TokenIdentifier.Value, actualESDT.ESDTTokenName) {
+			return fmt.Errorf("bad token identifier. Token index: %d. Want: \"%s\". Have: \"%s\"",
+				i,
+				expectedESDT.TokenIdentifier.Original,
+				ae.exprReconstructor.Reconstruct(actualESDT.ESDTTokenName, er.StrHint))
+		}
+		if !expectedESDT.Nonce.IsUnspecified() && !expectedESDT.Nonce.Check(actualESDT.ESDTTokenNonce) {
+			return fmt.Errorf("bad token nonce. Token index: %d. Want: \"%s\". Have: \"%d\"",
+				i,
+				expectedESDT.Nonce.Original,
+				actualESDT.ESDTTokenNonce)
+		}
+		if !expectedESDT.Value.IsUnspecified() && !expectedESDT.Value.Check(actualESDT.ESDTValue) {
+			return fmt.Errorf("bad token value. Token index: %d. Want: \"%s\". Have: \"%s\"",
+				i,
+				expectedESDT.Value.Original,
+				ae.exprReconstructor.ReconstructFromBigInt(actualESDT.ESDTValue))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package scenexec
+
+import (
+	"os"
+	"strings"
+
+	scenabi "github.com/multiversx/mx-chain-scenario-go/scenario/abi"
+	scenmodel "github.com/multiversx/mx-chain-scenario-go/scenario/model"
+)
+
+const mxscFileSuffix = ".mxsc.json"
+const wasmFileSuffix = ".wasm"
+const abiFileSuffix = ".abi.json"
+
+// codeABI yields the ABI of some code loaded from a file, or nil if there is none.
+// ABIs are loaded on first use, from the .mxsc.json file, or else from an .abi.json file next to the code file.
+func (ae *ScenarioExecutor) codeABI(code []byte) *scenabi.ABI {
+	abi, loaded := ae.abis[string(code)]
+	if loaded {
+		return abi
+	}
+	codePath, known := ae.codePaths[string(code)]
+	if !known {
+		return nil
+	}
+
+	abi, err := loadCodeABI(codePath)
+	if err != nil {
+		log.Warn("could not load contract ABI", "code", codePath, "error", err)
+	}
+	ae.abis[string(code)] = abi
+	return abi
+}
+
+func loadCodeABI(codePath string) (*scenabi.ABI, error) {
+	for _, prefix := range codeFilePrefixes {
+		if strings.HasPrefix(codePath, prefix) {
+			codePath = codePath[len(prefix):]
+			break
+		}
+	}
+
+	var abiPath string
+	switch {
+	case strings.HasSuffix(codePath, mxscFileSuffix):
+		mxscContents, err := os.ReadFile(codePath)
+		if err != nil {
+			return nil, err
+		}
+		abi, err := scenabi.ParseMxscABI(mxscContents)
+		if abi != nil || err != nil {
+			return abi, err
+		}
+		abiPath = strings.TrimSuffix(codePath, mxscFileSuffix) + abiFileSuffix
+	case strings.HasSuffix(codePath, wasmFileSuffix):
+		abiPath = strings.TrimSuffix(codePath, wasmFileSuffix) + abiFileSuffix
+	default:
+		return nil, nil
+	}
+
+	abiContents, err := os.ReadFile(abiPath)
+	if err != nil {
+		// the .abi.json file is optional
+		return nil, nil
+	}
+	return scenabi.ParseABI(abiContents)
+}
+
+// contractABI yields the ABI of the code deployed at an address, or nil if not known.
+func (ae *ScenarioExecutor) contractABI(address []byte) *scenabi.ABI {
+	account := ae.World.AcctMap.GetAccount(address)
+	if account == nil || len(account.Code) == 0 {
+		return nil
+	}
+	return ae.codeABI(account.Code)
+}
+
+// txEndpoint yields the ABI of the contract called or deployed by a transaction, and the endpoint it calls.
+// The endpoint is nil if either is not known.
+func (ae *ScenarioExecutor) txEndpoint(tx *scenmodel.Transaction) (*scenabi.ABI, *scenabi.Endpoint) {
+	switch tx.Type {
+	case scenmodel.ScDeploy, scenmodel.ScUpgrade:
+		abi := ae.codeABI(tx.Code.Value)
+		if abi != nil {
+			return abi, abi.Constructor
+		}
+	case scenmodel.ScCall, scenmodel.ScQuery:
+		abi := ae.contractABI(tx.To.Value)
+		if abi != nil {
+			return abi, abi.Endpoint(tx.Function)
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,467 @@
+package scenexec
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/data/vm"
+	scenmodel "github.com/multiversx/mx-chain-scenario-go/scenario/model"
+	worldmock "github.com/multiversx/mx-chain-scenario-go/worldmock"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/parsers"
+)
+
+// maxCrossShardRounds guards against messages that keep producing other messages forever.
+const maxCrossShardRounds = 100
+
+// multiTransferArgumentsPerToken is the number of MultiESDTNFTTransfer arguments describing each token:
+// identifier, nonce and value.
+const multiTransferArgumentsPerToken = 3
+
+// callbackFunctionName is the contract function that receives the results of asynchronous calls.
+const callbackFunctionName = "callBack"
+
+// setTxShard makes the shard of the sender the current shard, in multi-shard mode.
+// Transactions without a sender run in the shard of the receiver.
+func (ae *ScenarioExecutor) setTxShard(tx *scenmodel.Transaction) {
+	if !ae.World.MultiShard {
+		return
+	}
+	if tx.Type.HasSender() && tx.Type != scenmodel.ScQuery {
+		ae.World.SelfShardID = ae.World.ShardOf(tx.From.Value)
+	} else {
+		ae.World.SelfShardID = ae.World.ShardOf(tx.To.Value)
+	}
+}
+
+// restoreSelfShard resets the current shard after a transaction,
+// since the transaction and its cross-shard messages change it to the shards they run in.
+func (ae *ScenarioExecutor) restoreSelfShard(shardID uint32) {
+	ae.World.SelfShardID = shardID
+}
+
+// isCrossShardTx yields true for calls and transfers to accounts in another shard than the sender, in multi-shard mode.
+func (ae *ScenarioExecutor) isCrossShardTx(tx *scenmodel.Transaction) bool {
+	return ae.World.MultiShard &&
+		(tx.Type == scenmodel.ScCall || tx.Type == scenmodel.Transfer) &&
+		ae.World.ShardOf(tx.From.Value) != ae.World.ShardOf(tx.To.Value)
+}
+
+// crossShardTx performs the sender side of a call or transfer to an account in another shard,
+// then queues it as a cross-shard message, delivered to the shard of the receiver in the first round.
+// The output is that of the execution in the shard of the receiver. A failed execution is refunded
+// by a cross-shard message, instead of reverting the transaction.
+func (ae *ScenarioExecutor) crossShardTx(txIndex string, tx *scenmodel.Transaction) (*vmcommon.VMOutput, error) {
+	recipient := ae.World.AcctMap.GetAccount(tx.To.Value)
+	if recipient == nil {
+		return nil, fmt.Errorf("tx recipient (address: %s) does not exist", hex.EncodeToString(tx.To.Value))
+	}
+	if tx.Type == scenmodel.ScCall && len(recipient.Code) == 0 {
+		return nil, fmt.Errorf("tx recipient (address: %s) is not a smart contract", hex.EncodeToString(tx.To.Value))
+	}
+
+	input := ConvertScenarioTxToVMInput(tx)
+	txHash := generateTxHash(txIndex)
+	input.CurrentTxHash = txHash
+	input.OriginalTxHash = txHash
+
+	message := &worldmock.CrossShardMessage{
+		SourceShard:      ae.World.SelfShardID,
+		DestinationShard: recipient.ShardID,
+		Sender:           tx.From.Value,
+		Receiver:         tx.To.Value,
+		Value:            tx.EGLDValue.Value,
+		Data:             callData(tx.Function, input.Arguments),
+		GasLimit:         tx.GasLimit.Value,
+		CallType:         vm.DirectCall,
+		OriginalTxHash:   txHash,
+	}
+
+	if len(input.ESDTTransfers) > 0 {
+		// in the shard of the sender, the builtin function only takes the tokens from the sender
+		bfInput := worldmock.ConvertToBuiltinFunction(input)
+		vmOutput, err := ae.World.BuiltinFuncs.ProcessBuiltInFunction(bfInput)
+		if err != nil {
+			return nil, err
+		}
+		if vmOutput.ReturnCode != vmcommon.Ok {
+			return nil, fmt.Errorf(
+				"%s failed: retcode = %d, msg = %s",
+				bfInput.Function,
+				vmOutput.ReturnCode,
+				vmOutput.ReturnMessage)
+		}
+		message.Data = callData(bfInput.Function, bfInput.Arguments)
+		recipientOutput, found := vmOutput.OutputAccounts[string(tx.To.Value)]
+		if found && len(recipientOutput.OutputTransfers) > 0 {
+			// the builtin function already encoded the transfer for the shard of the receiver
+			message.Data = recipientOutput.OutputTransfers[0].Data
+		}
+	}
+
+	err := ae.World.UpdateBalanceWithDelta(tx.From.Value, big.NewInt(0).Neg(tx.EGLDValue.Value))
+	if err != nil {
+		return nil, err
+	}
+
+	ae.World.QueueCrossShardMessage(message)
+	return ae.runCrossShardRounds(txIndex, message)
+}
+
+// queueCrossShardMessages turns the transfers to accounts in other shards from an output into cross-shard messages.
+// Transfers that do not mention their sender are sent by the given default sender.
+func (ae *ScenarioExecutor) queueCrossShardMessages(output *vmcommon.VMOutput, defaultSender []byte, originalTxHash []byte) {
+	addresses := make([]string, 0, len(output.OutputAccounts))
+	for address := range output.OutputAccounts {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	for _, address := range addresses {
+		outputAccount := output.OutputAccounts[address]
+		if !ae.World.IsInOtherShard(outputAccount.Address) {
+			continue
+		}
+
+		destinationShard := ae.World.ShardOf(outputAccount.Address)
+		if len(outputAccount.OutputTransfers) == 0 {
+			if outputAccount.BalanceDelta != nil && outputAccount.BalanceDelta.Sign() > 0 {
+				ae.World.QueueCrossShardMessage(&worldmock.CrossShardMessage{
+					SourceShard:      ae.World.SelfShardID,
+					DestinationShard: destinationShard,
+					Sender:           defaultSender,
+					Receiver:         outputAccount.Address,
+					Value:            outputAccount.BalanceDelta,
+					CallType:         vm.DirectCall,
+					OriginalTxHash:   originalTxHash,
+				})
+			}
+			continue
+		}
+
+		for _, transfer := range outputAccount.OutputTransfers {
+			sender := transfer.SenderAddress
+			if len(sender) == 0 {
+				sender = defaultSender
+			}
+			ae.World.QueueCrossShardMessage(&worldmock.CrossShardMessage{
+				SourceShard:      ae.World.SelfShardID,
+				DestinationShard: destinationShard,
+				Sender:           sender,
+				Receiver:         outputAccount.Address,
+				Value:            bigIntOrZero(transfer.Value),
+				Data:             transfer.Data,
+				GasLimit:         transfer.GasLimit,
+				GasLocked:        transfer.GasLocked,
+				CallType:         transfer.CallType,
+				OriginalTxHash:   originalTxHash,
+			})
+		}
+	}
+}
+
+// runCrossShardRounds executes the queued cross-shard messages, round after round, until none are left.
+// The messages produced in a round are executed in the next one.
+// It yields the output of the execution of txMessage, the message sent by the transaction itself, if any.
+func (ae *ScenarioExecutor) runCrossShardRounds(txIndex string, txMessage *worldmock.CrossShardMessage) (*vmcommon.VMOutput, error) {
+	var txOutput *vmcommon.VMOutput
+	for round := 1; len(ae.World.CrossShardMessages) > 0; round++ {
+		if round > maxCrossShardRounds {
+			return nil, fmt.Errorf("tx %s: cross-shard messages still pending after %d rounds", txIndex, maxCrossShardRounds)
+		}
+		if ae.World.BlockProduction != nil {
+			ae.World.ProduceBlocks(1)
+		}
+		for _, message := range ae.World.TakeCrossShardMessages() {
+			output, err := ae.executeCrossShardMessage(txIndex, message)
+			if err != nil {
+				return nil, fmt.Errorf("tx %s, cross-shard round %d: %w", txIndex, round, err)
+			}
+			if message == txMessage {
+				txOutput = output
+			}
+		}
+	}
+	return txOutput, nil
+}
+
+// executeCrossShardMessage executes a message in the shard of its receiver.
+// Changes are rolled back if the execution fails, and the sender gets refunded.
+// Successful asynchronous calls get a callback, unless the contract already sent one.
+func (ae *ScenarioExecutor) executeCrossShardMessage(txIndex string, message *worldmock.CrossShardMessage) (*vmcommon.VMOutput, error) {
+	ae.World.SelfShardID = message.DestinationShard
+	snapshot := ae.World.GetSnapshot()
+
+	output, err := ae.crossShardMessageOutput(message)
+	if err != nil {
+		output = failedExecutionOutput(err)
+	}
+	if output.ReturnCode != vmcommon.Ok {
+		err = ae.World.RevertToSnapshot(snapshot)
+		if err != nil {
+			return nil, err
+		}
+		cause := fmt.Errorf(
+			"cross-shard message failed: retcode=%d, msg=%s",
+			output.ReturnCode, output.ReturnMessage)
+		for _, observer := range ae.observers {
+			observer.OnWorldRollback(txIndex, cause)
+		}
+		return output, ae.queueCrossShardRefund(message, output)
+	}
+
+	err = ae.World.UpdateAccounts(output.OutputAccounts, output.DeletedAccounts)
+	if err != nil {
+		return nil, err
+	}
+	ae.queueCrossShardMessages(output, message.Receiver, message.OriginalTxHash)
+
+	if message.CallType == vm.AsynchronousCall && !hasCallbackTransfer(output, message.Sender) {
+		ae.World.QueueCrossShardMessage(&worldmock.CrossShardMessage{
+			SourceShard:      message.DestinationShard,
+			DestinationShard: message.SourceShard,
+			Sender:           message.Receiver,
+			Receiver:         message.Sender,
+			Value:            big.NewInt(0),
+			Data:             callbackData(vmcommon.Ok, output.ReturnData),
+			GasLimit:         output.GasRemaining + message.GasLocked,
+			CallType:         vm.AsynchronousCallBack,
+			OriginalTxHash:   message.OriginalTxHash,
+		})
+	}
+
+	return output, nil
+}
+
+// crossShardMessageOutput runs the builtin function or contract call carried by a message.
+// Messages to accounts without code, or without a function, only transfer value.
+func (ae *ScenarioExecutor) crossShardMessageOutput(message *worldmock.CrossShardMessage) (*vmcommon.VMOutput, error) {
+	function, arguments, err := parseMessageData(message)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:           message.Sender,
+			Arguments:            arguments,
+			CallValue:            message.Value,
+			CallType:             message.CallType,
+			GasProvided:          message.GasLimit,
+			GasLocked:            message.GasLocked,
+			OriginalTxHash:       message.OriginalTxHash,
+			CurrentTxHash:        message.OriginalTxHash,
+			ESDTTransfers:        make([]*vmcommon.ESDTTransfer, 0),
+			ReturnCallAfterError: message.IsRefund,
+		},
+		RecipientAddr:     message.Receiver,
+		Function:          function,
+		AllowInitFunction: false,
+	}
+
+	if ae.isBuiltinFunction(function) {
+		// in the shard of the receiver, the builtin function only gives the tokens to the receiver
+		bfOutput, err := ae.World.BuiltinFuncs.ProcessBuiltInFunction(input)
+		if err != nil {
+			return nil, err
+		}
+		if bfOutput.ReturnCode != vmcommon.Ok || !isESDTTransferFunction(function) {
+			return bfOutput, nil
+		}
+
+		parsedTransfers, err := newESDTTransferParser().ParseESDTTransfers(message.Sender, message.Receiver, function, arguments)
+		if err != nil {
+			return nil, err
+		}
+		input.Function = parsedTransfers.CallFunction
+		input.Arguments = parsedTransfers.CallArgs
+		input.ESDTTransfers = parsedTransfers.ESDTTransfers
+	}
+
+	receiver := ae.World.AcctMap.GetAccount(message.Receiver)
+	if receiver == nil || len(receiver.Code) == 0 || len(input.Function) == 0 {
+		return valueTransferOutput(message.Receiver, message.Value), nil
+	}
+
+	return ae.vm.RunSmartContractCall(input)
+}
+
+// queueCrossShardRefund returns the value and tokens of a failed message to its sender.
+// Failed asynchronous calls are refunded by their callback, which receives the error.
+// Failed callbacks and refunds are not refunded.
+func (ae *ScenarioExecutor) queueCrossShardRefund(message *worldmock.CrossShardMessage, output *vmcommon.VMOutput) error {
+	if message.CallType == vm.AsynchronousCallBack || message.IsRefund {
+		return nil
+	}
+
+	refundData, err := esdtRefundData(message)
+	if err != nil {
+		return err
+	}
+	refund := &worldmock.CrossShardMessage{
+		SourceShard:      message.DestinationShard,
+		DestinationShard: message.SourceShard,
+		Sender:           message.Receiver,
+		Receiver:         message.Sender,
+		Value:            message.Value,
+		Data:             refundData,
+		CallType:         vm.DirectCall,
+		OriginalTxHash:   message.OriginalTxHash,
+		IsRefund:         true,
+	}
+
+	if message.CallType == vm.AsynchronousCall {
+		if len(refundData) > 0 {
+			tokenRefund := *refund
+			tokenRefund.Value = big.NewInt(0)
+			ae.World.QueueCrossShardMessage(&tokenRefund)
+		}
+		refund.Data = callbackData(output.ReturnCode, [][]byte{[]byte(output.ReturnMessage)})
+		refund.GasLimit = message.GasLocked
+		refund.CallType = vm.AsynchronousCallBack
+		refund.IsRefund = false
+		ae.World.QueueCrossShardMessage(refund)
+		return nil
+	}
+
+	if refund.Value.Sign() > 0 || len(refund.Data) > 0 {
+		ae.World.QueueCrossShardMessage(refund)
+	}
+	return nil
+}
+
+// esdtRefundData yields the transfer that sends back the tokens of a message, if it carries any.
+// It is the transfer of the message, without the call that followed it.
+func esdtRefundData(message *worldmock.CrossShardMessage) ([]byte, error) {
+	function, arguments, err := parseMessageData(message)
+	if err != nil {
+		// data that is not a function call carries no tokens
+		return nil, nil
+	}
+
+	numTransferArguments := 0
+	switch function {
+	case core.BuiltInFunctionESDTTransfer:
+		numTransferArguments = core.MinLenArgumentsESDTTransfer
+	case core.BuiltInFunctionESDTNFTTransfer:
+		numTransferArguments = core.MinLenArgumentsESDTNFTTransfer
+	case core.BuiltInFunctionMultiESDTNFTTransfer:
+		if len(arguments) == 0 {
+			return nil, errors.New("missing number of transfers in MultiESDTNFTTransfer")
+		}
+		numTransfers := big.NewInt(0).SetBytes(arguments[0]).Uint64()
+		numTransferArguments = 1 + int(numTransfers)*multiTransferArgumentsPerToken
+	default:
+		return nil, nil
+	}
+	if len(arguments) < numTransferArguments {
+		return nil, fmt.Errorf("not enough arguments for %s", function)
+	}
+	return callData(function, arguments[:numTransferArguments]), nil
+}
+
+// parseMessageData splits the data of a message into a function and arguments.
+// Callbacks carry no function, only the return code and the results of the call.
+func parseMessageData(message *worldmock.CrossShardMessage) (string, [][]byte, error) {
+	if message.CallType == vm.AsynchronousCallBack {
+		arguments, err := parseCallbackArguments(string(message.Data))
+		return callbackFunctionName, arguments, err
+	}
+	if len(message.Data) == 0 {
+		return "", make([][]byte, 0), nil
+	}
+	return parsers.NewCallArgsParser().ParseData(string(message.Data))
+}
+
+func parseCallbackArguments(data string) ([][]byte, error) {
+	arguments := make([][]byte, 0)
+	if len(data) == 0 {
+		return arguments, nil
+	}
+	for _, token := range strings.Split(strings.TrimPrefix(data, "@"), "@") {
+		argument, err := hex.DecodeString(token)
+		if err != nil {
+			return nil, fmt.Errorf("bad callback argument %s: %w", token, err)
+		}
+		arguments = append(arguments, argument)
+	}
+	return arguments, nil
+}
+
+// callData encodes a function call as transaction data.
+func callData(function string, arguments [][]byte) []byte {
+	var sb strings.Builder
+	sb.WriteString(function)
+	for _, argument := range arguments {
+		sb.WriteString("@")
+		sb.WriteString(hex.EncodeToString(argument))
+	}
+	return []byte(sb.String())
+}
+
+// callbackData encodes the return code and the results of an asynchronous call, as received by the callback.
+func callbackData(returnCode vmcommon.ReturnCode, results [][]byte) []byte {
+	return append([]byte("@"), callData(hex.EncodeToString([]byte{byte(returnCode)}), results)...)
+}
+
+func hasCallbackTransfer(output *vmcommon.VMOutput, caller []byte) bool {
+	callerOutput, found := output.OutputAccounts[string(caller)]
+	if !found {
+		return false
+	}
+	for _, transfer := range callerOutput.OutputTransfers {
+		if transfer.CallType == vm.AsynchronousCallBack {
+			return true
+		}
+	}
+	return false
+}
+
+func (ae *ScenarioExecutor) isBuiltinFunction(function string) bool {
+	if len(function) == 0 {
+		return false
+	}
+	_, isBuiltin := ae.World.BuiltinFuncs.GetBuiltinFunctionNames()[function]
+	return isBuiltin
+}
+
+func isESDTTransferFunction(function string) bool {
+	return function == core.BuiltInFunctionESDTTransfer ||
+		function == core.BuiltInFunctionESDTNFTTransfer ||
+		function == core.BuiltInFunctionMultiESDTNFTTransfer
+}
+
+func newESDTTransferParser() vmcommon.ESDTTransferParser {
+	parser, err := parsers.NewESDTTransferParser(worldmock.WorldMarshalizer)
+	if err != nil {
+		// only fails for a nil marshalizer
+		panic(err)
+	}
+	return parser
+}
+
+func failedExecutionOutput(err error) *vmcommon.VMOutput {
+	return &vmcommon.VMOutput{
+		ReturnData:      make([][]byte, 0),
+		ReturnCode:      vmcommon.ExecutionFailed,
+		ReturnMessage:   err.Error(),
+		GasRemaining:    0,
+		GasRefund:       big.NewInt(0),
+		OutputAccounts:  make(map[string]*vmcommon.OutputAccount),
+		DeletedAccounts: make([][]byte, 0),
+		TouchedAccounts: make([][]byte, 0),
+		Logs:            make([]*vmcommon.LogEntry, 0),
+	}
+}
+
+func bigIntOrZero(value *big.Int) *big.Int {
+	if value == nil {
+		return big.NewInt(0)
+	}
+	return value
+}
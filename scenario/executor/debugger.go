@@ -0,0 +1,379 @@
+package scenexec
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	fr "github.com/multiversx/mx-chain-scenario-go/scenario/expression/fileresolver"
+	ei "github.com/multiversx/mx-chain-scenario-go/scenario/expression/interpreter"
+	er "github.com/multiversx/mx-chain-scenario-go/scenario/expression/reconstructor"
+	scenio "github.com/multiversx/mx-chain-scenario-go/scenario/io"
+	scenmodel "github.com/multiversx/mx-chain-scenario-go/scenario/model"
+	worldmock "github.com/multiversx/mx-chain-scenario-go/worldmock"
+	"github.com/multiversx/mx-chain-scenario-go/worldmock/esdtconvert"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+const functionBreakpointPrefix = "fn:"
+const idBreakpointPrefix = "id:"
+
+// Breakpoint selects the steps before which the debugger stops,
+// by step index, by step id, or by the function called in the transaction.
+type Breakpoint struct {
+	StepIndex int
+	StepID    string
+	Function  string
+}
+
+// ParseBreakpoint reads a breakpoint from its text form:
+// a step index, "id:" followed by a step id, or "fn:" followed by a function name.
+// Any other text is also taken as a step id.
+func ParseBreakpoint(spec string) (*Breakpoint, error) {
+	spec = strings.TrimSpace(spec)
+	if len(spec) == 0 {
+		return nil, errors.New("empty breakpoint")
+	}
+
+	breakpoint := &Breakpoint{StepIndex: -1}
+	if stepIndex, err := strconv.Atoi(spec); err == nil {
+		if stepIndex < 0 {
+			return nil, fmt.Errorf("bad breakpoint step index: %d", stepIndex)
+		}
+		breakpoint.StepIndex = stepIndex
+		return breakpoint, nil
+	}
+	if strings.HasPrefix(spec, functionBreakpointPrefix) {
+		breakpoint.Function = strings.TrimPrefix(spec, functionBreakpointPrefix)
+		return breakpoint, nil
+	}
+	breakpoint.StepID = strings.TrimPrefix(spec, idBreakpointPrefix)
+	return breakpoint, nil
+}
+
+// String yields the text form of the breakpoint, as accepted by ParseBreakpoint.
+func (bp *Breakpoint) String() string {
+	switch {
+	case bp.StepIndex >= 0:
+		return strconv.Itoa(bp.StepIndex)
+	case len(bp.Function) > 0:
+		return functionBreakpointPrefix + bp.Function
+	default:
+		return idBreakpointPrefix + bp.StepID
+	}
+}
+
+func (bp *Breakpoint) matches(stepIndex int, step scenmodel.Step) bool {
+	switch {
+	case bp.StepIndex >= 0:
+		return bp.StepIndex == stepIndex
+	case len(bp.Function) > 0:
+		txStep, isTx := step.(*scenmodel.TxStep)
+		return isTx && txStep.Tx.Function == bp.Function
+	default:
+		return stepIdent(step) == bp.StepID
+	}
+}
+
+// ScenarioDebugger runs the steps of a scenario one at a time, or until a breakpoint,
+// so that the world can be inspected between steps.
+type ScenarioDebugger struct {
+	NoOpScenarioObserver
+	executor    *ScenarioExecutor
+	scenario    *scenmodel.Scenario
+	interpreter ei.ExprInterpreter
+	nextStep    int
+	breakpoints []*Breakpoint
+	lastOutput  *vmcommon.VMOutput
+}
+
+// NewScenarioDebugger prepares the executor for the scenario, as a top level scenario,
+// and registers the debugger as its observer. No step is executed yet.
+func NewScenarioDebugger(executor *ScenarioExecutor, scenario *scenmodel.Scenario, fileResolver fr.FileResolver) (*ScenarioDebugger, error) {
+	err := executor.PrepareScenario(scenario, fileResolver)
+	if err != nil {
+		return nil, err
+	}
+
+	debugger := &ScenarioDebugger{
+		executor: executor,
+		scenario: scenario,
+		interpreter: ei.ExprInterpreter{
+			FileResolver: fileResolver,
+			VMType:       executor.GetVMType(),
+		},
+		nextStep:    0,
+		breakpoints: nil,
+		lastOutput:  nil,
+	}
+	executor.AddObserver(debugger)
+	return debugger, nil
+}
+
+// AfterTx keeps the output of the last transaction.
+func (sd *ScenarioDebugger) AfterTx(step *scenmodel.TxStep, output *vmcommon.VMOutput, err error) {
+	if output != nil {
+		sd.lastOutput = output
+	}
+}
+
+// AddBreakpoint adds a breakpoint.
+func (sd *ScenarioDebugger) AddBreakpoint(breakpoint *Breakpoint) {
+	sd.breakpoints = append(sd.breakpoints, breakpoint)
+}
+
+// SaveWorld writes the current state of the world to a snapshot file, e.g. to attach it to a bug report.
+func (sd *ScenarioDebugger) SaveWorld(toPath string) error {
+	return sd.executor.SaveWorldSnapshotFile(toPath)
+}
+
+// ClearBreakpoints removes all breakpoints.
+func (sd *ScenarioDebugger) ClearBreakpoints() {
+	sd.breakpoints = nil
+}
+
+// Breakpoints yields the current breakpoints.
+func (sd *ScenarioDebugger) Breakpoints() []*Breakpoint {
+	return sd.breakpoints
+}
+
+// Steps yields all steps of the scenario.
+func (sd *ScenarioDebugger) Steps() []scenmodel.Step {
+	return sd.scenario.Steps
+}
+
+// NextStepIndex is the index of the step that runs next.
+func (sd *ScenarioDebugger) NextStepIndex() int {
+	return sd.nextStep
+}
+
+// Finished is true once all steps were executed.
+func (sd *ScenarioDebugger) Finished() bool {
+	return sd.nextStep >= len(sd.scenario.Steps)
+}
+
+// LastOutput yields the VM output of the last transaction executed, nil if none.
+func (sd *ScenarioDebugger) LastOutput() *vmcommon.VMOutput {
+	return sd.lastOutput
+}
+
+// HasBreakpoint is true if any breakpoint matches the step.
+func (sd *ScenarioDebugger) HasBreakpoint(stepIndex int) bool {
+	if stepIndex < 0 || stepIndex >= len(sd.scenario.Steps) {
+		return false
+	}
+	for _, breakpoint := range sd.breakpoints {
+		if breakpoint.matches(stepIndex, sd.scenario.Steps[stepIndex]) {
+			return true
+		}
+	}
+	return false
+}
+
+// Step executes the next step.
+// Failed steps are reported as a StepError, after which debugging can go on with the next step.
+func (sd *ScenarioDebugger) Step() error {
+	if sd.Finished() {
+		return errors.New("no more steps to run")
+	}
+
+	stepIndex := sd.nextStep
+	step := sd.scenario.Steps[stepIndex]
+	sd.nextStep++
+
+	// like in RunScenario, the steps of external scenarios run nested in the debugged one
+	sd.executor.currentStepIndex = stepIndex
+	sd.executor.scenarioDepth++
+	setGasTraceInMetering(sd.executor, true)
+	err := sd.executor.ExecuteStep(step)
+	setGasTraceInMetering(sd.executor, false)
+	sd.executor.scenarioDepth--
+	if err != nil {
+		return &scenio.StepError{
+			StepIndex: stepIndex,
+			StepID:    stepIdent(step),
+			StepType:  step.StepTypeName(),
+			Err:       err,
+		}
+	}
+	return nil
+}
+
+// Continue executes steps until the next step has a breakpoint, a step fails, or the scenario ends.
+// At least one step is executed, so that continuing from a breakpoint moves on.
+func (sd *ScenarioDebugger) Continue() error {
+	for !sd.Finished() {
+		err := sd.Step()
+		if err != nil {
+			return err
+		}
+		if sd.HasBreakpoint(sd.nextStep) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// ParseValue interprets a scenario value expression, such as "address:owner" or "str:key".
+func (sd *ScenarioDebugger) ParseValue(expression string) ([]byte, error) {
+	return sd.interpreter.InterpretString(expression)
+}
+
+// DescribeStep yields a one line description of a step.
+func (sd *ScenarioDebugger) DescribeStep(stepIndex int) string {
+	step := sd.scenario.Steps[stepIndex]
+	description := fmt.Sprintf("%d: %s", stepIndex, step.StepTypeName())
+	if ident := stepIdent(step); len(ident) > 0 {
+		description += fmt.Sprintf(" \"%s\"", ident)
+	}
+	if txStep, isTx := step.(*scenmodel.TxStep); isTx && len(txStep.Tx.Function) > 0 {
+		description += " " + txStep.Tx.Function
+	}
+	return description
+}
+
+// DescribeAccount yields the nonce, balance, owner, code size, storage and ESDT tokens of an account.
+func (sd *ScenarioDebugger) DescribeAccount(address []byte) (string, error) {
+	account, err := sd.findAccount(address)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("address: %s\n", sd.reconstruct(account.Address, er.AddressHint)))
+	sb.WriteString(fmt.Sprintf("nonce: %d\n", account.Nonce))
+	sb.WriteString(fmt.Sprintf("balance: %s\n", account.Balance.String()))
+	if len(account.OwnerAddress) > 0 {
+		sb.WriteString(fmt.Sprintf("owner: %s\n", sd.reconstruct(account.OwnerAddress, er.AddressHint)))
+	}
+	if len(account.Code) > 0 {
+		sb.WriteString(fmt.Sprintf("code: %d bytes\n", len(account.Code)))
+	}
+	sb.WriteString("storage:\n")
+	sb.WriteString(sd.describeStorageEntries(account, nil))
+	esdtDescription, err := sd.DescribeESDT(address)
+	if err != nil {
+		return "", err
+	}
+	sb.WriteString("esdt:\n")
+	sb.WriteString(esdtDescription)
+	return sb.String(), nil
+}
+
+// DescribeStorage yields the decoded storage of an account, either a single key, or all keys if key is nil.
+func (sd *ScenarioDebugger) DescribeStorage(address []byte, key []byte) (string, error) {
+	account, err := sd.findAccount(address)
+	if err != nil {
+		return "", err
+	}
+	return sd.describeStorageEntries(account, key), nil
+}
+
+func (sd *ScenarioDebugger) describeStorageEntries(account *worldmock.Account, key []byte) string {
+	var keys []string
+	if key != nil {
+		keys = []string{string(key)}
+	} else {
+		for storageKey, value := range account.Storage {
+			if len(value) > 0 {
+				keys = append(keys, storageKey)
+			}
+		}
+		sort.Strings(keys)
+	}
+	if len(keys) == 0 {
+		return "  (empty)\n"
+	}
+
+	var sb strings.Builder
+	for _, storageKey := range keys {
+		sb.WriteString(fmt.Sprintf("  %s: %s\n",
+			sd.reconstruct([]byte(storageKey), er.NoHint),
+			sd.reconstruct(account.StorageValue(storageKey), er.NoHint)))
+	}
+	return sb.String()
+}
+
+// DescribeESDT yields the ESDT balances of an account, per token and nonce, and the token roles.
+func (sd *ScenarioDebugger) DescribeESDT(address []byte) (string, error) {
+	account, err := sd.findAccount(address)
+	if err != nil {
+		return "", err
+	}
+
+	systemAccStorage := make(map[string][]byte)
+	systemAcc, exists := sd.executor.World.AcctMap[string(vmcommon.SystemAccountAddress)]
+	if exists {
+		systemAccStorage = systemAcc.Storage
+	}
+	tokens, err := esdtconvert.GetFullMockESDTData(account.Storage, systemAccStorage)
+	if err != nil {
+		return "", err
+	}
+	if len(tokens) == 0 {
+		return "  (none)\n", nil
+	}
+
+	tokenNames := make([]string, 0, len(tokens))
+	for tokenName := range tokens {
+		tokenNames = append(tokenNames, tokenName)
+	}
+	sort.Strings(tokenNames)
+
+	var sb strings.Builder
+	for _, tokenName := range tokenNames {
+		token := tokens[tokenName]
+		for _, instance := range token.Instances {
+			nonce := uint64(0)
+			if instance.TokenMetaData != nil {
+				nonce = instance.TokenMetaData.Nonce
+			}
+			sb.WriteString(fmt.Sprintf("  %s, nonce %d: %s\n", tokenName, nonce, instance.Value.String()))
+		}
+		if len(token.Roles) > 0 {
+			roles := make([]string, 0, len(token.Roles))
+			for _, role := range token.Roles {
+				roles = append(roles, string(role))
+			}
+			sb.WriteString(fmt.Sprintf("  %s roles: %s\n", tokenName, strings.Join(roles, ", ")))
+		}
+	}
+	return sb.String(), nil
+}
+
+// DescribeLastOutput yields the status, return data, gas and logs of the last transaction.
+func (sd *ScenarioDebugger) DescribeLastOutput() string {
+	output := sd.lastOutput
+	if output == nil {
+		return "no transaction executed yet\n"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("status: %d (%s)\n", int(output.ReturnCode), output.ReturnCode.String()))
+	if len(output.ReturnMessage) > 0 {
+		sb.WriteString(fmt.Sprintf("message: %s\n", output.ReturnMessage))
+	}
+	sb.WriteString(fmt.Sprintf("out: %s\n", sd.executor.exprReconstructor.ReconstructList(output.ReturnData, er.NoHint)))
+	sb.WriteString(fmt.Sprintf("gas remaining: %d\n", output.GasRemaining))
+	for _, outputLog := range output.Logs {
+		sb.WriteString(fmt.Sprintf("log %s from %s, topics: %s\n",
+			sd.reconstruct(outputLog.Identifier, er.StrHint),
+			sd.reconstruct(outputLog.Address, er.AddressHint),
+			sd.executor.exprReconstructor.ReconstructList(outputLog.Topics, er.NoHint)))
+	}
+	return sb.String()
+}
+
+func (sd *ScenarioDebugger) findAccount(address []byte) (*worldmock.Account, error) {
+	account, found := sd.executor.World.AcctMap[string(address)]
+	if !found {
+		return nil, fmt.Errorf("account %s not found", sd.reconstruct(address, er.AddressHint))
+	}
+	return account, nil
+}
+
+func (sd *ScenarioDebugger) reconstruct(value []byte, hint er.ExprReconstructorHint) string {
+	return sd.executor.exprReconstructor.Reconstruct(value, hint)
+}
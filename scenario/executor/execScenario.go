@@ -1,31 +1,105 @@
 package scenexec
 
 import (
+	scenabi "github.com/multiversx/mx-chain-scenario-go/scenario/abi"
 	fr "github.com/multiversx/mx-chain-scenario-go/scenario/expression/fileresolver"
+	scenio "github.com/multiversx/mx-chain-scenario-go/scenario/io"
 	scenmodel "github.com/multiversx/mx-chain-scenario-go/scenario/model"
+	worldmock "github.com/multiversx/mx-chain-scenario-go/worldmock"
 )
 
 // RunScenario executes an individual test.
 func (ae *ScenarioExecutor) RunScenario(scenario *scenmodel.Scenario, fileResolver fr.FileResolver) error {
-	ae.fileResolver = fileResolver
-	ae.checkGas = scenario.CheckGas
-	resetGasTracesIfNewTest(ae, scenario)
+	// external steps also run as scenarios, but the top level scenario decides the run mode
+	isTopLevel := ae.scenarioDepth == 0
 
-	err := ae.InitVM(scenario.GasSchedule)
+	err := ae.PrepareScenario(scenario, fileResolver)
 	if err != nil {
 		return err
 	}
 
-	txIndex := 0
-	for _, generalStep := range scenario.Steps {
+	ae.scenarioDepth++
+	defer func() {
+		ae.scenarioDepth--
+	}()
+
+	for stepIndex, generalStep := range scenario.Steps {
+		if isTopLevel {
+			ae.currentStepIndex = stepIndex
+		}
 		setGasTraceInMetering(ae, true)
 		err := ae.ExecuteStep(generalStep)
 		if err != nil {
-			return err
+			stepErr := &scenio.StepError{
+				StepIndex: stepIndex,
+				StepID:    stepIdent(generalStep),
+				StepType:  generalStep.StepTypeName(),
+				Err:       err,
+			}
+			if !ae.handleStepError(stepErr) {
+				if isTopLevel {
+					return ae.collectedCheckFailuresError(stepErr)
+				}
+				return stepErr
+			}
 		}
 		setGasTraceInMetering(ae, false)
-		txIndex++
 	}
 
+	if isTopLevel {
+		return ae.collectedCheckFailuresError(nil)
+	}
 	return nil
 }
+
+// PrepareScenario sets up the executor for the steps of a scenario, which can then be executed one by one.
+// RunScenario calls it before running the steps.
+func (ae *ScenarioExecutor) PrepareScenario(scenario *scenmodel.Scenario, fileResolver fr.FileResolver) error {
+	// external steps are prepared too, but only the top level scenario decides the run mode
+	if ae.scenarioDepth == 0 {
+		ae.setUpTopLevelScenario(scenario)
+	}
+	ae.fileResolver = fileResolver
+	ae.checkGas = scenario.CheckGas
+	resetGasTracesIfNewTest(ae, scenario)
+	return ae.InitVM(scenario.GasSchedule)
+}
+
+// setUpTopLevelScenario applies the run mode of a top level scenario,
+// and resets everything collected while running the previous one.
+func (ae *ScenarioExecutor) setUpTopLevelScenario(scenario *scenmodel.Scenario) {
+	ae.topLevelScenario = scenario
+	ae.continueOnCheckFailure = scenario.ContinueOnCheckFailure
+	ae.updateExpectations = scenario.UpdateExpectations
+	ae.expectationUpdates = nil
+	ae.checkFailures = nil
+	ae.txProfiles = nil
+	ae.gasTraces = nil
+	ae.currentStepIndex = 0
+	ae.checkpoints = make(map[string]*worldmock.WorldCheckpoint)
+	ae.codePaths = make(map[string]string)
+	ae.decodeWithABI = scenario.DecodeWithABI
+	ae.abis = make(map[string]*scenabi.ABI)
+	ae.World.MultiShard = scenario.MultiShard
+	ae.World.BlockProduction = convertBlockProduction(scenario.BlockProduction)
+}
+
+// stepIdent yields the id of a step, or the path in case of external steps.
+func stepIdent(generalStep scenmodel.Step) string {
+	switch step := generalStep.(type) {
+	case *scenmodel.ExternalStepsStep:
+		return step.Path
+	case *scenmodel.SetStateStep:
+		return step.SetStateIdent
+	case *scenmodel.CheckStateStep:
+		return step.CheckStateIdent
+	case *scenmodel.TxStep:
+		return step.TxIdent
+	case *scenmodel.SaveStateStep:
+		return step.Name
+	case *scenmodel.RestoreStateStep:
+		return step.Name
+	default:
+		return ""
+	}
+}
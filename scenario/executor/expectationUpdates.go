@@ -0,0 +1,166 @@
+package scenexec
+
+import (
+	"bytes"
+	"math/big"
+
+	oj "github.com/multiversx/mx-chain-scenario-go/orderedjson"
+	scenio "github.com/multiversx/mx-chain-scenario-go/scenario/io"
+	scenjwrite "github.com/multiversx/mx-chain-scenario-go/scenario/json/write"
+	scenmodel "github.com/multiversx/mx-chain-scenario-go/scenario/model"
+	worldmock "github.com/multiversx/mx-chain-scenario-go/worldmock"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+var _ scenio.ExpectationUpdater = (*ScenarioExecutor)(nil)
+
+// ExpectationUpdates yields the actual values of the expectations that did not match in the last scenario,
+// when run in update mode.
+func (ae *ScenarioExecutor) ExpectationUpdates() []*scenio.ExpectationUpdate {
+	return ae.expectationUpdates
+}
+
+// updatesExpectations is true while running the steps of the top level scenario in update mode.
+// Steps of external scenarios are checked as usual, since they belong to other files.
+func (ae *ScenarioExecutor) updatesExpectations() bool {
+	return ae.updateExpectations && ae.scenarioDepth == 1
+}
+
+func (ae *ScenarioExecutor) addExpectationUpdate(update *scenio.ExpectationUpdate) {
+	update.StepIndex = ae.currentStepIndex
+	ae.expectationUpdates = append(ae.expectationUpdates, update)
+}
+
+// updateTxExpectation records the actual values of all mismatched fields of the expect block.
+// Fields that match, including "*" values, are left as they are.
+func (ae *ScenarioExecutor) updateTxExpectation(txIndex string, expected *scenmodel.TransactionResult, output *vmcommon.VMOutput) {
+	actualOJ := scenjwrite.TransactionResultToOJ(ae.txResultFromOutput(output)).(*oj.OJsonMap)
+	fields := oj.NewMap()
+
+	if !expected.Out.CheckList(output.ReturnData) {
+		fields.Put("out", actualOJ.Get("out"))
+	}
+	if !expected.Status.Check(big.NewInt(int64(output.ReturnCode))) {
+		fields.Put("status", actualOJ.Get("status"))
+	}
+	if !expected.Message.Check([]byte(output.ReturnMessage)) {
+		fields.Put("message", fieldOrDefault(actualOJ, "message", &oj.OJsonString{}))
+	}
+	if ae.checkTxLogs(txIndex, expected.Logs, output.Logs) != nil {
+		fields.Put("logs", actualOJ.Get("logs"))
+	}
+	if ae.checkTxTransfers(txIndex, expected.Transfers, output) != nil {
+		fields.Put("transfers", scenjwrite.CheckTransfersToOJ(ae.transfersFromOutput(output)))
+	}
+	if ae.checkGas && !expected.Gas.IsUnspecified() && !expected.Gas.Check(output.GasRemaining) {
+		fields.Put("gas", actualOJ.Get("gas"))
+	}
+	if output.GasRefund != nil && !expected.Refund.Check(output.GasRefund) {
+		fields.Put("refund", actualOJ.Get("refund"))
+	}
+
+	if fields.Size() > 0 {
+		ae.addExpectationUpdate(&scenio.ExpectationUpdate{Expect: fields})
+	}
+}
+
+// updateCheckStateExpectation records the actual values of all mismatched account fields of a checkState step.
+// Unexpected accounts are added and missing ones removed.
+func (ae *ScenarioExecutor) updateCheckStateExpectation(checkAccounts *scenmodel.CheckAccounts) error {
+	update := &scenio.ExpectationUpdate{Accounts: oj.NewMap()}
+
+	for _, expectedAcct := range checkAccounts.Accounts {
+		matchingAcct, isMatch := ae.World.AcctMap[string(expectedAcct.Address.Value)]
+		if !isMatch {
+			update.RemovedAccounts = append(update.RemovedAccounts, expectedAcct.Address.Original)
+			continue
+		}
+
+		fields, err := ae.mismatchedAccountFields(expectedAcct, matchingAcct)
+		if err != nil {
+			return err
+		}
+		if fields.Size() > 0 {
+			update.Accounts.Put(expectedAcct.Address.Original, fields)
+		}
+	}
+
+	if !checkAccounts.MoreAccountsAllowed {
+		for _, worldAcctAddr := range sortedAccountAddresses(ae.World.AcctMap) {
+			if bytes.Equal([]byte(worldAcctAddr), vmcommon.SystemAccountAddress) ||
+				scenmodel.FindCheckAccount(checkAccounts.Accounts, []byte(worldAcctAddr)) != nil {
+				continue
+			}
+			accountKey, accountOJ, err := ae.accountToOJ(ae.World.AcctMap[worldAcctAddr])
+			if err != nil {
+				return err
+			}
+			update.Accounts.Put(accountKey, accountOJ)
+		}
+	}
+
+	if update.Accounts.Size() > 0 || len(update.RemovedAccounts) > 0 {
+		ae.addExpectationUpdate(update)
+	}
+	return nil
+}
+
+func (ae *ScenarioExecutor) mismatchedAccountFields(expectedAcct *scenmodel.CheckAccount, matchingAcct *worldmock.Account) (*oj.OJsonMap, error) {
+	_, actualOJ, err := ae.accountToOJ(matchingAcct)
+	if err != nil {
+		return nil, err
+	}
+	emptyString := &oj.OJsonString{}
+	fields := oj.NewMap()
+
+	if !expectedAcct.Nonce.Check(matchingAcct.Nonce) {
+		fields.Put("nonce", actualOJ.Get("nonce"))
+	}
+	if !expectedAcct.Balance.Check(matchingAcct.Balance) {
+		fields.Put("balance", actualOJ.Get("balance"))
+	}
+	if !expectedAcct.Username.Check(matchingAcct.Username) {
+		fields.Put("username", fieldOrDefault(actualOJ, "username", emptyString))
+	}
+	if len(ae.checkAccountStorage(expectedAcct, matchingAcct)) > 0 {
+		fields.Put("storage", fieldOrDefault(actualOJ, "storage", oj.NewMap()))
+	}
+	if !expectedAcct.Code.Check(matchingAcct.Code) {
+		fields.Put("code", fieldOrDefault(actualOJ, "code", emptyString))
+	}
+	if !expectedAcct.CodeMetadata.IsUnspecified() && !expectedAcct.CodeMetadata.Check(matchingAcct.CodeMetadata) {
+		fields.Put("codeMetadata", fieldOrDefault(actualOJ, "codeMetadata", emptyString))
+	}
+	if !expectedAcct.Owner.IsUnspecified() && !bytes.Equal(matchingAcct.OwnerAddress, expectedAcct.Owner.Value) {
+		fields.Put("owner", fieldOrDefault(actualOJ, "owner", emptyString))
+	}
+
+	esdtErrs, err := ae.checkAccountESDT(expectedAcct, matchingAcct)
+	if err != nil {
+		return nil, err
+	}
+	if len(esdtErrs) > 0 {
+		fields.Put("esdt", fieldOrDefault(actualOJ, "esdt", oj.NewMap()))
+	}
+
+	return fields, nil
+}
+
+// accountToOJ yields the full state of an account, in the format of the scenario files, along with its key.
+func (ae *ScenarioExecutor) accountToOJ(account *worldmock.Account) (string, *oj.OJsonMap, error) {
+	scenAccount, err := ae.convertFullMockAccountToScenarioFormat(account)
+	if err != nil {
+		return "", nil, err
+	}
+	accountsOJ := scenjwrite.AccountsToOJ([]*scenmodel.Account{scenAccount}).(*oj.OJsonMap)
+	accountKV := accountsOJ.OrderedKV[0]
+	return accountKV.Key, accountKV.Value.(*oj.OJsonMap), nil
+}
+
+func fieldOrDefault(ojMap *oj.OJsonMap, key string, defaultValue oj.OJsonObject) oj.OJsonObject {
+	value := ojMap.Get(key)
+	if value == nil {
+		return defaultValue
+	}
+	return value
+}
@@ -1,30 +1,76 @@
 package scenexec
 
 import (
-	"fmt"
+	"sort"
 
+	er "github.com/multiversx/mx-chain-scenario-go/scenario/expression/reconstructor"
+	scenio "github.com/multiversx/mx-chain-scenario-go/scenario/io"
 	scenmodel "github.com/multiversx/mx-chain-scenario-go/scenario/model"
 )
 
-func logGasTrace(ae *ScenarioExecutor) {
-	if ae.PeekTraceGas() {
-		scGasTrace := ae.vm.GetGasTrace()
-		totalGasUsedByAPIs := 0
-		for scAddress, gasTrace := range scGasTrace {
-			fmt.Println("Gas Trace for: ", "SC Address", scAddress)
-			for functionName, value := range gasTrace {
-				totalGasUsed := uint64(0)
-				for _, usedGas := range value {
-					totalGasUsed += usedGas
-				}
-				fmt.Println("GasTrace: functionName:", functionName, ",  totalGasUsed:", totalGasUsed, ", numberOfCalls:", len(value))
-				totalGasUsedByAPIs += int(totalGasUsed)
+// captureGasTrace converts the VM gas trace of a transaction into the structured model,
+// keeps it for the scenario result and passes it to the gas trace handler, if any.
+func captureGasTrace(ae *ScenarioExecutor, step *scenmodel.TxStep) {
+	if !ae.PeekTraceGas() {
+		return
+	}
+
+	gasTrace := &scenio.TxGasTrace{
+		StepID:    step.TxIdent,
+		StepType:  step.StepTypeName(),
+		Contracts: make([]*scenio.GasTraceContract, 0),
+	}
+	scGasTrace := ae.vm.GetGasTrace()
+	scAddresses := make([]string, 0, len(scGasTrace))
+	for scAddress := range scGasTrace {
+		scAddresses = append(scAddresses, scAddress)
+	}
+	sort.Strings(scAddresses)
+	for _, scAddress := range scAddresses {
+		contractTrace := &scenio.GasTraceContract{
+			Address:   ae.exprReconstructor.Reconstruct([]byte(scAddress), er.AddressHint),
+			Functions: make([]*scenio.GasTraceFunction, 0),
+		}
+		functionNames := make([]string, 0, len(scGasTrace[scAddress]))
+		for functionName := range scGasTrace[scAddress] {
+			functionNames = append(functionNames, functionName)
+		}
+		sort.Strings(functionNames)
+		for _, functionName := range functionNames {
+			usedGasList := scGasTrace[scAddress][functionName]
+			functionTrace := &scenio.GasTraceFunction{
+				Function:  functionName,
+				CallCount: len(usedGasList),
+				Gas:       usedGasList,
+			}
+			for _, usedGas := range usedGasList {
+				functionTrace.TotalGas += usedGas
 			}
-			fmt.Println("TotalGasUsedByAPIs: ", totalGasUsedByAPIs)
+			contractTrace.TotalGas += functionTrace.TotalGas
+			contractTrace.Functions = append(contractTrace.Functions, functionTrace)
 		}
+		gasTrace.Contracts = append(gasTrace.Contracts, contractTrace)
 	}
+
+	ae.gasTraces = append(ae.gasTraces, gasTrace)
+	if ae.gasTraceHandler != nil {
+		ae.gasTraceHandler(gasTrace)
+	}
+}
+
+// SetGasTraceHandler registers a callback receiving the gas trace of every transaction,
+// as soon as it executes, whenever gas tracing is enabled.
+func (ae *ScenarioExecutor) SetGasTraceHandler(handler func(*scenio.TxGasTrace)) {
+	ae.gasTraceHandler = handler
 }
 
+// TxGasTraces yields the gas traces of the transactions in the last scenario, including external steps.
+func (ae *ScenarioExecutor) TxGasTraces() []*scenio.TxGasTrace {
+	return ae.gasTraces
+}
+
+var _ scenio.GasTracer = (*ScenarioExecutor)(nil)
+
 func setGasTraceInMetering(ae *ScenarioExecutor, enable bool) {
 	if enable && ae.PeekTraceGas() {
 		ae.vm.SetGasTracing(true)
@@ -0,0 +1,86 @@
+package scenexec
+
+import (
+	scenmodel "github.com/multiversx/mx-chain-scenario-go/scenario/model"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+// ScenarioObserver is notified of everything the executor does while running scenarios.
+// Steps of external scenarios are also reported, after their externalSteps step begins.
+// Steps executed by calling the public Execute*Step methods directly are reported the same way.
+type ScenarioObserver interface {
+	// BeforeStep is called before executing any step.
+	BeforeStep(step scenmodel.Step)
+
+	// AfterStep is called after executing any step, with the error of the step, if any.
+	AfterStep(step scenmodel.Step, err error)
+
+	// BeforeTx is called before executing a transaction, with its VM input.
+	// The input is also provided for transactions that do not reach the VM, such as transfers.
+	BeforeTx(step *scenmodel.TxStep, input *vmcommon.ContractCallInput)
+
+	// AfterTx is called after executing a transaction and before checking its results.
+	// The output is nil if the transaction could not be executed, in which case err is set.
+	AfterTx(step *scenmodel.TxStep, output *vmcommon.VMOutput, err error)
+
+	// OnCheckFailure is called when a checkState step or the expected results of a transaction do not match.
+	OnCheckFailure(step scenmodel.Step, err error)
+
+	// OnWorldCommit is called after the changes of a successful transaction are committed to the world.
+	OnWorldCommit(txID string)
+
+	// OnWorldRollback is called after the changes of a failed transaction are rolled back,
+	// and after those of a failed cross-shard message, which do not revert the transaction.
+	OnWorldRollback(txID string, cause error)
+}
+
+// NoOpScenarioObserver implements all ScenarioObserver methods, doing nothing.
+// Embed it in observers that only need some of the callbacks.
+type NoOpScenarioObserver struct{}
+
+var _ ScenarioObserver = (*NoOpScenarioObserver)(nil)
+
+// BeforeStep does nothing.
+func (*NoOpScenarioObserver) BeforeStep(step scenmodel.Step) {}
+
+// AfterStep does nothing.
+func (*NoOpScenarioObserver) AfterStep(step scenmodel.Step, err error) {}
+
+// BeforeTx does nothing.
+func (*NoOpScenarioObserver) BeforeTx(step *scenmodel.TxStep, input *vmcommon.ContractCallInput) {}
+
+// AfterTx does nothing.
+func (*NoOpScenarioObserver) AfterTx(step *scenmodel.TxStep, output *vmcommon.VMOutput, err error) {}
+
+// OnCheckFailure does nothing.
+func (*NoOpScenarioObserver) OnCheckFailure(step scenmodel.Step, err error) {}
+
+// OnWorldCommit does nothing.
+func (*NoOpScenarioObserver) OnWorldCommit(txID string) {}
+
+// OnWorldRollback does nothing.
+func (*NoOpScenarioObserver) OnWorldRollback(txID string, cause error) {}
+
+// AddObserver registers an observer. Observers are notified in the order they were added.
+func (ae *ScenarioExecutor) AddObserver(observer ScenarioObserver) {
+	ae.observers = append(ae.observers, observer)
+}
+
+func (ae *ScenarioExecutor) notifyBeforeStep(step scenmodel.Step) {
+	for _, observer := range ae.observers {
+		observer.BeforeStep(step)
+	}
+}
+
+func (ae *ScenarioExecutor) notifyAfterStep(step scenmodel.Step, err error) {
+	// failures inside external steps were already reported by the nested step
+	_, isExternal := step.(*scenmodel.ExternalStepsStep)
+	if !isExternal && isCheckFailure(err) {
+		for _, observer := range ae.observers {
+			observer.OnCheckFailure(step, err)
+		}
+	}
+	for _, observer := range ae.observers {
+		observer.AfterStep(step, err)
+	}
+}
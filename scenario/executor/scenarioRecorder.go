@@ -0,0 +1,316 @@
+package scenexec
+
+import (
+	"math/big"
+	"strings"
+
+	oj "github.com/multiversx/mx-chain-scenario-go/orderedjson"
+	er "github.com/multiversx/mx-chain-scenario-go/scenario/expression/reconstructor"
+	scenio "github.com/multiversx/mx-chain-scenario-go/scenario/io"
+	scenmodel "github.com/multiversx/mx-chain-scenario-go/scenario/model"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+// ScenarioRecorder records everything an executor runs, including steps called directly from Go code,
+// and turns it into a self-contained scenario that can be saved and replayed.
+//
+// Steps of external scenarios are recorded inline. Accounts in setState steps are recorded
+// with their full state right after the step, code included, and values read from files
+// in other steps are also written inline, so no other files are needed.
+type ScenarioRecorder struct {
+	NoOpScenarioObserver
+	executor      *ScenarioExecutor
+	expectOutputs bool
+	lastTxOutput  *vmcommon.VMOutput
+	steps         []scenmodel.Step
+}
+
+var _ ScenarioObserver = (*ScenarioRecorder)(nil)
+
+// NewScenarioRecorder creates a recorder and registers it as an observer of the executor.
+// If expectOutputs is set, the expect blocks of transactions are replaced with the observed outputs,
+// and the recorded scenario ends with a checkState of the final state of all accounts.
+func NewScenarioRecorder(executor *ScenarioExecutor, expectOutputs bool) *ScenarioRecorder {
+	recorder := &ScenarioRecorder{
+		executor:      executor,
+		expectOutputs: expectOutputs,
+	}
+	executor.AddObserver(recorder)
+	return recorder
+}
+
+// AfterTx keeps the output of the transaction, until its step completes.
+func (sr *ScenarioRecorder) AfterTx(step *scenmodel.TxStep, output *vmcommon.VMOutput, err error) {
+	sr.lastTxOutput = output
+}
+
+// AfterStep records the step.
+func (sr *ScenarioRecorder) AfterStep(step scenmodel.Step, err error) {
+	switch typedStep := step.(type) {
+	case *scenmodel.SetStateStep:
+		sr.steps = append(sr.steps, sr.recordSetStateStep(typedStep))
+	case *scenmodel.TxStep:
+		sr.steps = append(sr.steps, sr.recordTxStep(typedStep, sr.lastTxOutput))
+		sr.lastTxOutput = nil
+	case *scenmodel.CheckStateStep:
+		sr.steps = append(sr.steps, sr.recordCheckStateStep(typedStep))
+	case *scenmodel.DumpStateStep, *scenmodel.SaveStateStep, *scenmodel.RestoreStateStep, *scenmodel.AdvanceBlocksStep:
+		sr.steps = append(sr.steps, step)
+	}
+}
+
+// Scenario yields the recorded scenario.
+func (sr *ScenarioRecorder) Scenario() *scenmodel.Scenario {
+	steps := make([]scenmodel.Step, len(sr.steps), len(sr.steps)+1)
+	copy(steps, sr.steps)
+	if sr.expectOutputs {
+		steps = append(steps, sr.finalCheckStateStep())
+	}
+
+	scenario := &scenmodel.Scenario{
+		Name:     "recorded scenario",
+		CheckGas: sr.expectOutputs,
+		Steps:    steps,
+	}
+	// the replay needs to run in the same mode as the recorded scenario
+	if recordedScenario := sr.executor.topLevelScenario; recordedScenario != nil {
+		scenario.GasSchedule = recordedScenario.GasSchedule
+		scenario.MultiShard = recordedScenario.MultiShard
+		scenario.BlockProduction = recordedScenario.BlockProduction
+	}
+	return scenario
+}
+
+// Save writes the recorded scenario to a file.
+func (sr *ScenarioRecorder) Save(toPath string) error {
+	return scenio.WriteScenariosScenario(sr.Scenario(), toPath)
+}
+
+func (sr *ScenarioRecorder) recordSetStateStep(step *scenmodel.SetStateStep) *scenmodel.SetStateStep {
+	recorded := &scenmodel.SetStateStep{
+		SetStateIdent:     step.SetStateIdent,
+		Comment:           step.Comment,
+		PreviousBlockInfo: step.PreviousBlockInfo,
+		CurrentBlockInfo:  step.CurrentBlockInfo,
+		BlockHashes:       step.BlockHashes,
+		NewAddressMocks:   step.NewAddressMocks,
+	}
+	for _, account := range step.Accounts {
+		worldAccount, found := sr.executor.World.AcctMap[string(account.Address.Value)]
+		if !found {
+			continue
+		}
+		recordedAccount, err := sr.executor.convertFullMockAccountToScenarioFormat(worldAccount)
+		if err != nil {
+			log.Warn("could not record account", "address", account.Address.Original, "err", err)
+			continue
+		}
+		recorded.Accounts = append(recorded.Accounts, recordedAccount)
+	}
+	return recorded
+}
+
+func (sr *ScenarioRecorder) recordCheckStateStep(step *scenmodel.CheckStateStep) *scenmodel.CheckStateStep {
+	if step.CheckAccounts == nil {
+		return step
+	}
+
+	recorded := &scenmodel.CheckStateStep{
+		CheckStateIdent: step.CheckStateIdent,
+		Comment:         step.Comment,
+		CheckAccounts: &scenmodel.CheckAccounts{
+			MoreAccountsAllowed: step.CheckAccounts.MoreAccountsAllowed,
+		},
+	}
+	for _, checkAccount := range step.CheckAccounts.Accounts {
+		recordedAccount := *checkAccount
+		recordedAccount.Username = sr.inlineCheckBytes(checkAccount.Username, er.StrHint)
+		recordedAccount.Code = sr.inlineCheckBytes(checkAccount.Code, er.CodeHint)
+		recordedAccount.CodeMetadata = sr.inlineCheckBytes(checkAccount.CodeMetadata, er.HexHint)
+		recordedAccount.Owner = sr.inlineCheckBytes(checkAccount.Owner, er.AddressHint)
+		recordedAccount.AsyncCallData = sr.inlineCheckBytes(checkAccount.AsyncCallData, er.NoHint)
+
+		recordedAccount.CheckStorage = nil
+		for _, storagePair := range checkAccount.CheckStorage {
+			recordedAccount.CheckStorage = append(recordedAccount.CheckStorage, &scenmodel.CheckStorageKeyValuePair{
+				Key:        storagePair.Key,
+				CheckValue: sr.inlineCheckBytes(storagePair.CheckValue, er.NoHint),
+			})
+		}
+
+		recordedAccount.CheckESDTData = nil
+		for _, esdtData := range checkAccount.CheckESDTData {
+			recordedESDT := *esdtData
+			recordedESDT.Instances = nil
+			for _, instance := range esdtData.Instances {
+				recordedInstance := *instance
+				recordedInstance.Creator = sr.inlineCheckBytes(instance.Creator, er.AddressHint)
+				recordedInstance.Hash = sr.inlineCheckBytes(instance.Hash, er.NoHint)
+				recordedInstance.Uris = sr.inlineCheckValueList(instance.Uris)
+				recordedInstance.Attributes = sr.inlineCheckBytes(instance.Attributes, er.NoHint)
+				recordedESDT.Instances = append(recordedESDT.Instances, &recordedInstance)
+			}
+			recordedAccount.CheckESDTData = append(recordedAccount.CheckESDTData, &recordedESDT)
+		}
+
+		recorded.CheckAccounts.Accounts = append(recorded.CheckAccounts.Accounts, &recordedAccount)
+	}
+	return recorded
+}
+
+func (sr *ScenarioRecorder) recordTxStep(step *scenmodel.TxStep, output *vmcommon.VMOutput) *scenmodel.TxStep {
+	expectedResult := step.ExpectedResult
+	if sr.expectOutputs && output != nil {
+		expectedResult = sr.executor.txResultFromOutput(output)
+	} else if expectedResult != nil {
+		recordedResult := *expectedResult
+		recordedResult.Out = sr.inlineCheckValueList(expectedResult.Out)
+		expectedResult = &recordedResult
+	}
+
+	return &scenmodel.TxStep{
+		TxIdent:        step.TxIdent,
+		Comment:        step.Comment,
+		DisplayLogs:    step.DisplayLogs,
+		DisplayDiff:    step.DisplayDiff,
+		Tx:             sr.txWithOriginals(step.Tx),
+		ExpectedResult: expectedResult,
+	}
+}
+
+// txWithOriginals yields a copy of the transaction where all missing original expressions are filled in,
+// since transactions built in Go code usually only have values.
+func (sr *ScenarioRecorder) txWithOriginals(tx *scenmodel.Transaction) *scenmodel.Transaction {
+	recorded := *tx
+	recorded.From = sr.withOriginal(tx.From, er.AddressHint)
+	recorded.To = sr.withOriginal(tx.To, er.AddressHint)
+	recorded.Code = sr.withOriginal(tx.Code, er.CodeHint)
+	recorded.CodeMetadata = sr.withOriginal(tx.CodeMetadata, er.HexHint)
+	recorded.Nonce = sr.uint64WithOriginal(tx.Nonce)
+	recorded.GasLimit = sr.uint64WithOriginal(tx.GasLimit)
+	recorded.GasPrice = sr.uint64WithOriginal(tx.GasPrice)
+	if len(tx.EGLDValue.Original) == 0 && tx.EGLDValue.Value != nil {
+		recorded.EGLDValue = sr.bigIntExpression(tx.EGLDValue.Value)
+	}
+
+	recorded.ESDTValue = nil
+	for _, esdtValue := range tx.ESDTValue {
+		recordedESDT := &scenmodel.ESDTTxData{
+			TokenIdentifier: sr.withOriginal(esdtValue.TokenIdentifier, er.StrHint),
+			Nonce:           sr.uint64WithOriginal(esdtValue.Nonce),
+			Value:           esdtValue.Value,
+		}
+		if len(esdtValue.Value.Original) == 0 && esdtValue.Value.Value != nil {
+			recordedESDT.Value = sr.bigIntExpression(esdtValue.Value.Value)
+		}
+		recorded.ESDTValue = append(recorded.ESDTValue, recordedESDT)
+	}
+
+	// typed arguments are already encoded, and the replay might not have the ABI to encode them again
+	recorded.TypedArguments = nil
+	recorded.Arguments = nil
+	for _, argument := range tx.Arguments {
+		if (argument.OriginalEmpty() && len(argument.Value) > 0) || referencesFile(argument.Original) {
+			argument = scenmodel.JSONBytesFromTree{
+				Value:    argument.Value,
+				Original: &oj.OJsonString{Value: sr.executor.exprReconstructor.ReconstructExpression(argument.Value, er.NoHint)},
+			}
+		}
+		recorded.Arguments = append(recorded.Arguments, argument)
+	}
+	return &recorded
+}
+
+// finalCheckStateStep checks the nonce, balance, code and storage of all accounts in the world.
+// ESDT tokens are not checked.
+func (sr *ScenarioRecorder) finalCheckStateStep() *scenmodel.CheckStateStep {
+	checkAccounts := &scenmodel.CheckAccounts{}
+	for _, address := range sortedAccountAddresses(sr.executor.World.AcctMap) {
+		if address == string(vmcommon.SystemAccountAddress) {
+			continue
+		}
+		checkAccount, err := sr.executor.checkAccountFromWorld(sr.executor.World.AcctMap[address], CheckStateOptions{IgnoreESDT: true})
+		if err != nil {
+			log.Warn("could not record account", "address", address, "err", err)
+			continue
+		}
+		checkAccounts.Accounts = append(checkAccounts.Accounts, checkAccount)
+	}
+
+	return &scenmodel.CheckStateStep{
+		Comment:       "final state, as recorded",
+		CheckAccounts: checkAccounts,
+	}
+}
+
+func (sr *ScenarioRecorder) withOriginal(value scenmodel.JSONBytesFromString, hint er.ExprReconstructorHint) scenmodel.JSONBytesFromString {
+	if (len(value.Original) > 0 && !isFileExpression(value.Original)) || len(value.Value) == 0 {
+		return value
+	}
+	return sr.executor.bytesExpression(value.Value, hint)
+}
+
+func (sr *ScenarioRecorder) uint64WithOriginal(value scenmodel.JSONUint64) scenmodel.JSONUint64 {
+	if len(value.Original) > 0 {
+		return value
+	}
+	return scenmodel.JSONUint64{
+		Value:    value.Value,
+		Original: sr.executor.exprReconstructor.ReconstructFromUint64(value.Value),
+	}
+}
+
+func (sr *ScenarioRecorder) bigIntExpression(value *big.Int) scenmodel.JSONBigInt {
+	return scenmodel.JSONBigInt{
+		Value:    value,
+		Original: sr.executor.exprReconstructor.ReconstructFromBigInt(value),
+	}
+}
+
+// inlineCheckBytes replaces expected values read from files with the values themselves.
+// Only equality checks are rewritten, operators keep their original form.
+func (sr *ScenarioRecorder) inlineCheckBytes(value scenmodel.JSONCheckBytes, hint er.ExprReconstructorHint) scenmodel.JSONCheckBytes {
+	if value.IsStar || value.Operator != scenmodel.CheckOperatorEqual || !referencesFile(value.Original) {
+		return value
+	}
+	return scenmodel.JSONCheckBytesReconstructed(value.Value, sr.executor.exprReconstructor.ReconstructExpression(value.Value, hint))
+}
+
+func (sr *ScenarioRecorder) inlineCheckValueList(values scenmodel.JSONCheckValueList) scenmodel.JSONCheckValueList {
+	recorded := values
+	recorded.Values = nil
+	for _, value := range values.Values {
+		recorded.Values = append(recorded.Values, sr.inlineCheckBytes(value, er.NoHint))
+	}
+	return recorded
+}
+
+// referencesFile is true if any expression in the JSON tree reads a file.
+func referencesFile(original oj.OJsonObject) bool {
+	switch typed := original.(type) {
+	case *oj.OJsonString:
+		return isFileExpression(typed.Value)
+	case *oj.OJsonList:
+		for _, item := range typed.AsList() {
+			if referencesFile(item) {
+				return true
+			}
+		}
+	case *oj.OJsonMap:
+		for _, keyValue := range typed.OrderedKV {
+			if referencesFile(keyValue.Value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isFileExpression(expression string) bool {
+	for _, prefix := range codeFilePrefixes {
+		if strings.HasPrefix(expression, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+package scenexec
+
+import (
+	scenmodel "github.com/multiversx/mx-chain-scenario-go/scenario/model"
+	worldmock "github.com/multiversx/mx-chain-scenario-go/worldmock"
+)
+
+// ExecuteAdvanceBlocksStep executes an AdvanceBlocksStep, producing empty blocks.
+func (ae *ScenarioExecutor) ExecuteAdvanceBlocksStep(step *scenmodel.AdvanceBlocksStep) error {
+	ae.notifyBeforeStep(step)
+	if len(step.Comment) > 0 {
+		log.Trace("AdvanceBlocksStep", "comment", step.Comment)
+	}
+
+	numBlocks := step.Blocks.Value
+	if !step.Seconds.OriginalEmpty() {
+		numBlocks = ae.World.BlocksForDuration(step.Seconds.Value * 1000)
+	}
+	ae.World.ProduceBlocks(numBlocks)
+
+	ae.notifyAfterStep(step, nil)
+	return nil
+}
+
+func convertBlockProduction(testBlockProduction *scenmodel.BlockProduction) *worldmock.BlockProduction {
+	if testBlockProduction == nil {
+		return nil
+	}
+
+	return &worldmock.BlockProduction{
+		RoundDurationMs: testBlockProduction.RoundDurationMs.Value,
+		TxsPerBlock:     testBlockProduction.TxsPerBlock.Value,
+		RoundsPerEpoch:  testBlockProduction.RoundsPerEpoch.Value,
+	}
+}
@@ -3,8 +3,10 @@ package scenexec
 import (
 	"bytes"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"math/big"
+	"sort"
 	"strings"
 
 	oj "github.com/multiversx/mx-chain-scenario-go/orderedjson"
@@ -20,12 +22,23 @@ import (
 
 // ExecuteCheckStateStep executes a CheckStateStep defined by the current scenario.
 func (ae *ScenarioExecutor) ExecuteCheckStateStep(step *scenmodel.CheckStateStep) error {
+	ae.notifyBeforeStep(step)
+	err := ae.executeCheckStateStep(step)
+	ae.notifyAfterStep(step, err)
+	return err
+}
+
+func (ae *ScenarioExecutor) executeCheckStateStep(step *scenmodel.CheckStateStep) error {
 	if len(step.Comment) > 0 {
 		log.Trace("CheckStateStep", "comment", step.Comment)
 	}
 
+	if ae.updatesExpectations() {
+		return ae.updateCheckStateExpectation(step.CheckAccounts)
+	}
+
 	baseErrMsg := checkStateBaseErrorMsg(step)
-	return ae.checkAccounts(baseErrMsg, step.CheckAccounts)
+	return newCheckFailure(ae.checkAccounts(baseErrMsg, step.CheckAccounts))
 }
 
 func checkStateBaseErrorMsg(step *scenmodel.CheckStateStep) string {
@@ -35,16 +48,19 @@ func checkStateBaseErrorMsg(step *scenmodel.CheckStateStep) string {
 	return "Check state:"
 }
 
+// checkAccounts verifies all accounts and all their fields, and reports all mismatches at once,
+// grouped by account.
 func (ae *ScenarioExecutor) checkAccounts(baseErrMsg string, checkAccounts *scenmodel.CheckAccounts) error {
+	var accountErrors []string
 	if !checkAccounts.MoreAccountsAllowed {
-		for worldAcctAddr := range ae.World.AcctMap {
+		for _, worldAcctAddr := range sortedAccountAddresses(ae.World.AcctMap) {
 			postAcctMatch := scenmodel.FindCheckAccount(checkAccounts.Accounts, []byte(worldAcctAddr))
 			if postAcctMatch == nil && !bytes.Equal([]byte(worldAcctAddr), vmcommon.SystemAccountAddress) {
-				return fmt.Errorf("%s unexpected account address: %s",
+				accountErrors = append(accountErrors, fmt.Sprintf("%s unexpected account address: %s",
 					baseErrMsg,
 					ae.exprReconstructor.Reconstruct(
 						[]byte(worldAcctAddr),
-						er.AddressHint))
+						er.AddressHint)))
 			}
 		}
 	}
@@ -52,101 +68,118 @@ func (ae *ScenarioExecutor) checkAccounts(baseErrMsg string, checkAccounts *scen
 	for _, expectedAcct := range checkAccounts.Accounts {
 		matchingAcct, isMatch := ae.World.AcctMap[string(expectedAcct.Address.Value)]
 		if !isMatch {
-			return fmt.Errorf("%s account %s expected but not found after running test",
+			accountErrors = append(accountErrors, fmt.Sprintf("%s account %s expected but not found after running test",
 				baseErrMsg,
-				expectedAcct.Address.Original)
+				expectedAcct.Address.Original))
+			continue
 		}
 
-		if !bytes.Equal(matchingAcct.Address, expectedAcct.Address.Value) {
-			return fmt.Errorf("%s bad account address %s",
-				baseErrMsg,
-				ae.exprReconstructor.Reconstruct(
-					matchingAcct.Address,
-					er.AddressHint))
+		errs, err := ae.checkAccount(expectedAcct, matchingAcct)
+		if err != nil {
+			return err
 		}
 
-		if !expectedAcct.Nonce.Check(matchingAcct.Nonce) {
-			return fmt.Errorf("%s bad account nonce. Account: %s. Want: \"%s\". Have: \"%d\"",
+		errorString := makeErrorString(errs)
+		if len(errorString) > 0 {
+			accountErrors = append(accountErrors, fmt.Sprintf("%s mismatch for account \"%s\":%s",
 				baseErrMsg,
 				expectedAcct.Address.Original,
-				expectedAcct.Nonce.Original,
-				matchingAcct.Nonce)
+				errorString))
 		}
+	}
 
-		if !expectedAcct.Balance.Check(matchingAcct.Balance) {
-			return fmt.Errorf("%s bad account balance. Account: %s. Want: \"%s\". Have: \"%s\"",
-				baseErrMsg,
-				expectedAcct.Address.Original,
-				expectedAcct.Balance.Original,
-				ae.exprReconstructor.ReconstructFromBigInt(matchingAcct.Balance))
-		}
+	if len(accountErrors) > 0 {
+		return errors.New(strings.Join(accountErrors, "\n"))
+	}
+	return nil
+}
 
-		if !expectedAcct.Username.Check(matchingAcct.Username) {
-			return fmt.Errorf("%s bad account username. Account: %s. Want: %s. Have: \"%s\"",
-				baseErrMsg,
-				expectedAcct.Address.Original,
-				oj.JSONString(expectedAcct.Username.Original),
-				ae.exprReconstructor.Reconstruct(
-					matchingAcct.Username,
-					er.StrHint))
-		}
+func sortedAccountAddresses(acctMap worldmock.AccountMap) []string {
+	addresses := make([]string, 0, len(acctMap))
+	for address := range acctMap {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+	return addresses
+}
 
-		if !expectedAcct.Code.Check(matchingAcct.Code) {
-			return fmt.Errorf("%s bad account code. Account: %s. Want: %s. Have: \"%s\"",
-				baseErrMsg,
-				expectedAcct.Address.Original,
-				oj.JSONString(expectedAcct.Code.Original),
-				ae.exprReconstructor.Reconstruct(
-					matchingAcct.Code,
-					er.CodeHint))
-		}
+// checkAccount yields all mismatches between an expected account and the actual one.
+// The returned error is only set if the check itself could not be performed.
+func (ae *ScenarioExecutor) checkAccount(expectedAcct *scenmodel.CheckAccount, matchingAcct *worldmock.Account) ([]error, error) {
+	var errs []error
 
-		if !expectedAcct.CodeMetadata.IsUnspecified() &&
-			!expectedAcct.CodeMetadata.Check(matchingAcct.CodeMetadata) {
-			return fmt.Errorf("%s bad account code metadata. Account: %s. Want: %s. Have: \"%s\"",
-				baseErrMsg,
-				expectedAcct.Address.Original,
-				oj.JSONString(expectedAcct.CodeMetadata.Original),
-				ae.exprReconstructor.Reconstruct(
-					matchingAcct.CodeMetadata,
-					er.HexHint))
-		}
+	if !bytes.Equal(matchingAcct.Address, expectedAcct.Address.Value) {
+		errs = append(errs, fmt.Errorf("bad account address %s",
+			ae.exprReconstructor.Reconstruct(
+				matchingAcct.Address,
+				er.AddressHint)))
+	}
 
-		if !expectedAcct.Owner.IsUnspecified() && !bytes.Equal(matchingAcct.OwnerAddress, expectedAcct.Owner.Value) {
-			return fmt.Errorf("%s bad account owner. Account: %s. Want: %s. Have: \"%s\"",
-				baseErrMsg,
-				expectedAcct.Address.Original,
-				oj.JSONString(expectedAcct.Owner.Original),
-				ae.exprReconstructor.Reconstruct(
-					matchingAcct.OwnerAddress,
-					er.AddressHint))
-		}
+	if !expectedAcct.Nonce.Check(matchingAcct.Nonce) {
+		errs = append(errs, fmt.Errorf("bad account nonce. Want: \"%s\". Have: \"%d\"",
+			expectedAcct.Nonce.Original,
+			matchingAcct.Nonce))
+	}
 
-		// currently ignoring asyncCallData that is unspecified in the json
-		if !expectedAcct.AsyncCallData.IsUnspecified() &&
-			!expectedAcct.AsyncCallData.Check([]byte(matchingAcct.AsyncCallData)) {
-			return fmt.Errorf("%s bad async call data. Account: %s. Want: [%s]. Have: [%s]",
-				baseErrMsg,
-				expectedAcct.Address.Original,
-				expectedAcct.AsyncCallData.Original,
-				matchingAcct.AsyncCallData)
-		}
+	if !expectedAcct.Balance.Check(matchingAcct.Balance) {
+		errs = append(errs, fmt.Errorf("bad account balance. Want: \"%s\". Have: \"%s\"",
+			expectedAcct.Balance.Original,
+			ae.exprReconstructor.ReconstructFromBigInt(matchingAcct.Balance)))
+	}
 
-		err := ae.checkAccountStorage(baseErrMsg, expectedAcct, matchingAcct)
-		if err != nil {
-			return err
-		}
+	if !expectedAcct.Username.Check(matchingAcct.Username) {
+		errs = append(errs, fmt.Errorf("bad account username. Want: %s. Have: \"%s\"",
+			oj.JSONString(expectedAcct.Username.Original),
+			ae.exprReconstructor.Reconstruct(
+				matchingAcct.Username,
+				er.StrHint)))
+	}
 
-		err = ae.checkAccountESDT(baseErrMsg, expectedAcct, matchingAcct)
-		if err != nil {
-			return err
-		}
+	if !expectedAcct.Code.Check(matchingAcct.Code) {
+		errs = append(errs, fmt.Errorf("bad account code. Want: %s. Have: \"%s\"",
+			oj.JSONString(expectedAcct.Code.Original),
+			ae.exprReconstructor.Reconstruct(
+				matchingAcct.Code,
+				er.CodeHint)))
 	}
 
-	return nil
+	if !expectedAcct.CodeMetadata.IsUnspecified() &&
+		!expectedAcct.CodeMetadata.Check(matchingAcct.CodeMetadata) {
+		errs = append(errs, fmt.Errorf("bad account code metadata. Want: %s. Have: \"%s\"",
+			oj.JSONString(expectedAcct.CodeMetadata.Original),
+			ae.exprReconstructor.Reconstruct(
+				matchingAcct.CodeMetadata,
+				er.HexHint)))
+	}
+
+	if !expectedAcct.Owner.IsUnspecified() && !bytes.Equal(matchingAcct.OwnerAddress, expectedAcct.Owner.Value) {
+		errs = append(errs, fmt.Errorf("bad account owner. Want: %s. Have: \"%s\"",
+			oj.JSONString(expectedAcct.Owner.Original),
+			ae.exprReconstructor.Reconstruct(
+				matchingAcct.OwnerAddress,
+				er.AddressHint)))
+	}
+
+	// currently ignoring asyncCallData that is unspecified in the json
+	if !expectedAcct.AsyncCallData.IsUnspecified() &&
+		!expectedAcct.AsyncCallData.Check([]byte(matchingAcct.AsyncCallData)) {
+		errs = append(errs, fmt.Errorf("bad async call data. Want: [%s]. Have: [%s]",
+			objectStringOrDefault(expectedAcct.AsyncCallData.Original),
+			matchingAcct.AsyncCallData))
+	}
+
+	errs = append(errs, ae.checkAccountStorage(expectedAcct, matchingAcct)...)
+
+	esdtErrs, err := ae.checkAccountESDT(expectedAcct, matchingAcct)
+	if err != nil {
+		return nil, err
+	}
+	errs = append(errs, esdtErrs...)
+
+	return errs, nil
 }
 
-func (ae *ScenarioExecutor) checkAccountStorage(baseErrMsg string, expectedAcct *scenmodel.CheckAccount, matchingAcct *worldmock.Account) error {
+func (ae *ScenarioExecutor) checkAccountStorage(expectedAcct *scenmodel.CheckAccount, matchingAcct *worldmock.Account) []error {
 	if expectedAcct.IgnoreStorage {
 		return nil
 	}
@@ -163,8 +196,14 @@ func (ae *ScenarioExecutor) checkAccountStorage(baseErrMsg string, expectedAcct
 	for k := range matchingAcct.Storage {
 		allKeys[k] = true
 	}
-	storageError := ""
+	sortedKeys := make([]string, 0, len(allKeys))
 	for k := range allKeys {
+		sortedKeys = append(sortedKeys, k)
+	}
+	sort.Strings(sortedKeys)
+
+	var errs []error
+	for _, k := range sortedKeys {
 		// ignore all reserved keys
 		if strings.HasPrefix(k, core.ProtectedKeyPrefix) {
 			continue
@@ -184,24 +223,20 @@ func (ae *ScenarioExecutor) checkAccountStorage(baseErrMsg string, expectedAcct
 		have := matchingAcct.StorageValue(k)
 
 		if !want.Check(have) {
-			storageError += fmt.Sprintf(
-				"\n  for key %s: Want: %s. Have: \"%s\"",
+			errs = append(errs, fmt.Errorf(
+				"bad storage value for key %s. Want: %s. Have: \"%s\"%s",
 				ae.exprReconstructor.Reconstruct([]byte(k), er.NoHint),
 				oj.JSONString(want.Original),
-				ae.exprReconstructor.Reconstruct(have, er.NoHint))
+				ae.exprReconstructor.Reconstruct(have, er.NoHint),
+				decodedSuffix(ae.decodedStorage(matchingAcct.Address, []byte(k), have))))
 		}
 	}
-	if len(storageError) > 0 {
-		return fmt.Errorf("%s wrong account storage for account \"%s\":%s",
-			baseErrMsg,
-			expectedAcct.Address.Original, storageError)
-	}
-	return nil
+	return errs
 }
 
-func (ae *ScenarioExecutor) checkAccountESDT(baseErrMsg string, expectedAcct *scenmodel.CheckAccount, matchingAcct *worldmock.Account) error {
+func (ae *ScenarioExecutor) checkAccountESDT(expectedAcct *scenmodel.CheckAccount, matchingAcct *worldmock.Account) ([]error, error) {
 	if expectedAcct.IgnoreESDT {
-		return nil
+		return nil, nil
 	}
 
 	systemAccStorage := make(map[string][]byte)
@@ -214,7 +249,7 @@ func (ae *ScenarioExecutor) checkAccountESDT(baseErrMsg string, expectedAcct *sc
 	expectedTokens := getExpectedTokens(expectedAcct)
 	accountTokens, err := esdtconvert.GetFullMockESDTData(matchingAcct.Storage, systemAccStorage)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	allTokenNames := make(map[string]bool)
@@ -224,8 +259,14 @@ func (ae *ScenarioExecutor) checkAccountESDT(baseErrMsg string, expectedAcct *sc
 	for tokenName := range accountTokens {
 		allTokenNames[tokenName] = true
 	}
-	var errs []error
+	sortedTokenNames := make([]string, 0, len(allTokenNames))
 	for tokenName := range allTokenNames {
+		sortedTokenNames = append(sortedTokenNames, tokenName)
+	}
+	sort.Strings(sortedTokenNames)
+
+	var errs []error
+	for _, tokenName := range sortedTokenNames {
 		expectedToken := expectedTokens[tokenName]
 		accountToken := accountTokens[tokenName]
 		if expectedToken == nil {
@@ -250,12 +291,7 @@ func (ae *ScenarioExecutor) checkAccountESDT(baseErrMsg string, expectedAcct *sc
 		errs = append(errs, ae.checkTokenState(accountAddress, tokenName, expectedToken, accountToken)...)
 	}
 
-	errorString := makeErrorString(errs)
-	if len(errorString) > 0 {
-		return fmt.Errorf("%s mismatch for account \"%s\":%s", baseErrMsg, accountAddress, errorString)
-	}
-
-	return nil
+	return errs, nil
 }
 
 func getExpectedTokens(expectedAcct *scenmodel.CheckAccount) map[string]*scenmodel.CheckESDTData {
@@ -315,7 +351,15 @@ func (ae *ScenarioExecutor) checkTokenInstances(
 		accountInstances[nonce] = accountInstance
 	}
 
+	sortedNonces := make([]uint64, 0, len(allNonces))
 	for nonce := range allNonces {
+		sortedNonces = append(sortedNonces, nonce)
+	}
+	sort.Slice(sortedNonces, func(i, j int) bool {
+		return sortedNonces[i] < sortedNonces[j]
+	})
+
+	for _, nonce := range sortedNonces {
 		expectedInstance := expectedInstances[nonce]
 		accountInstance := accountInstances[nonce]
 
@@ -424,7 +468,13 @@ func checkTokenRoles(
 		allRoles[string(accountRole)] = true
 		accountRoles[string(accountRole)] = true
 	}
+	sortedRoles := make([]string, 0, len(allRoles))
 	for role := range allRoles {
+		sortedRoles = append(sortedRoles, role)
+	}
+	sort.Strings(sortedRoles)
+
+	for _, role := range sortedRoles {
 		if !expectedRoles[role] {
 			errors = append(errors, fmt.Errorf("unexpected ESDT role. Account: %s. Token: %s. Role: %s",
 				accountAddress,
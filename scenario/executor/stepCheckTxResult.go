@@ -14,6 +14,7 @@ import (
 
 func (ae *ScenarioExecutor) checkTxResults(
 	txIndex string,
+	tx *scenmodel.Transaction,
 	blResult *scenmodel.TransactionResult,
 	checkGas bool,
 	output *vmcommon.VMOutput,
@@ -31,10 +32,11 @@ func (ae *ScenarioExecutor) checkTxResults(
 
 	// check result
 	if !blResult.Out.CheckList(output.ReturnData) {
-		return fmt.Errorf("result mismatch. Tx '%s'. Want: %s. Have: %s",
+		return fmt.Errorf("result mismatch. Tx '%s'. Want: %s. Have: %s%s",
 			txIndex,
 			checkBytesListPretty(blResult.Out),
-			ae.exprReconstructor.ReconstructList(output.ReturnData, er.NoHint))
+			ae.exprReconstructor.ReconstructList(output.ReturnData, er.NoHint),
+			decodedSuffix(ae.decodedResults(tx, output.ReturnData)))
 	}
 
 	// check refund
@@ -53,7 +55,12 @@ func (ae *ScenarioExecutor) checkTxResults(
 			output.GasRemaining)
 	}
 
-	return ae.checkTxLogs(txIndex, blResult.Logs, output.Logs)
+	err := ae.checkTxLogs(txIndex, blResult.Logs, output.Logs)
+	if err != nil {
+		return err
+	}
+
+	return ae.checkTxTransfers(txIndex, blResult.Transfers, output)
 }
 
 func (ae *ScenarioExecutor) checkTxLogs(
@@ -66,6 +73,10 @@ func (ae *ScenarioExecutor) checkTxLogs(
 		return nil
 	}
 
+	if expectedLogs.Mode != scenmodel.LogCheckOrdered {
+		return ae.checkUnorderedTxLogs(txIndex, expectedLogs, actualLogs)
+	}
+
 	// this is the real log check
 	if len(actualLogs) < len(expectedLogs.List) {
 		return fmt.Errorf("too few logs. Tx '%s'. Want:%d. Got:%d",
@@ -113,18 +124,20 @@ func (ae *ScenarioExecutor) checkTxLog(
 			scenjwrite.LogToString(ae.convertLogToTestFormat(actualLog)))
 	}
 	if !expectedLog.Topics.CheckList(actualLog.Topics) {
-		return fmt.Errorf("bad log topics. Tx '%s'. Log index: %d. Want: %s. Have: %s",
+		return fmt.Errorf("bad log topics. Tx '%s'. Log index: %d. Want: %s. Have: %s%s",
 			txIndex,
 			logIndex,
 			checkBytesListPretty(expectedLog.Topics),
-			ae.exprReconstructor.ReconstructList(actualLog.Topics, er.NoHint))
+			ae.exprReconstructor.ReconstructList(actualLog.Topics, er.NoHint),
+			decodedSuffix(ae.decodedLog(actualLog)))
 	}
 	if !expectedLog.Data.CheckList(actualLog.Data) {
-		return fmt.Errorf("bad log data. Tx '%s'. Log index: %d. Want:\n%s\nGot:\n%s",
+		return fmt.Errorf("bad log data. Tx '%s'. Log index: %d. Want:\n%s\nGot:\n%s%s",
 			txIndex,
 			logIndex,
 			scenjwrite.LogToString(expectedLog),
-			scenjwrite.LogToString(ae.convertLogToTestFormat(actualLog)))
+			scenjwrite.LogToString(ae.convertLogToTestFormat(actualLog)),
+			decodedLine(ae.decodedLog(actualLog)))
 	}
 	return nil
 }
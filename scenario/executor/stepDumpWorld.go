@@ -2,11 +2,13 @@ package scenexec
 
 import (
 	"fmt"
+	"path/filepath"
 	"sort"
 	"strings"
 
 	oj "github.com/multiversx/mx-chain-scenario-go/orderedjson"
 	er "github.com/multiversx/mx-chain-scenario-go/scenario/expression/reconstructor"
+	scenio "github.com/multiversx/mx-chain-scenario-go/scenario/io"
 	scenjwrite "github.com/multiversx/mx-chain-scenario-go/scenario/json/write"
 	scenmodel "github.com/multiversx/mx-chain-scenario-go/scenario/model"
 	worldmock "github.com/multiversx/mx-chain-scenario-go/worldmock"
@@ -18,6 +20,8 @@ import (
 
 const includeProtectedStorage = false
 
+// convertMockAccountToScenarioFormat converts a world account to a setState account.
+// Values are written as expressions, so the result can be saved to scenario files.
 func (ae *ScenarioExecutor) convertMockAccountToScenarioFormat(account *worldmock.Account) (*scenmodel.Account, error) {
 	var storageKeys []string
 	for storageKey := range account.Storage {
@@ -33,11 +37,11 @@ func (ae *ScenarioExecutor) convertMockAccountToScenarioFormat(account *worldmoc
 			storageKvps = append(storageKvps, &scenmodel.StorageKeyValuePair{
 				Key: scenmodel.JSONBytesFromString{
 					Value:    []byte(storageKey),
-					Original: ae.exprReconstructor.Reconstruct([]byte(storageKey), er.NoHint),
+					Original: ae.exprReconstructor.ReconstructExpression([]byte(storageKey), er.NoHint),
 				},
 				Value: scenmodel.JSONBytesFromTree{
 					Value:    storageValue,
-					Original: &oj.OJsonString{Value: ae.exprReconstructor.Reconstruct(storageValue, er.NoHint)},
+					Original: &oj.OJsonString{Value: ae.exprReconstructor.ReconstructExpression(storageValue, er.NoHint)},
 				},
 			})
 		}
@@ -72,7 +76,7 @@ func (ae *ScenarioExecutor) convertMockAccountToScenarioFormat(account *worldmoc
 			if len(mockInstance.TokenMetaData.Creator) > 0 {
 				creator = scenmodel.JSONBytesFromString{
 					Value:    mockInstance.TokenMetaData.Creator,
-					Original: ae.exprReconstructor.Reconstruct(mockInstance.TokenMetaData.Creator, er.AddressHint),
+					Original: ae.exprReconstructor.ReconstructExpression(mockInstance.TokenMetaData.Creator, er.AddressHint),
 				}
 			}
 
@@ -88,7 +92,7 @@ func (ae *ScenarioExecutor) convertMockAccountToScenarioFormat(account *worldmoc
 			if len(mockInstance.TokenMetaData.Hash) > 0 {
 				hash = scenmodel.JSONBytesFromString{
 					Value:    mockInstance.TokenMetaData.Hash,
-					Original: ae.exprReconstructor.Reconstruct(mockInstance.TokenMetaData.Hash, er.NoHint),
+					Original: ae.exprReconstructor.ReconstructExpression(mockInstance.TokenMetaData.Hash, er.NoHint),
 				}
 			}
 
@@ -96,7 +100,7 @@ func (ae *ScenarioExecutor) convertMockAccountToScenarioFormat(account *worldmoc
 			for _, uri := range mockInstance.TokenMetaData.URIs {
 				jsonUris = append(jsonUris, scenmodel.JSONBytesFromString{
 					Value:    uri,
-					Original: ae.exprReconstructor.Reconstruct(uri, er.StrHint),
+					Original: ae.exprReconstructor.ReconstructExpression(uri, er.StrHint),
 				})
 			}
 
@@ -104,7 +108,7 @@ func (ae *ScenarioExecutor) convertMockAccountToScenarioFormat(account *worldmoc
 			if len(mockInstance.TokenMetaData.Attributes) > 0 {
 				attributes = scenmodel.JSONBytesFromTree{
 					Value:    mockInstance.TokenMetaData.Attributes,
-					Original: &oj.OJsonString{Value: ae.exprReconstructor.Reconstruct(mockInstance.TokenMetaData.Attributes, er.NoHint)},
+					Original: &oj.OJsonString{Value: ae.exprReconstructor.ReconstructExpression(mockInstance.TokenMetaData.Attributes, er.NoHint)},
 				}
 			}
 
@@ -128,7 +132,7 @@ func (ae *ScenarioExecutor) convertMockAccountToScenarioFormat(account *worldmoc
 		scenESDT = append(scenESDT, &scenmodel.ESDTData{
 			TokenIdentifier: scenmodel.JSONBytesFromString{
 				Value:    esdtObj.TokenIdentifier,
-				Original: ae.exprReconstructor.Reconstruct(esdtObj.TokenIdentifier, er.StrHint),
+				Original: ae.exprReconstructor.ReconstructExpression(esdtObj.TokenIdentifier, er.StrHint),
 			},
 			Instances: scenInstances,
 			LastNonce: scenmodel.JSONUint64{
@@ -142,7 +146,7 @@ func (ae *ScenarioExecutor) convertMockAccountToScenarioFormat(account *worldmoc
 	return &scenmodel.Account{
 		Address: scenmodel.JSONBytesFromString{
 			Value:    account.Address,
-			Original: ae.exprReconstructor.Reconstruct(account.Address, er.AddressHint),
+			Original: ae.exprReconstructor.ReconstructExpression(account.Address, er.AddressHint),
 		},
 		Nonce: scenmodel.JSONUint64{
 			Value:    account.Nonce,
@@ -156,11 +160,194 @@ func (ae *ScenarioExecutor) convertMockAccountToScenarioFormat(account *worldmoc
 		ESDTData: scenESDT,
 		Owner: scenmodel.JSONBytesFromString{
 			Value:    account.OwnerAddress,
-			Original: ae.exprReconstructor.Reconstruct(account.OwnerAddress, er.AddressHint),
+			Original: ae.exprReconstructor.ReconstructExpression(account.OwnerAddress, er.AddressHint),
 		},
 	}, nil
 }
 
+// convertFullMockAccountToScenarioFormat also includes the shard, code, code metadata, username and developer rewards,
+// so that the account can be recreated from the result alone.
+func (ae *ScenarioExecutor) convertFullMockAccountToScenarioFormat(account *worldmock.Account) (*scenmodel.Account, error) {
+	scenAccount, err := ae.convertMockAccountToScenarioFormat(account)
+	if err != nil {
+		return nil, err
+	}
+
+	if account.ShardID > 0 {
+		scenAccount.Shard = ae.uint64Expression(uint64(account.ShardID))
+	}
+	scenAccount.Username = ae.bytesExpression(account.Username, er.StrHint)
+	scenAccount.Code = ae.bytesExpression(account.Code, er.CodeHint)
+	scenAccount.CodeMetadata = ae.bytesExpression(account.CodeMetadata, er.HexHint)
+	if account.DeveloperReward != nil && account.DeveloperReward.Sign() > 0 {
+		scenAccount.DeveloperReward = scenmodel.JSONBigInt{
+			Value:    account.DeveloperReward,
+			Original: ae.exprReconstructor.ReconstructFromBigInt(account.DeveloperReward),
+		}
+	}
+	return scenAccount, nil
+}
+
+func (ae *ScenarioExecutor) bytesExpression(value []byte, hint er.ExprReconstructorHint) scenmodel.JSONBytesFromString {
+	return scenmodel.JSONBytesFromString{
+		Value:    value,
+		Original: ae.exprReconstructor.ReconstructExpression(value, hint),
+	}
+}
+
+// ExecuteDumpStateStep executes a DumpStateStep.
+func (ae *ScenarioExecutor) ExecuteDumpStateStep(step *scenmodel.DumpStateStep) error {
+	ae.notifyBeforeStep(step)
+	err := ae.executeDumpStateStep(step)
+	ae.notifyAfterStep(step, err)
+	return err
+}
+
+func (ae *ScenarioExecutor) executeDumpStateStep(step *scenmodel.DumpStateStep) error {
+	if len(step.Path) == 0 && len(step.Format) == 0 && len(step.Accounts) == 0 {
+		return ae.DumpWorld()
+	}
+
+	dumpPath := ""
+	codeBase := ""
+	if len(step.Path) > 0 {
+		dumpPath = ae.fileResolver.ResolveAbsolutePath(step.Path)
+		codeBase = filepath.Dir(dumpPath)
+	}
+	dumpedStep, err := ae.DumpStateAsStep(step.Format, step.Accounts, codeBase)
+	if err != nil {
+		return err
+	}
+	dumpScenario := &scenmodel.Scenario{
+		Comment:     step.Comment,
+		CheckGas:    true,
+		GasSchedule: scenmodel.GasScheduleDefault,
+		Steps:       []scenmodel.Step{dumpedStep},
+	}
+
+	if len(step.Path) == 0 {
+		fmt.Print("world state dump:\n")
+		fmt.Print(scenjwrite.ScenarioToJSONString(dumpScenario))
+		return nil
+	}
+	err = scenio.WriteScenariosScenario(dumpScenario, dumpPath)
+	if err != nil {
+		return fmt.Errorf("could not dump state to %s: %w", dumpPath, err)
+	}
+	return nil
+}
+
+// DumpStateAsStep converts the current state into a setState or checkState step, according to the format.
+// All accounts are included if the address list is empty, except for the system account.
+// A setState step also sets the block infos, block hashes and new address mocks,
+// while a filtered checkState step allows other accounts.
+// Code loaded from files is referenced by path, relative to the code base, if one is given.
+func (ae *ScenarioExecutor) DumpStateAsStep(format string, addresses []scenmodel.JSONBytesFromString, codeBase string) (scenmodel.Step, error) {
+	worldAccounts, err := ae.dumpedAccounts(addresses)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case "", scenmodel.DumpFormatSetState:
+		setStateStep := &scenmodel.SetStateStep{
+			PreviousBlockInfo: ae.blockInfoToScenarioFormat(ae.World.PreviousBlockInfo),
+			CurrentBlockInfo:  ae.blockInfoToScenarioFormat(ae.World.CurrentBlockInfo),
+			NewAddressMocks:   ae.newAddressMocksToScenarioFormat(ae.World.NewAddressMocks),
+		}
+		for _, blockHash := range ae.World.Blockhashes {
+			setStateStep.BlockHashes.Values = append(setStateStep.BlockHashes.Values, ae.bytesExpression(blockHash, er.HexHint))
+		}
+		for _, worldAccount := range worldAccounts {
+			account, err := ae.convertFullMockAccountToScenarioFormat(worldAccount)
+			if err != nil {
+				return nil, err
+			}
+			account.Code = ae.codeExpression(worldAccount.Code, codeBase)
+			setStateStep.Accounts = append(setStateStep.Accounts, account)
+		}
+		return setStateStep, nil
+	case scenmodel.DumpFormatCheckState:
+		checkAccounts := &scenmodel.CheckAccounts{
+			MoreAccountsAllowed: len(addresses) > 0,
+		}
+		options := CheckStateOptions{CodeBase: codeBase}
+		for _, worldAccount := range worldAccounts {
+			checkAccount, err := ae.checkAccountFromWorld(worldAccount, options)
+			if err != nil {
+				return nil, err
+			}
+			checkAccounts.Accounts = append(checkAccounts.Accounts, checkAccount)
+		}
+		return &scenmodel.CheckStateStep{CheckAccounts: checkAccounts}, nil
+	default:
+		return nil, fmt.Errorf("unknown dump state format: %s", format)
+	}
+}
+
+// dumpedAccounts yields the accounts with the given addresses, in that order,
+// or all accounts sorted by address, except for the system account.
+func (ae *ScenarioExecutor) dumpedAccounts(addresses []scenmodel.JSONBytesFromString) ([]*worldmock.Account, error) {
+	var worldAccounts []*worldmock.Account
+	if len(addresses) == 0 {
+		for _, address := range sortedAccountAddresses(ae.World.AcctMap) {
+			if address == string(vmcommon.SystemAccountAddress) {
+				continue
+			}
+			worldAccounts = append(worldAccounts, ae.World.AcctMap[address])
+		}
+		return worldAccounts, nil
+	}
+
+	for _, address := range addresses {
+		worldAccount, found := ae.World.AcctMap[string(address.Value)]
+		if !found {
+			return nil, fmt.Errorf("cannot dump account %s, it does not exist", address.Original)
+		}
+		worldAccounts = append(worldAccounts, worldAccount)
+	}
+	return worldAccounts, nil
+}
+
+func (ae *ScenarioExecutor) blockInfoToScenarioFormat(blockInfo *worldmock.BlockInfo) *scenmodel.BlockInfo {
+	if blockInfo == nil {
+		return nil
+	}
+
+	scenBlockInfo := &scenmodel.BlockInfo{
+		BlockTimestampMs: ae.uint64Expression(blockInfo.BlockTimestampMs),
+		BlockNonce:       ae.uint64Expression(blockInfo.BlockNonce),
+		BlockRound:       ae.uint64Expression(blockInfo.BlockRound),
+		BlockEpoch:       ae.uint64Expression(uint64(blockInfo.BlockEpoch)),
+	}
+	if blockInfo.RandomSeed != nil {
+		scenBlockInfo.BlockRandomSeed = &scenmodel.JSONBytesFromTree{
+			Value:    blockInfo.RandomSeed[:],
+			Original: &oj.OJsonString{Value: ae.exprReconstructor.ReconstructExpression(blockInfo.RandomSeed[:], er.HexHint)},
+		}
+	}
+	return scenBlockInfo
+}
+
+func (ae *ScenarioExecutor) newAddressMocksToScenarioFormat(newAddressMocks []*worldmock.NewAddressMock) []*scenmodel.NewAddressMock {
+	var scenNewAddressMocks []*scenmodel.NewAddressMock
+	for _, newAddressMock := range newAddressMocks {
+		scenNewAddressMocks = append(scenNewAddressMocks, &scenmodel.NewAddressMock{
+			CreatorAddress: ae.bytesExpression(newAddressMock.CreatorAddress, er.AddressHint),
+			CreatorNonce:   ae.uint64Expression(newAddressMock.CreatorNonce),
+			NewAddress:     ae.bytesExpression(newAddressMock.NewAddress, er.AddressHint),
+		})
+	}
+	return scenNewAddressMocks
+}
+
+func (ae *ScenarioExecutor) uint64Expression(value uint64) scenmodel.JSONUint64 {
+	return scenmodel.JSONUint64{
+		Value:    value,
+		Original: ae.exprReconstructor.ReconstructFromUint64(value),
+	}
+}
+
 // DumpWorld prints the state of the MockWorld to stdout.
 func (ae *ScenarioExecutor) DumpWorld() error {
 	fmt.Print("world state dump:\n")
@@ -178,5 +365,46 @@ func (ae *ScenarioExecutor) DumpWorld() error {
 	s := oj.JSONString(ojAccount)
 	fmt.Println(s)
 
+	decodedStorage := ae.decodedWorldStorage()
+	if len(decodedStorage) > 0 {
+		fmt.Print("decoded storage:\n")
+		for _, line := range decodedStorage {
+			fmt.Println(line)
+		}
+	}
+
 	return nil
 }
+
+// decodedWorldStorage describes the storage of all contracts with a known ABI, one entry per line.
+func (ae *ScenarioExecutor) decodedWorldStorage() []string {
+	var addresses []string
+	for address := range ae.World.AcctMap {
+		if ae.decodingABI([]byte(address)) != nil {
+			addresses = append(addresses, address)
+		}
+	}
+	sort.Strings(addresses)
+
+	var lines []string
+	for _, address := range addresses {
+		account := ae.World.AcctMap.GetAccount([]byte(address))
+		var keys []string
+		for key := range account.Storage {
+			if !strings.HasPrefix(key, core.ProtectedKeyPrefix) {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			decoded := ae.decodedStorage(account.Address, []byte(key), account.Storage[key])
+			if len(decoded) > 0 {
+				lines = append(lines, fmt.Sprintf("%s: %s",
+					ae.exprReconstructor.Reconstruct(account.Address, er.AddressHint),
+					decoded))
+			}
+		}
+	}
+	return lines
+}
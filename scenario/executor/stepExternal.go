@@ -7,6 +7,13 @@ import (
 
 // ExecuteExternalStep executes an external step referenced by the scenario.
 func (ae *ScenarioExecutor) ExecuteExternalStep(step *scenmodel.ExternalStepsStep) error {
+	ae.notifyBeforeStep(step)
+	err := ae.executeExternalStep(step)
+	ae.notifyAfterStep(step, err)
+	return err
+}
+
+func (ae *ScenarioExecutor) executeExternalStep(step *scenmodel.ExternalStepsStep) error {
 	log.Trace("ExternalStepsStep", "path", step.Path)
 	if len(step.Comment) > 0 {
 		log.Trace("ExternalStepsStep", "comment", step.Comment)
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"time"
 
 	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-core-go/data/vm"
@@ -16,6 +17,13 @@ import (
 
 // ExecuteTxStep executes a TxStep.
 func (ae *ScenarioExecutor) ExecuteTxStep(step *scenmodel.TxStep) (*vmcommon.VMOutput, error) {
+	ae.notifyBeforeStep(step)
+	output, err := ae.executeTxStep(step)
+	ae.notifyAfterStep(step, err)
+	return output, err
+}
+
+func (ae *ScenarioExecutor) executeTxStep(step *scenmodel.TxStep) (*vmcommon.VMOutput, error) {
 	log.Trace("ExecuteTxStep", "id", step.TxIdent)
 	if len(step.Comment) > 0 {
 		log.Trace("ExecuteTxStep", "comment", step.Comment)
@@ -25,20 +33,54 @@ func (ae *ScenarioExecutor) ExecuteTxStep(step *scenmodel.TxStep) (*vmcommon.VMO
 		SetLoggingForTests()
 	}
 
+	if step.Tx.Type == scenmodel.ScDeploy || step.Tx.Type == scenmodel.ScUpgrade {
+		ae.recordCodePath(step.Tx.Code)
+	}
+	err := ae.encodeTypedArguments(step.Tx)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments. Tx '%s': %w", step.TxIdent, err)
+	}
+
+	if len(ae.observers) > 0 {
+		input := ConvertScenarioTxToVMInput(step.Tx)
+		for _, observer := range ae.observers {
+			observer.BeforeTx(step, input)
+		}
+	}
+
+	var accountsBefore worldmock.AccountMap
+	if step.DisplayDiff {
+		accountsBefore = ae.World.AcctMap.Clone()
+	}
+
+	startTime := time.Now()
 	output, err := ae.executeTx(step.TxIdent, step.Tx)
+	for _, observer := range ae.observers {
+		observer.AfterTx(step, output, err)
+	}
 	if err != nil {
 		return nil, err
 	}
+	ae.recordTxProfile(step, time.Since(startTime), output)
+	captureGasTrace(ae, step)
+	if step.DisplayDiff {
+		err = ae.printTxDiff(step.TxIdent, accountsBefore)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	if step.DisplayLogs {
 		DisableLoggingForTests()
 	}
 
 	// check results
-	if step.ExpectedResult != nil {
-		err = ae.checkTxResults(step.TxIdent, step.ExpectedResult, ae.checkGas, output)
+	if step.ExpectedResult != nil && ae.updatesExpectations() {
+		ae.updateTxExpectation(step.TxIdent, step.ExpectedResult, output)
+	} else if step.ExpectedResult != nil {
+		err = ae.checkTxResults(step.TxIdent, step.Tx, step.ExpectedResult, ae.checkGas, output)
 		if err != nil {
-			return nil, err
+			return nil, newCheckFailure(err)
 		}
 	}
 
@@ -48,6 +90,11 @@ func (ae *ScenarioExecutor) ExecuteTxStep(step *scenmodel.TxStep) (*vmcommon.VMO
 func (ae *ScenarioExecutor) executeTx(txIndex string, tx *scenmodel.Transaction) (*vmcommon.VMOutput, error) {
 	var err error
 	gasForExecution := uint64(0)
+	defer ae.restoreSelfShard(ae.World.SelfShardID)
+	ae.setTxShard(tx)
+	if tx.Type != scenmodel.ScQuery {
+		ae.World.IncludeTxInBlock()
+	}
 
 	// use gas (before snaphot)
 	if tx.Type.HasSender() {
@@ -71,16 +118,24 @@ func (ae *ScenarioExecutor) executeTx(txIndex string, tx *scenmodel.Transaction)
 			if errRollback != nil {
 				err = errRollback
 			}
+			for _, observer := range ae.observers {
+				observer.OnWorldRollback(txIndex, err)
+			}
 		} else {
 			errCommit := ae.World.CommitChanges()
 			if errCommit != nil {
 				err = errCommit
+				return
+			}
+			for _, observer := range ae.observers {
+				observer.OnWorldCommit(txIndex)
 			}
 		}
 	}()
 
 	// we also use fake vm outputs for transactions that don't use the VM, just for convenience
 	var output *vmcommon.VMOutput
+	isCrossShardTx := ae.isCrossShardTx(tx)
 
 	if !ae.senderHasEnoughBalance(tx) {
 		// out of funds is handled by the protocol, so it needs to be mocked here
@@ -104,7 +159,11 @@ func (ae *ScenarioExecutor) executeTx(txIndex string, tx *scenmodel.Transaction)
 			gasForExecution = math.MaxInt64
 			fallthrough
 		case scenmodel.ScCall:
-			output, err = ae.scCall(txIndex, tx, tx.GasLimit.Value)
+			if isCrossShardTx {
+				output, err = ae.crossShardTx(txIndex, tx)
+			} else {
+				output, err = ae.scCall(txIndex, tx, tx.GasLimit.Value)
+			}
 			if err != nil {
 				return nil, err
 			}
@@ -112,7 +171,12 @@ func (ae *ScenarioExecutor) executeTx(txIndex string, tx *scenmodel.Transaction)
 				fmt.Println("\nIn txID:", txIndex, ", step type:ScCall, function:", tx.Function, ", total gas used:", gasForExecution-output.GasRemaining)
 			}
 		case scenmodel.Transfer:
-			if tx.ESDTValue != nil {
+			if isCrossShardTx {
+				output, err = ae.crossShardTx(txIndex, tx)
+				if err != nil {
+					return nil, err
+				}
+			} else if tx.ESDTValue != nil {
 				output, err = ae.directESDTTransfer(tx)
 				if err != nil {
 					return nil, err
@@ -130,20 +194,42 @@ func (ae *ScenarioExecutor) executeTx(txIndex string, tx *scenmodel.Transaction)
 		}
 	}
 
-	if output.ReturnCode == vmcommon.Ok {
+	switch {
+	case isCrossShardTx && output.ReturnCode != vmcommon.OutOfFunds:
+		// the state was already updated in both shards, a failed call being refunded instead of reverted
+	case output.ReturnCode == vmcommon.Ok:
 		err := ae.updateStateAfterTx(tx, output)
 		if err != nil {
 			return nil, err
 		}
-	} else {
+		if ae.World.MultiShard {
+			ae.queueCrossShardMessages(output, txDefaultSender(tx), generateTxHash(txIndex))
+		}
+	default:
 		err = fmt.Errorf(
 			"tx step failed: retcode=%d, msg=%s",
 			output.ReturnCode, output.ReturnMessage)
+		return output, nil
+	}
+
+	if ae.World.MultiShard {
+		_, err = ae.runCrossShardRounds(txIndex, nil)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return output, nil
 }
 
+// txDefaultSender yields the account that sends the transfers of a transaction output which mention no sender.
+func txDefaultSender(tx *scenmodel.Transaction) []byte {
+	if tx.Type == scenmodel.Transfer {
+		return tx.From.Value
+	}
+	return tx.To.Value
+}
+
 func (ae *ScenarioExecutor) senderHasEnoughBalance(tx *scenmodel.Transaction) bool {
 	if !tx.Type.HasSender() {
 		return true
@@ -153,10 +239,14 @@ func (ae *ScenarioExecutor) senderHasEnoughBalance(tx *scenmodel.Transaction) bo
 }
 
 func (ae *ScenarioExecutor) simpleTransferOutput(tx *scenmodel.Transaction) *vmcommon.VMOutput {
+	return valueTransferOutput(tx.To.Value, tx.EGLDValue.Value)
+}
+
+func valueTransferOutput(receiver []byte, value *big.Int) *vmcommon.VMOutput {
 	outputAccounts := make(map[string]*vmcommon.OutputAccount)
-	outputAccounts[string(tx.To.Value)] = &vmcommon.OutputAccount{
-		Address:      tx.To.Value,
-		BalanceDelta: tx.EGLDValue.Value,
+	outputAccounts[string(receiver)] = &vmcommon.OutputAccount{
+		Address:      receiver,
+		BalanceDelta: value,
 	}
 
 	return &vmcommon.VMOutput{
@@ -322,6 +412,10 @@ func (ae *ScenarioExecutor) updateStateAfterTx(
 	if tx.Type.HasSender() {
 		sumOfBalanceDeltas := big.NewInt(0)
 		for _, oa := range output.OutputAccounts {
+			if oa.BalanceDelta == nil {
+				// builtin functions leave it unset for transfers to other shards
+				continue
+			}
 			sumOfBalanceDeltas = sumOfBalanceDeltas.Add(sumOfBalanceDeltas, oa.BalanceDelta)
 		}
 		if sumOfBalanceDeltas.Cmp(tx.EGLDValue.Value) != 0 {
@@ -0,0 +1,39 @@
+package scenexec
+
+import (
+	"fmt"
+
+	scenmodel "github.com/multiversx/mx-chain-scenario-go/scenario/model"
+)
+
+// ExecuteSaveStateStep executes a SaveStateStep, keeping a copy of the world under the step name.
+// Saving again under the same name replaces the previous copy.
+func (ae *ScenarioExecutor) ExecuteSaveStateStep(step *scenmodel.SaveStateStep) error {
+	ae.notifyBeforeStep(step)
+	if len(step.Comment) > 0 {
+		log.Trace("SaveStateStep", "comment", step.Comment)
+	}
+	ae.checkpoints[step.Name] = ae.World.SaveCheckpoint()
+	ae.notifyAfterStep(step, nil)
+	return nil
+}
+
+// ExecuteRestoreStateStep executes a RestoreStateStep, bringing the world back to a state saved before.
+func (ae *ScenarioExecutor) ExecuteRestoreStateStep(step *scenmodel.RestoreStateStep) error {
+	ae.notifyBeforeStep(step)
+	err := ae.executeRestoreStateStep(step)
+	ae.notifyAfterStep(step, err)
+	return err
+}
+
+func (ae *ScenarioExecutor) executeRestoreStateStep(step *scenmodel.RestoreStateStep) error {
+	if len(step.Comment) > 0 {
+		log.Trace("RestoreStateStep", "comment", step.Comment)
+	}
+
+	checkpoint, found := ae.checkpoints[step.Name]
+	if !found {
+		return fmt.Errorf("no state saved under the name %s", step.Name)
+	}
+	return ae.World.RestoreCheckpoint(checkpoint)
+}
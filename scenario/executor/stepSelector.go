@@ -21,10 +21,14 @@ func (ae *ScenarioExecutor) ExecuteStep(generalStep scenmodel.Step) error {
 	case *scenmodel.TxStep:
 		_, err = ae.ExecuteTxStep(step)
 	case *scenmodel.DumpStateStep:
-		err = ae.DumpWorld()
+		err = ae.ExecuteDumpStateStep(step)
+	case *scenmodel.SaveStateStep:
+		err = ae.ExecuteSaveStateStep(step)
+	case *scenmodel.RestoreStateStep:
+		err = ae.ExecuteRestoreStateStep(step)
+	case *scenmodel.AdvanceBlocksStep:
+		err = ae.ExecuteAdvanceBlocksStep(step)
 	}
 
-	logGasTrace(ae)
-
 	return err
 }
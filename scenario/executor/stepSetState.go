@@ -15,11 +15,19 @@ var DefaultCodeMetadata = []byte{0x05, 0x06}
 
 // ExecuteSetStateStep executes a SetStateStep.
 func (ae *ScenarioExecutor) ExecuteSetStateStep(step *scenmodel.SetStateStep) error {
+	ae.notifyBeforeStep(step)
+	err := ae.executeSetStateStep(step)
+	ae.notifyAfterStep(step, err)
+	return err
+}
+
+func (ae *ScenarioExecutor) executeSetStateStep(step *scenmodel.SetStateStep) error {
 	if len(step.Comment) > 0 {
 		log.Trace("SetStateStep", "comment", step.Comment)
 	}
 
 	for _, scenAccount := range step.Accounts {
+		ae.recordCodePath(scenAccount.Code)
 		if scenAccount.Update {
 			err := ae.UpdateAccount(scenAccount)
 			if err != nil {
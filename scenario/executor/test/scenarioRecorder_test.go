@@ -0,0 +1,113 @@
+package executortest
+
+import (
+	"math/big"
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+
+	scenexec "github.com/multiversx/mx-chain-scenario-go/scenario/executor"
+	scenio "github.com/multiversx/mx-chain-scenario-go/scenario/io"
+	scenmodel "github.com/multiversx/mx-chain-scenario-go/scenario/model"
+	"github.com/stretchr/testify/require"
+)
+
+func runRecordedScenario(t *testing.T, scenarioPath string) {
+	executor := scenexec.NewScenarioExecutor(&DummyVMBuilder{})
+	controller := scenio.NewScenarioController(executor, scenio.NewDefaultFileResolver(), scenexec.TestVMType)
+	err := controller.RunSingleJSONScenario(scenarioPath, scenio.DefaultRunScenarioOptions())
+	require.Nil(t, err)
+}
+
+func TestScenarioRecorderReplay(t *testing.T) {
+	for _, expectOutputs := range []bool{false, true} {
+		executor := scenexec.NewScenarioExecutor(&DummyVMBuilder{})
+		recorder := scenexec.NewScenarioRecorder(executor, expectOutputs)
+		controller := scenio.NewScenarioController(executor, scenio.NewDefaultFileResolver(), scenexec.TestVMType)
+		err := controller.RunSingleJSONScenario(
+			path.Join(getTestRoot(), "scenarios-self-test", "external_steps", "external_steps.scen.json"),
+			scenio.DefaultRunScenarioOptions())
+		require.Nil(t, err)
+
+		recorded := recorder.Scenario()
+		for _, step := range recorded.Steps {
+			_, isExternal := step.(*scenmodel.ExternalStepsStep)
+			require.False(t, isExternal)
+		}
+
+		recordedPath := filepath.Join(t.TempDir(), "recorded.scen.json")
+		require.Nil(t, recorder.Save(recordedPath))
+		runRecordedScenario(t, recordedPath)
+	}
+}
+
+func TestScenarioRecorderSelfContained(t *testing.T) {
+	for _, testCase := range []struct {
+		scenarioPath string
+		multiShard   bool
+	}{
+		{path.Join(getTestRoot(), "scenarios-self-test", "set-check", "set-check-code.scen.json"), false},
+		{path.Join(getTestRoot(), "scenarios-self-test", "multi-shard", "egld-cross-shard.scen.json"), true},
+	} {
+		executor := scenexec.NewScenarioExecutor(&DummyVMBuilder{})
+		recorder := scenexec.NewScenarioRecorder(executor, false)
+		controller := scenio.NewScenarioController(executor, scenio.NewDefaultFileResolver(), scenexec.TestVMType)
+		err := controller.RunSingleJSONScenario(testCase.scenarioPath, scenio.DefaultRunScenarioOptions())
+		require.Nil(t, err)
+		require.Equal(t, testCase.multiShard, recorder.Scenario().MultiShard)
+
+		// the recorded scenario is replayed from another directory
+		recordedPath := filepath.Join(t.TempDir(), "recorded.scen.json")
+		require.Nil(t, recorder.Save(recordedPath))
+		recordedJSON, err := os.ReadFile(recordedPath)
+		require.Nil(t, err)
+		require.NotContains(t, string(recordedJSON), "file:")
+		runRecordedScenario(t, recordedPath)
+	}
+}
+
+func TestScenarioRecorderDirectSteps(t *testing.T) {
+	executor := scenexec.NewScenarioExecutor(&DummyVMBuilder{})
+	recorder := scenexec.NewScenarioRecorder(executor, true)
+
+	sender := []byte("sender__________________________")
+	receiver := []byte("receiver________________________")
+	err := executor.ExecuteSetStateStep(&scenmodel.SetStateStep{
+		Accounts: []*scenmodel.Account{
+			{
+				Address:         scenmodel.NewJSONBytesFromString(sender, ""),
+				Balance:         scenmodel.JSONBigInt{Value: big.NewInt(1000)},
+				DeveloperReward: scenmodel.JSONBigIntZero(),
+			},
+			{
+				Address:         scenmodel.NewJSONBytesFromString(receiver, ""),
+				Balance:         scenmodel.JSONBigInt{Value: big.NewInt(0)},
+				DeveloperReward: scenmodel.JSONBigIntZero(),
+			},
+		},
+	})
+	require.Nil(t, err)
+
+	_, err = executor.ExecuteTxStep(&scenmodel.TxStep{
+		TxIdent: "direct-transfer",
+		Tx: &scenmodel.Transaction{
+			Type:      scenmodel.Transfer,
+			From:      scenmodel.NewJSONBytesFromString(sender, ""),
+			To:        scenmodel.NewJSONBytesFromString(receiver, ""),
+			EGLDValue: scenmodel.JSONBigInt{Value: big.NewInt(400)},
+		},
+	})
+	require.Nil(t, err)
+
+	recordedPath := filepath.Join(t.TempDir(), "recorded.scen.json")
+	require.Nil(t, recorder.Save(recordedPath))
+
+	recordedJSON, err := os.ReadFile(recordedPath)
+	require.Nil(t, err)
+	require.Contains(t, string(recordedJSON), `"address:sender"`)
+	require.Contains(t, string(recordedJSON), `"egldValue": "400"`)
+	require.Contains(t, string(recordedJSON), `"balance": "600"`)
+
+	runRecordedScenario(t, recordedPath)
+}
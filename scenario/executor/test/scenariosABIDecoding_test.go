@@ -0,0 +1,45 @@
+package executortest
+
+import (
+	"testing"
+)
+
+func runABIDecoding(t *testing.T, fileName string) *ScenariosTestBuilder {
+	return ScenariosTest(t).
+		Folder("scenarios-self-test/echo-vm").
+		File(fileName).
+		VM(&EchoVMBuilder{}).
+		DecodeWithABI().
+		Run()
+}
+
+func TestABIDecodingPasses(t *testing.T) {
+	runABIDecoding(t, "abi-decoding.scen.json").CheckNoError()
+}
+
+func TestABIDecodingResult(t *testing.T) {
+	runABIDecoding(t, "abi-decoding-result.err.json").
+		RequireErrorContains(`Decoded: [Point { x: 3, y: 5, tags: ["TOK-123456"] }]`)
+
+	// nothing is decoded unless asked
+	ScenariosTest(t).
+		Folder("scenarios-self-test/echo-vm").
+		File("abi-decoding-result.err.json").
+		VM(&EchoVMBuilder{}).
+		Run().
+		RequireError(`result mismatch. Tx 'echo'. Want: ["0x01"]. ` +
+			`Have: ["0x000000030000000105000000010000000a544f4b2d313233343536 ` +
+			`(str:"\x00\x00\x00\x03\x00\x00\x00\x01\x05\x00\x00\x00\x01\x00\x00\x00\nTOK-123456")"]`)
+}
+
+func TestABIDecodingLogTopics(t *testing.T) {
+	runABIDecoding(t, "abi-decoding-log-topics.err.json").
+		RequireErrorContains(`Decoded: echoPoint, topics: [point: Point { x: 3, y: 5, tags: ["TOK-123456"] }], data: []`)
+}
+
+func TestABIDecodingStorage(t *testing.T) {
+	runABIDecoding(t, "abi-decoding-storage-mapper.err.json").
+		RequireErrorContains("Decoded: balance(owner: address:A) = 1000")
+	runABIDecoding(t, "abi-decoding-storage-enum.err.json").
+		RequireErrorContains("Decoded: status = Status::Active { since: 7 }")
+}
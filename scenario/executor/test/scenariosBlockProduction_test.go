@@ -0,0 +1,64 @@
+package executortest
+
+import (
+	"path/filepath"
+	"testing"
+
+	scenexec "github.com/multiversx/mx-chain-scenario-go/scenario/executor"
+	scenio "github.com/multiversx/mx-chain-scenario-go/scenario/io"
+	scenmodel "github.com/multiversx/mx-chain-scenario-go/scenario/model"
+	worldmock "github.com/multiversx/mx-chain-scenario-go/worldmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockProduction(t *testing.T) {
+	scenarioPath := filepath.Join(getTestRoot(), "scenarios-self-test", "block-production.scen.json")
+
+	vmBuilder := &DummyVMBuilder{}
+	executor := scenexec.NewScenarioExecutor(vmBuilder)
+	controller := scenio.NewScenarioController(executor, scenio.NewDefaultFileResolver(), vmBuilder.GetVMType())
+	err := controller.RunSingleJSONScenario(scenarioPath, scenio.DefaultRunScenarioOptions())
+	require.Nil(t, err)
+
+	world := executor.World
+	require.Equal(t, uint64(13), world.CurrentBlockInfo.BlockNonce)
+	require.Equal(t, uint64(13), world.CurrentBlockInfo.BlockRound)
+	require.Equal(t, uint64(78000), world.CurrentBlockInfo.BlockTimestampMs)
+	require.Equal(t, uint32(1), world.CurrentBlockInfo.BlockEpoch)
+	require.Equal(t, uint64(12), world.PreviousBlockInfo.BlockNonce)
+	require.Equal(t, uint64(10), world.EpochStartBlockNonce())
+	require.Equal(t, uint64(60000), world.EpochStartBlockTimeStampMs())
+	require.Equal(t, uint64(6000), world.RoundTime())
+
+	// producing all the blocks in one go yields the same chain
+	expectedWorld := worldmock.NewMockWorld()
+	expectedWorld.BlockProduction = world.BlockProduction
+	expectedWorld.CurrentBlockInfo = &worldmock.BlockInfo{
+		BlockTimestampMs: 30000,
+		BlockNonce:       5,
+		BlockRound:       5,
+	}
+	expectedWorld.ProduceBlocks(8)
+	require.Equal(t, expectedWorld.CurrentBlockInfo, world.CurrentBlockInfo)
+	require.Equal(t, expectedWorld.PreviousBlockInfo, world.PreviousBlockInfo)
+	require.Equal(t, expectedWorld.Blockhashes, world.Blockhashes)
+	require.Len(t, world.Blockhashes, 8)
+}
+
+func TestPrepareScenarioBlockProduction(t *testing.T) {
+	executor := scenexec.NewScenarioExecutor(&DummyVMBuilder{})
+	scenario := &scenmodel.Scenario{
+		BlockProduction: &scenmodel.BlockProduction{
+			RoundDurationMs: scenmodel.JSONUint64{Value: 6000},
+			TxsPerBlock:     scenmodel.JSONUint64{Value: 2},
+			RoundsPerEpoch:  scenmodel.JSONUint64{Value: 10},
+		},
+	}
+	err := executor.PrepareScenario(scenario, scenio.NewDefaultFileResolver())
+	require.Nil(t, err)
+	require.Equal(t, &worldmock.BlockProduction{
+		RoundDurationMs: 6000,
+		TxsPerBlock:     2,
+		RoundsPerEpoch:  10,
+	}, executor.World.BlockProduction)
+}
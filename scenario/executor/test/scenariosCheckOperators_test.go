@@ -0,0 +1,23 @@
+package executortest
+
+import (
+	"testing"
+)
+
+func TestCheckOperators(t *testing.T) {
+	ScenariosTest(t).
+		Folder("scenarios-self-test/set-check").
+		File("check-operators.scen.json").
+		Run().
+		CheckNoError()
+}
+
+func TestCheckOperatorsErr(t *testing.T) {
+	ScenariosTest(t).
+		Folder("scenarios-self-test/set-check").
+		File("check-operators.err.json").
+		Run().
+		RequireError(
+			"Check state \"check-1\": mismatch for account \"address:owner\":\n" +
+				"  bad account balance. Want: \"<= 1,000\". Have: \"1500\"")
+}
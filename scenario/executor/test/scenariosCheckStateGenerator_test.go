@@ -0,0 +1,213 @@
+package executortest
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	scenexec "github.com/multiversx/mx-chain-scenario-go/scenario/executor"
+	scenio "github.com/multiversx/mx-chain-scenario-go/scenario/io"
+	"github.com/multiversx/mx-chain-scenario-go/worldmock/esdtconvert"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/stretchr/testify/require"
+)
+
+const generateCheckStateScenario = `{
+    "steps": [
+        {
+            "step": "setState",
+            "accounts": {
+                "address:A": {
+                    "nonce": "0",
+                    "balance": "150",
+                    "esdt": {
+                        "str:TOK-123456": "20",
+                        "str:NFT-123456": {
+                            "instances": [
+                                {
+                                    "nonce": "1",
+                                    "balance": "1",
+                                    "attributes": "str:rare"
+                                }
+                            ],
+                            "lastNonce": "1",
+                            "roles": [
+                                "ESDTRoleNFTCreate"
+                            ]
+                        }
+                    }
+                },
+                "address:B": {
+                    "nonce": "0",
+                    "balance": "0"
+                },
+                "sc:contract": {
+                    "nonce": "0",
+                    "balance": "0",
+                    "storage": {
+                        "str:sum": "5"
+                    },
+                    "code": "file:contract.wasm",
+                    "owner": "address:A"
+                }
+            }
+        },
+        {
+            "step": "saveState",
+            "name": "before-transfer"
+        },
+        {
+            "step": "transfer",
+            "id": "1",
+            "tx": {
+                "from": "address:A",
+                "to": "address:B",
+                "egldValue": "100"
+            }
+        }
+    ]
+}
+`
+
+const generatedCheckStateStep = `{
+    "steps": [
+        {
+            "step": "checkState",
+            "accounts": {
+                "sc:contract": {
+                    "nonce": "0",
+                    "balance": "0",
+                    "storage": {
+                        "str:sum": "5",
+                        "+": ""
+                    },
+                    "code": "file:../contract.wasm",
+                    "owner": "address:A"
+                },
+                "address:A": {
+                    "nonce": "1",
+                    "balance": "50",
+                    "esdt": {
+                        "str:NFT-123456": {
+                            "instances": [
+                                {
+                                    "nonce": "1",
+                                    "balance": "1",
+                                    "attributes": "str:rare"
+                                }
+                            ],
+                            "lastNonce": "1",
+                            "roles": [
+                                "ESDTRoleNFTCreate"
+                            ]
+                        },
+                        "str:TOK-123456": "20"
+                    },
+                    "storage": {
+                        "+": ""
+                    }
+                },
+                "address:B": {
+                    "nonce": "0",
+                    "balance": "100",
+                    "storage": {
+                        "+": ""
+                    }
+                }
+            }
+        }
+    ]
+}
+`
+
+const verifyCheckStateScenario = `{
+    "steps": [
+        {
+            "step": "externalSteps",
+            "path": "generate.scen.json"
+        },
+        {
+            "step": "externalSteps",
+            "path": "checks/check.steps.json"
+        }
+    ]
+}
+`
+
+func runGenerateCheckStateScenario(t *testing.T) (*scenexec.ScenarioExecutor, string) {
+	tempDir := writeTempFiles(t, map[string]string{
+		"generate.scen.json": generateCheckStateScenario,
+		"verify.scen.json":   verifyCheckStateScenario,
+		"contract.wasm":      "\x01\x00",
+	})
+
+	vmBuilder := &DummyVMBuilder{}
+	executor := scenexec.NewScenarioExecutor(vmBuilder)
+	controller := scenio.NewScenarioController(executor, scenio.NewDefaultFileResolver(), vmBuilder.GetVMType())
+	err := controller.RunSingleJSONScenario(
+		filepath.Join(tempDir, "generate.scen.json"),
+		scenio.DefaultRunScenarioOptions())
+	require.Nil(t, err)
+	return executor, tempDir
+}
+
+func TestWriteCheckStateStep(t *testing.T) {
+	executor, tempDir := runGenerateCheckStateScenario(t)
+	checkPath := filepath.Join(tempDir, "checks", "check.steps.json")
+	err := executor.WriteCheckStateStep(checkPath, scenexec.CheckStateOptions{MoreStorageAllowed: true})
+	require.Nil(t, err)
+
+	generated, err := os.ReadFile(checkPath)
+	require.Nil(t, err)
+	require.Equal(t, generatedCheckStateStep, string(generated))
+
+	err = newTestController().RunSingleJSONScenario(
+		filepath.Join(tempDir, "verify.scen.json"),
+		scenio.DefaultRunScenarioOptions())
+	require.Nil(t, err)
+}
+
+func TestGenerateCheckStateStepSinceBaseline(t *testing.T) {
+	executor, _ := runGenerateCheckStateScenario(t)
+	baseline, err := executor.World.ToWorldSnapshot().AccountMap()
+	require.Nil(t, err)
+	addressB := []byte("B_______________________________")
+	baseline[string(addressB)].Balance = big.NewInt(0)
+
+	step, err := executor.GenerateCheckStateStep(scenexec.CheckStateOptions{Baseline: baseline})
+	require.Nil(t, err)
+	require.True(t, step.CheckAccounts.MoreAccountsAllowed)
+	require.Len(t, step.CheckAccounts.Accounts, 1)
+	require.Equal(t, addressB, step.CheckAccounts.Accounts[0].Address.Value)
+
+	err = executor.ExecuteCheckStateStep(step)
+	require.Nil(t, err)
+}
+
+func TestGenerateCheckStateStepSinceBaselineAttributes(t *testing.T) {
+	executor, _ := runGenerateCheckStateScenario(t)
+	baseline, err := executor.World.ToWorldSnapshot().AccountMap()
+	require.Nil(t, err)
+
+	// only the NFT attributes change, and they are held by the system account
+	systemAccount := executor.World.AcctMap.CreateAccount(vmcommon.SystemAccountAddress, executor.World)
+	err = esdtconvert.SetTokenData([]byte("NFT-123456"), 1, &esdt.ESDigitalToken{
+		Value: big.NewInt(0),
+		TokenMetaData: &esdt.MetaData{
+			Name:       []byte{},
+			Nonce:      1,
+			Attributes: []byte("epic"),
+		},
+	}, systemAccount.Storage)
+	require.Nil(t, err)
+
+	step, err := executor.GenerateCheckStateStep(scenexec.CheckStateOptions{Baseline: baseline})
+	require.Nil(t, err)
+	require.Len(t, step.CheckAccounts.Accounts, 1)
+	require.Equal(t, []byte("A_______________________________"), step.CheckAccounts.Accounts[0].Address.Value)
+
+	err = executor.ExecuteCheckStateStep(step)
+	require.Nil(t, err)
+}
@@ -0,0 +1,122 @@
+package executortest
+
+import (
+	"math/big"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	scenexec "github.com/multiversx/mx-chain-scenario-go/scenario/executor"
+	scenio "github.com/multiversx/mx-chain-scenario-go/scenario/io"
+	scenmodel "github.com/multiversx/mx-chain-scenario-go/scenario/model"
+	worldmock "github.com/multiversx/mx-chain-scenario-go/worldmock"
+	"github.com/stretchr/testify/require"
+)
+
+func newCrossShardTestController() *scenio.ScenarioController {
+	vmBuilder := &CrossShardVMBuilder{}
+	return scenio.NewScenarioController(
+		scenexec.NewScenarioExecutor(vmBuilder),
+		scenio.NewDefaultFileResolver(),
+		vmBuilder.GetVMType(),
+	)
+}
+
+func TestCrossShard(t *testing.T) {
+	ScenariosTest(t).
+		Folder("scenarios-self-test/cross-shard-vm").
+		File("cross-shard.scen.json").
+		VM(&CrossShardVMBuilder{}).
+		Run().
+		CheckNoError()
+}
+
+func TestCrossShardESDT(t *testing.T) {
+	ScenariosTest(t).
+		Folder("scenarios-self-test/cross-shard-vm").
+		File("cross-shard-esdt.scen.json").
+		VM(&CrossShardVMBuilder{}).
+		Run().
+		CheckNoError()
+}
+
+func TestSingleShardAfterMultiShard(t *testing.T) {
+	executor := scenexec.NewScenarioExecutor(&DummyVMBuilder{})
+	controller := scenio.NewScenarioController(executor, scenio.NewDefaultFileResolver(), scenexec.TestVMType)
+	for _, scenarioPath := range []string{
+		filepath.Join(getTestRoot(), "scenarios-self-test", "multi-shard", "egld-cross-shard.scen.json"),
+		filepath.Join(getTestRoot(), "scenarios-self-test", "transfer-esdt.scen.json"),
+	} {
+		executor.Reset()
+		controller.RunsNewTest = true
+		err := controller.RunSingleJSONScenario(scenarioPath, scenio.DefaultRunScenarioOptions())
+		require.Nil(t, err)
+		require.Zero(t, executor.World.SelfShardID)
+	}
+}
+
+func TestPrepareScenarioMultiShard(t *testing.T) {
+	executor := scenexec.NewScenarioExecutor(&DummyVMBuilder{})
+	err := executor.PrepareScenario(&scenmodel.Scenario{MultiShard: true}, scenio.NewDefaultFileResolver())
+	require.Nil(t, err)
+	require.True(t, executor.World.MultiShard)
+}
+
+func TestCrossShardDeliveredInNextBlock(t *testing.T) {
+	executor := scenexec.NewScenarioExecutor(&DummyVMBuilder{})
+	controller := scenio.NewScenarioController(executor, scenio.NewDefaultFileResolver(), scenexec.TestVMType)
+	err := controller.RunSingleJSONScenario(
+		filepath.Join(getTestRoot(), "scenarios-self-test", "multi-shard", "egld-cross-shard-blocks.scen.json"),
+		scenio.DefaultRunScenarioOptions())
+	require.Nil(t, err)
+	require.Equal(t, uint64(6), executor.World.CurrentBlockInfo.BlockNonce)
+	require.Equal(t, uint64(5), executor.World.PreviousBlockInfo.BlockNonce)
+}
+
+func TestCrossShardRollbackObserved(t *testing.T) {
+	executor := scenexec.NewScenarioExecutor(&CrossShardVMBuilder{})
+	observer := &recordingObserver{}
+	executor.AddObserver(observer)
+	controller := scenio.NewScenarioController(executor, scenio.NewDefaultFileResolver(), scenexec.TestVMType)
+	err := controller.RunSingleJSONScenario(
+		filepath.Join(getTestRoot(), "scenarios-self-test", "cross-shard-vm", "cross-shard.scen.json"),
+		scenio.DefaultRunScenarioOptions())
+	require.Nil(t, err)
+
+	var rollbacks []string
+	for _, event := range observer.events {
+		if strings.HasPrefix(event, "rollback") {
+			rollbacks = append(rollbacks, event)
+		}
+	}
+	require.Equal(t,
+		[]string{
+			"rollback failed-async-call: cross-shard message failed: retcode=4, msg=ping failed",
+			"rollback failed-cross-shard-call: cross-shard message failed: retcode=4, msg=ping failed",
+		},
+		rollbacks)
+}
+
+func TestCheckpointCrossShardMessages(t *testing.T) {
+	gasMap, err := (&DummyVMBuilder{}).GasScheduleMapFromScenarios(scenmodel.GasScheduleDefault)
+	require.Nil(t, err)
+	world := worldmock.NewMockWorld()
+	require.Nil(t, world.InitBuiltinFunctions(gasMap))
+	builtinFuncs := world.BuiltinFuncs
+	message := &worldmock.CrossShardMessage{
+		DestinationShard: 1,
+		Sender:           []byte("sender"),
+		Receiver:         []byte("receiver"),
+		Value:            big.NewInt(10),
+		Data:             []byte("ping"),
+		OriginalTxHash:   []byte("tx-hash"),
+	}
+	world.QueueCrossShardMessage(message)
+	checkpoint := world.SaveCheckpoint()
+
+	require.Len(t, world.TakeCrossShardMessages(), 1)
+	require.Nil(t, world.RestoreCheckpoint(checkpoint))
+	require.Equal(t, []*worldmock.CrossShardMessage{message}, world.CrossShardMessages)
+	require.NotSame(t, message, world.CrossShardMessages[0])
+	require.NotSame(t, builtinFuncs, world.BuiltinFuncs)
+}
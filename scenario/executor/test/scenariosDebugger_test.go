@@ -0,0 +1,94 @@
+package executortest
+
+import (
+	"path"
+	"testing"
+
+	scenexec "github.com/multiversx/mx-chain-scenario-go/scenario/executor"
+	scenio "github.com/multiversx/mx-chain-scenario-go/scenario/io"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDebugger(t *testing.T, scenarioPath string) *scenexec.ScenarioDebugger {
+	executor := scenexec.NewScenarioExecutor(&DummyVMBuilder{})
+	controller := scenio.NewScenarioController(executor, scenio.NewDefaultFileResolver(), scenexec.TestVMType)
+	scenario, err := scenio.ParseScenariosScenario(
+		controller.Parser,
+		path.Join(getTestRoot(), "scenarios-self-test", scenarioPath))
+	require.Nil(t, err)
+
+	debugger, err := scenexec.NewScenarioDebugger(executor, scenario, controller.Parser.ExprInterpreter.FileResolver)
+	require.Nil(t, err)
+	return debugger
+}
+
+func TestParseBreakpoint(t *testing.T) {
+	breakpoint, err := scenexec.ParseBreakpoint("3")
+	require.Nil(t, err)
+	require.Equal(t, 3, breakpoint.StepIndex)
+
+	breakpoint, err = scenexec.ParseBreakpoint("fn:add")
+	require.Nil(t, err)
+	require.Equal(t, -1, breakpoint.StepIndex)
+	require.Equal(t, "add", breakpoint.Function)
+	require.Equal(t, "fn:add", breakpoint.String())
+
+	breakpoint, err = scenexec.ParseBreakpoint("check-1")
+	require.Nil(t, err)
+	require.Equal(t, "check-1", breakpoint.StepID)
+	require.Equal(t, "id:check-1", breakpoint.String())
+
+	_, err = scenexec.ParseBreakpoint(" ")
+	require.NotNil(t, err)
+	_, err = scenexec.ParseBreakpoint("-1")
+	require.NotNil(t, err)
+}
+
+func TestScenarioDebuggerBreakpoints(t *testing.T) {
+	debugger := newTestDebugger(t, "transfer-egld.scen.json")
+	breakpoint, err := scenexec.ParseBreakpoint("id:2")
+	require.Nil(t, err)
+	debugger.AddBreakpoint(breakpoint)
+	require.True(t, debugger.HasBreakpoint(3))
+	require.Equal(t, "3: transfer \"2\"", debugger.DescribeStep(3))
+
+	require.Nil(t, debugger.Continue())
+	require.Equal(t, 3, debugger.NextStepIndex())
+	require.Contains(t, debugger.DescribeLastOutput(), "status: 0")
+
+	addressA, err := debugger.ParseValue("address:A")
+	require.Nil(t, err)
+	description, err := debugger.DescribeAccount(addressA)
+	require.Nil(t, err)
+	require.Contains(t, description, "nonce: 1\n")
+	require.Contains(t, description, "balance: 50\n")
+
+	require.Nil(t, debugger.Step())
+	require.Equal(t, 4, debugger.NextStepIndex())
+	require.Nil(t, debugger.Continue())
+	require.True(t, debugger.Finished())
+	require.NotNil(t, debugger.Step())
+
+	addressC, err := debugger.ParseValue("address:C")
+	require.Nil(t, err)
+	_, err = debugger.DescribeAccount(addressC)
+	require.NotNil(t, err)
+}
+
+func TestScenarioDebuggerFailedStep(t *testing.T) {
+	debugger := newTestDebugger(t, "set-check/set-check-nonce.err.json")
+	require.Nil(t, debugger.Step())
+
+	err := debugger.Continue()
+	require.NotNil(t, err)
+	stepErr, isStepErr := err.(*scenio.StepError)
+	require.True(t, isStepErr)
+	require.Equal(t, 1, stepErr.StepIndex)
+	require.True(t, debugger.Finished())
+}
+
+func TestScenarioDebuggerExternalSteps(t *testing.T) {
+	debugger := newTestDebugger(t, "external_steps/save-external-restore.scen.json")
+	require.Nil(t, debugger.Continue())
+	require.True(t, debugger.Finished())
+}
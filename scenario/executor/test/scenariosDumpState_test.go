@@ -0,0 +1,174 @@
+package executortest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	scenio "github.com/multiversx/mx-chain-scenario-go/scenario/io"
+	"github.com/stretchr/testify/require"
+)
+
+const dumpStateScenario = `{
+    "steps": [
+        {
+            "step": "setState",
+            "accounts": {
+                "address:A": {
+                    "nonce": "0",
+                    "balance": "150",
+                    "esdt": {
+                        "str:TOK-123456": "20"
+                    }
+                },
+                "address:B": {
+                    "nonce": "0",
+                    "balance": "0",
+                    "shard": "1"
+                },
+                "sc:contract": {
+                    "nonce": "0",
+                    "balance": "0",
+                    "storage": {
+                        "str:sum": "5"
+                    },
+                    "code": "0x0100",
+                    "owner": "address:A"
+                }
+            },
+            "currentBlockInfo": {
+                "blockNonce": "7"
+            },
+            "blockHashes": [
+                "0x1234"
+            ],
+            "newAddresses": [
+                {
+                    "creatorAddress": "address:A",
+                    "creatorNonce": "1",
+                    "newAddress": "sc:deployed"
+                }
+            ]
+        },
+        {
+            "step": "transfer",
+            "id": "1",
+            "tx": {
+                "from": "address:A",
+                "to": "address:B",
+                "egldValue": "100"
+            }
+        },
+        {
+            "step": "dumpState",
+            "comment": "state after the transfer",
+            "path": "fixtures/after-transfer.steps.json"
+        },
+        {
+            "step": "dumpState",
+            "path": "fixtures/check-b.steps.json",
+            "format": "checkState",
+            "accounts": [
+                "address:B"
+            ]
+        }
+    ]
+}
+`
+
+const dumpedCheckBStep = `{
+    "steps": [
+        {
+            "step": "checkState",
+            "accounts": {
+                "address:B": {
+                    "nonce": "0",
+                    "balance": "100",
+                    "storage": {}
+                },
+                "+": ""
+            }
+        }
+    ]
+}
+`
+
+const fixtureScenario = `{
+    "steps": [
+        {
+            "step": "externalSteps",
+            "path": "fixtures/after-transfer.steps.json"
+        },
+        {
+            "step": "externalSteps",
+            "path": "fixtures/check-b.steps.json"
+        },
+        {
+            "step": "checkState",
+            "accounts": {
+                "address:A": {
+                    "nonce": "1",
+                    "balance": "50",
+                    "esdt": {
+                        "str:TOK-123456": "20"
+                    },
+                    "storage": "*",
+                    "code": ""
+                },
+                "address:B": {
+                    "nonce": "0",
+                    "balance": "100",
+                    "storage": {},
+                    "code": ""
+                },
+                "sc:contract": {
+                    "nonce": "0",
+                    "balance": "0",
+                    "storage": {
+                        "str:sum": "5"
+                    },
+                    "code": "0x0100",
+                    "owner": "address:A"
+                }
+            }
+        }
+    ]
+}
+`
+
+func TestDumpStateToFile(t *testing.T) {
+	tempDir := writeTempFiles(t, map[string]string{
+		"dump.scen.json":    dumpStateScenario,
+		"fixture.scen.json": fixtureScenario,
+	})
+
+	err := newTestController().RunSingleJSONScenario(
+		filepath.Join(tempDir, "dump.scen.json"),
+		scenio.DefaultRunScenarioOptions())
+	require.Nil(t, err)
+
+	checkB, err := os.ReadFile(filepath.Join(tempDir, "fixtures", "check-b.steps.json"))
+	require.Nil(t, err)
+	require.Equal(t, dumpedCheckBStep, string(checkB))
+
+	afterTransfer, err := os.ReadFile(filepath.Join(tempDir, "fixtures", "after-transfer.steps.json"))
+	require.Nil(t, err)
+	require.Contains(t, string(afterTransfer), `"shard": "1"`)
+	require.Contains(t, string(afterTransfer), `"blockHashes": [
+                "0x1234"
+            ]`)
+	require.Contains(t, string(afterTransfer), `"newAddress": "sc:deployed"`)
+
+	err = newTestController().RunSingleJSONScenario(
+		filepath.Join(tempDir, "fixture.scen.json"),
+		scenio.DefaultRunScenarioOptions())
+	require.Nil(t, err)
+}
+
+func TestDumpStateUnknownAccountErr(t *testing.T) {
+	ScenariosTest(t).
+		Folder("scenarios-self-test/set-check").
+		File("dump-state-unknown-account.err.json").
+		Run().
+		RequireError("cannot dump account address:missing, it does not exist")
+}
@@ -0,0 +1,102 @@
+package executortest
+
+import (
+	"path"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	scenio "github.com/multiversx/mx-chain-scenario-go/scenario/io"
+	"github.com/stretchr/testify/require"
+)
+
+func listSelfTestScenarios(t *testing.T, options *scenio.RunScenarioOptions) []string {
+	paths, err := newTestController().ListJSONScenariosInDirectory(
+		getTestRoot(),
+		"scenarios-self-test",
+		".scen.json",
+		[]string{},
+		options)
+	require.Nil(t, err)
+
+	var relativePaths []string
+	for _, scenarioPath := range paths {
+		relativePaths = append(relativePaths, scenarioPath[len(getTestRoot())+1:])
+	}
+	return relativePaths
+}
+
+func TestListScenariosIncludeExclude(t *testing.T) {
+	options := scenio.DefaultRunScenarioOptions()
+	options.IncludePatterns = []string{"transfer-*.scen.json"}
+	options.ExcludePatterns = []string{"scenarios-self-test/transfer-esdt-*"}
+	require.Equal(t,
+		[]string{
+			path.Join("scenarios-self-test", "transfer-egld.scen.json"),
+			path.Join("scenarios-self-test", "transfer-esdt.scen.json"),
+		},
+		listSelfTestScenarios(t, options))
+}
+
+func TestListScenariosByName(t *testing.T) {
+	options := scenio.DefaultRunScenarioOptions()
+	options.NameFilter = regexp.MustCompile("^check should fail")
+	require.Equal(t,
+		[]string{
+			path.Join("scenarios-self-test", "esdt-non-zero-balance-check-err.scen.json"),
+			path.Join("scenarios-self-test", "esdt-zero-balance-check-err.scen.json"),
+		},
+		listSelfTestScenarios(t, options))
+}
+
+func TestListScenariosByStepID(t *testing.T) {
+	options := scenio.DefaultRunScenarioOptions()
+	options.StepIDFilter = regexp.MustCompile("^cross-shard-transfer$")
+	require.Equal(t,
+		[]string{
+			path.Join("scenarios-self-test", "cross-shard-vm", "cross-shard.scen.json"),
+			path.Join("scenarios-self-test", "multi-shard", "egld-cross-shard-blocks.scen.json"),
+			path.Join("scenarios-self-test", "multi-shard", "egld-cross-shard.scen.json"),
+		},
+		listSelfTestScenarios(t, options))
+}
+
+func TestRunScenariosByName(t *testing.T) {
+	options := scenio.DefaultRunScenarioOptions()
+	options.NameFilter = regexp.MustCompile("external steps")
+	report, err := newTestController().RunAllJSONScenariosInDirectoryWithReport(
+		getTestRoot(),
+		"scenarios-self-test",
+		".scen.json",
+		[]string{},
+		options)
+	require.Nil(t, err)
+	require.Len(t, report.Results, 1)
+	require.Equal(t, scenio.ScenarioPassed, report.Results[0].Status)
+}
+
+func TestScenarioDependencies(t *testing.T) {
+	dirPath, err := filepath.Abs(path.Join(getTestRoot(), "scenarios-self-test"))
+	require.Nil(t, err)
+
+	dependencies, err := newTestController().ScenarioDependencies(
+		path.Join(dirPath, "external_steps", "external_steps.scen.json"))
+	require.Nil(t, err)
+	require.Equal(t,
+		[]string{
+			path.Join(dirPath, "external_steps", "external_step_2.step.json"),
+			path.Join(dirPath, "external_steps", "external_steps.scen.json"),
+			path.Join(dirPath, "external_steps", "nested", "external_step_1.step.json"),
+		},
+		dependencies)
+
+	dependencies, err = newTestController().ScenarioDependencies(
+		path.Join(dirPath, "set-check", "set-check-code.scen.json"))
+	require.Nil(t, err)
+	require.Equal(t,
+		[]string{
+			path.Join(dirPath, "set-check", "set-check-code.scen.json"),
+			path.Join(dirPath, "set-check", "set-check-esdt.scen.json"),
+		},
+		dependencies)
+}
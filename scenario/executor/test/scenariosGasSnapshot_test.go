@@ -0,0 +1,121 @@
+package executortest
+
+import (
+	"path"
+	"path/filepath"
+	"testing"
+
+	scenio "github.com/multiversx/mx-chain-scenario-go/scenario/io"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGasSnapshotRoundTrip(t *testing.T) {
+	report, err := newTestController().RunAllJSONScenariosInDirectoryWithReport(
+		getTestRoot(),
+		"scenarios-self-test",
+		".scen.json",
+		[]string{},
+		&scenio.RunScenarioOptions{
+			IncludePatterns: []string{"transfer-egld.scen.json"},
+		})
+	require.Nil(t, err)
+	require.Nil(t, report.Err())
+
+	snapshot := scenio.NewGasSnapshot(report, getTestRoot())
+	require.Contains(t, snapshot, "scenarios-self-test/transfer-egld.scen.json")
+	require.Len(t, snapshot["scenarios-self-test/transfer-egld.scen.json"], 2)
+
+	snapshotPath := filepath.Join(t.TempDir(), "gas-snapshot.json")
+	err = scenio.WriteGasSnapshotFile(snapshot, snapshotPath)
+	require.Nil(t, err)
+	loaded, err := scenio.LoadGasSnapshot(snapshotPath)
+	require.Nil(t, err)
+	require.Equal(t, snapshot, loaded)
+	require.Empty(t, scenio.FindGasRegressions(loaded, snapshot, 0))
+}
+
+func TestGasSnapshotGasUsed(t *testing.T) {
+	report, err := newTestController().RunAllJSONScenariosInDirectoryWithReport(
+		getTestRoot(),
+		"scenarios-self-test",
+		".scen.json",
+		[]string{},
+		&scenio.RunScenarioOptions{
+			IncludePatterns: []string{"scenarios-self-test/gas/*.scen.json"},
+		})
+	require.Nil(t, err)
+	require.Nil(t, report.Err())
+
+	snapshot := scenio.NewGasSnapshot(report, getTestRoot())
+	require.Equal(t,
+		map[string]uint64{
+			"egld-transfer": 0,
+			"step-2":        0,
+		},
+		snapshot["scenarios-self-test/gas/egld-transfer-gas.scen.json"])
+}
+
+func TestGasSnapshotKeys(t *testing.T) {
+	report := &scenio.ScenariosReport{
+		Results: []*scenio.ScenarioResult{
+			{
+				Path: path.Join("tests", "a.scen.json"),
+				TxProfiles: []*scenio.TxProfile{
+					{StepID: "call", GasUsed: 10},
+					{StepID: "call", GasUsed: 20},
+					{StepID: "", StepIndex: 4, GasUsed: 30},
+				},
+			},
+		},
+	}
+	require.Equal(t,
+		scenio.GasSnapshot{
+			"a.scen.json": {
+				"call":   10,
+				"call#2": 20,
+				"step-4": 30,
+			},
+		},
+		scenio.NewGasSnapshot(report, "tests"))
+}
+
+func TestFindGasRegressions(t *testing.T) {
+	baseline := scenio.GasSnapshot{
+		"a.scen.json": {"tx-1": 1000, "tx-2": 1000},
+		"b.scen.json": {"tx-1": 500},
+	}
+	current := scenio.GasSnapshot{
+		"a.scen.json": {"tx-1": 1010, "tx-2": 1030, "tx-new": 5000},
+		"b.scen.json": {"tx-1": 400},
+		"c.scen.json": {"tx-1": 5000},
+	}
+
+	regressions := scenio.FindGasRegressions(baseline, current, 0)
+	require.Len(t, regressions, 2)
+	require.Equal(t, "a.scen.json, tx tx-1: gas used 1010, baseline 1000 (+1.00%)", regressions[0].String())
+	require.Equal(t, "a.scen.json, tx tx-2: gas used 1030, baseline 1000 (+3.00%)", regressions[1].String())
+
+	regressions = scenio.FindGasRegressions(baseline, current, 2)
+	require.Len(t, regressions, 1)
+	require.Equal(t, "tx-2", regressions[0].TxID)
+}
+
+func TestUnmatchedGasBaseline(t *testing.T) {
+	baseline := scenio.GasSnapshot{
+		"a.scen.json": {"tx-1": 1000, "tx-2": 1000},
+		"b.scen.json": {"tx-1": 500},
+	}
+
+	unmatched, matchedTxs := scenio.UnmatchedGasBaseline(baseline, scenio.GasSnapshot{
+		"a.scen.json": {"tx-1": 1000, "tx-new": 5000},
+	})
+	require.Equal(t, []string{"a.scen.json, tx tx-2", "b.scen.json"}, unmatched)
+	require.Equal(t, 1, matchedTxs)
+
+	// same scenarios, keyed relative to another directory
+	unmatched, matchedTxs = scenio.UnmatchedGasBaseline(baseline, scenio.GasSnapshot{
+		"tests/a.scen.json": {"tx-1": 1000, "tx-2": 1000},
+	})
+	require.Equal(t, []string{"a.scen.json", "b.scen.json"}, unmatched)
+	require.Zero(t, matchedTxs)
+}
@@ -0,0 +1,123 @@
+package executortest
+
+import (
+	"bytes"
+	"math/big"
+	"path"
+	"testing"
+
+	scenexec "github.com/multiversx/mx-chain-scenario-go/scenario/executor"
+	scenio "github.com/multiversx/mx-chain-scenario-go/scenario/io"
+	scenmodel "github.com/multiversx/mx-chain-scenario-go/scenario/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScenarioGasTraces(t *testing.T) {
+	executor := scenexec.NewScenarioExecutor(&DummyVMBuilder{})
+	var handledTraces []*scenio.TxGasTrace
+	executor.SetGasTraceHandler(func(gasTrace *scenio.TxGasTrace) {
+		handledTraces = append(handledTraces, gasTrace)
+	})
+	controller := scenio.NewScenarioController(executor, scenio.NewDefaultFileResolver(), scenexec.TestVMType)
+	scenarioPath := path.Join(getTestRoot(), "scenarios-self-test", "transfer-egld.scen.json")
+
+	result := controller.RunSingleJSONScenarioWithResult(scenarioPath, scenio.DefaultRunScenarioOptions())
+	require.Nil(t, result.Err)
+	require.Empty(t, result.GasTraces)
+	require.Empty(t, handledTraces)
+
+	options := scenio.DefaultRunScenarioOptions()
+	options.ForceTraceGas = true
+	controller.RunsNewTest = true
+	result = controller.RunSingleJSONScenarioWithResult(scenarioPath, options)
+	require.Nil(t, result.Err)
+	require.Len(t, result.GasTraces, 2)
+	require.Equal(t, "1", result.GasTraces[0].StepID)
+	require.Equal(t, "transfer", result.GasTraces[0].StepType)
+	require.Equal(t, result.GasTraces, handledTraces)
+}
+
+func TestGasTracesDirectTxSteps(t *testing.T) {
+	executor := scenexec.NewScenarioExecutor(&DummyVMBuilder{})
+	err := executor.PrepareScenario(
+		&scenmodel.Scenario{TraceGas: true, IsNewTest: true},
+		scenio.NewDefaultFileResolver())
+	require.Nil(t, err)
+
+	sender := []byte("sender__________________________")
+	receiver := []byte("receiver________________________")
+	err = executor.ExecuteSetStateStep(&scenmodel.SetStateStep{
+		Accounts: []*scenmodel.Account{
+			{
+				Address:         scenmodel.NewJSONBytesFromString(sender, ""),
+				Balance:         scenmodel.JSONBigInt{Value: big.NewInt(1000)},
+				DeveloperReward: scenmodel.JSONBigIntZero(),
+			},
+		},
+	})
+	require.Nil(t, err)
+
+	txStep := func(txIdent string, txType scenmodel.TransactionType) *scenmodel.TxStep {
+		return &scenmodel.TxStep{
+			TxIdent: txIdent,
+			Tx: &scenmodel.Transaction{
+				Type:      txType,
+				From:      scenmodel.NewJSONBytesFromString(sender, ""),
+				To:        scenmodel.NewJSONBytesFromString(receiver, ""),
+				Function:  "missing",
+				EGLDValue: scenmodel.JSONBigInt{Value: big.NewInt(400)},
+			},
+		}
+	}
+	_, err = executor.ExecuteTxStep(txStep("ok", scenmodel.Transfer))
+	require.Nil(t, err)
+	// the call fails, because the receiver is not a contract
+	_, err = executor.ExecuteTxStep(txStep("failed", scenmodel.ScCall))
+	require.NotNil(t, err)
+
+	require.Len(t, executor.TxGasTraces(), 1)
+	require.Equal(t, "ok", executor.TxGasTraces()[0].StepID)
+}
+
+func TestGasTracesFolded(t *testing.T) {
+	report := &scenio.ScenariosReport{
+		Results: []*scenio.ScenarioResult{
+			{
+				Path: "adder.scen.json",
+				GasTraces: []*scenio.TxGasTrace{
+					{
+						StepID:   "add",
+						StepType: "scCall",
+						Contracts: []*scenio.GasTraceContract{
+							{
+								Address:  "sc:adder",
+								TotalGas: 130,
+								Functions: []*scenio.GasTraceFunction{
+									{Function: "bigIntAdd", CallCount: 2, TotalGas: 30, Gas: []uint64{10, 20}},
+									{Function: "storageStore", CallCount: 1, TotalGas: 100, Gas: []uint64{100}},
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				Path: "no-trace.scen.json",
+			},
+		},
+	}
+
+	var folded bytes.Buffer
+	err := scenio.WriteGasTracesFolded(report, &folded)
+	require.Nil(t, err)
+	require.Equal(t,
+		"adder.scen.json;scCall add;sc:adder;bigIntAdd 30\n"+
+			"adder.scen.json;scCall add;sc:adder;storageStore 100\n",
+		folded.String())
+
+	var jsonTraces bytes.Buffer
+	err = scenio.WriteGasTracesJSON(report, &jsonTraces)
+	require.Nil(t, err)
+	require.Contains(t, jsonTraces.String(), `"scenario": "adder.scen.json"`)
+	require.NotContains(t, jsonTraces.String(), "no-trace.scen.json")
+}
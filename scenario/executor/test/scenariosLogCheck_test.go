@@ -0,0 +1,59 @@
+package executortest
+
+import (
+	"testing"
+)
+
+func runLogCheck(t *testing.T, fileName string) *ScenariosTestBuilder {
+	return ScenariosTest(t).
+		Folder("scenarios-self-test/echo-vm").
+		File(fileName).
+		VM(&EchoVMBuilder{}).
+		Run()
+}
+
+func TestLogCheckUnordered(t *testing.T) {
+	runLogCheck(t, "log-check-unordered.scen.json").CheckNoError()
+	runLogCheck(t, "log-check-unordered.err.json").RequireError(`log mismatch. Tx 'emit'.
+unexpected log. Log index: 2. Log:
+{
+    "address": "sc:echo",
+    "endpoint": "str:transfer",
+    "topics": [],
+    "data": []
+}`)
+}
+
+func TestLogCheckContains(t *testing.T) {
+	runLogCheck(t, "log-check-contains.scen.json").CheckNoError()
+	runLogCheck(t, "log-check-contains.err.json").RequireError(`log mismatch. Tx 'emit'.
+log not found. Want:
+{
+    "address": "sc:echo",
+    "endpoint": "str:transfer",
+    "topics": [
+        "str:to"
+    ],
+    "data": []
+}
+Closest:
+{
+    "address": "sc:echo",
+    "endpoint": "str:transfer",
+    "topics": [],
+    "data": []
+}`)
+}
+
+func TestLogCheckCount(t *testing.T) {
+	runLogCheck(t, "log-check-count.scen.json").CheckNoError()
+	runLogCheck(t, "log-check-count.err.json").RequireError(`log mismatch. Tx 'emit'.
+bad log count. Want: ">= 3". Have: 2. Log:
+{
+    "address": "sc:echo",
+    "endpoint": "str:transfer",
+    "topics": [],
+    "data": [],
+    "count": ">= 3"
+}`)
+}
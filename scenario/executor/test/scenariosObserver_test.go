@@ -0,0 +1,96 @@
+package executortest
+
+import (
+	"fmt"
+	"path"
+	"testing"
+
+	scenexec "github.com/multiversx/mx-chain-scenario-go/scenario/executor"
+	scenio "github.com/multiversx/mx-chain-scenario-go/scenario/io"
+	scenmodel "github.com/multiversx/mx-chain-scenario-go/scenario/model"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingObserver struct {
+	scenexec.NoOpScenarioObserver
+	events []string
+}
+
+func (ro *recordingObserver) BeforeStep(step scenmodel.Step) {
+	ro.events = append(ro.events, "before "+step.StepTypeName())
+}
+
+func (ro *recordingObserver) AfterStep(step scenmodel.Step, err error) {
+	ro.events = append(ro.events, fmt.Sprintf("after %s, failed: %t", step.StepTypeName(), err != nil))
+}
+
+func (ro *recordingObserver) BeforeTx(step *scenmodel.TxStep, input *vmcommon.ContractCallInput) {
+	ro.events = append(ro.events, fmt.Sprintf("before tx %s, value %d", step.TxIdent, input.CallValue))
+}
+
+func (ro *recordingObserver) AfterTx(step *scenmodel.TxStep, output *vmcommon.VMOutput, err error) {
+	ro.events = append(ro.events, fmt.Sprintf("after tx %s, retcode %d", step.TxIdent, output.ReturnCode))
+}
+
+func (ro *recordingObserver) OnCheckFailure(step scenmodel.Step, err error) {
+	ro.events = append(ro.events, "check failure "+step.StepTypeName())
+}
+
+func (ro *recordingObserver) OnWorldCommit(txID string) {
+	ro.events = append(ro.events, "commit "+txID)
+}
+
+func (ro *recordingObserver) OnWorldRollback(txID string, cause error) {
+	ro.events = append(ro.events, fmt.Sprintf("rollback %s: %v", txID, cause))
+}
+
+func runObservedScenario(t *testing.T, scenarioPath string) (*scenio.ScenarioResult, []string) {
+	executor := scenexec.NewScenarioExecutor(&DummyVMBuilder{})
+	observer := &recordingObserver{}
+	executor.AddObserver(observer)
+	controller := scenio.NewScenarioController(executor, scenio.NewDefaultFileResolver(), scenexec.TestVMType)
+	result := controller.RunSingleJSONScenarioWithResult(
+		path.Join(getTestRoot(), "scenarios-self-test", scenarioPath),
+		scenio.DefaultRunScenarioOptions())
+	return result, observer.events
+}
+
+func TestScenarioObserverTx(t *testing.T) {
+	result, events := runObservedScenario(t, "transfer-egld.scen.json")
+	require.Nil(t, result.Err)
+	require.Equal(t,
+		[]string{
+			"before setState",
+			"after setState, failed: false",
+			"before transfer",
+			"before tx 1, value 100",
+			"commit 1",
+			"after tx 1, retcode 0",
+			"after transfer, failed: false",
+			"before checkState",
+			"after checkState, failed: false",
+			"before transfer",
+			"before tx 2, value 50",
+			"commit 2",
+			"after tx 2, retcode 0",
+			"after transfer, failed: false",
+			"before checkState",
+			"after checkState, failed: false",
+		},
+		events)
+}
+
+func TestScenarioObserverCheckFailure(t *testing.T) {
+	result, events := runObservedScenario(t, "set-check/set-check-nonce.err.json")
+	require.NotNil(t, result.Err)
+	require.Equal(t,
+		[]string{
+			"before setState",
+			"after setState, failed: false",
+			"before checkState",
+			"check failure checkState",
+			"after checkState, failed: true",
+		},
+		events)
+}
@@ -0,0 +1,75 @@
+package executortest
+
+import (
+	"bytes"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	scenio "github.com/multiversx/mx-chain-scenario-go/scenario/io"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScenarioTxProfiles(t *testing.T) {
+	result := newTestController().RunSingleJSONScenarioWithResult(
+		path.Join(getTestRoot(), "scenarios-self-test", "transfer-egld.scen.json"),
+		scenio.DefaultRunScenarioOptions())
+	require.Nil(t, result.Err)
+	require.Len(t, result.TxProfiles, 2)
+	require.Equal(t, "1", result.TxProfiles[0].StepID)
+	require.Equal(t, "transfer", result.TxProfiles[0].StepType)
+	require.Equal(t, "", result.TxProfiles[0].Contract)
+	require.Equal(t, "2", result.TxProfiles[1].StepID)
+}
+
+func TestScenariosProfileAggregation(t *testing.T) {
+	report := &scenio.ScenariosReport{
+		Results: []*scenio.ScenarioResult{
+			{
+				Path: "a.scen.json",
+				TxProfiles: []*scenio.TxProfile{
+					{StepID: "deploy", Contract: "sc:adder", Endpoint: "init", Duration: time.Millisecond, GasUsed: 100},
+					{StepID: "add", Contract: "sc:adder", Endpoint: "add", Duration: time.Millisecond, GasUsed: 30},
+					{StepID: "pay", Duration: time.Millisecond, GasUsed: 5},
+				},
+			},
+			{
+				Path: "b.scen.json",
+				TxProfiles: []*scenio.TxProfile{
+					{StepID: "add-1", Contract: "sc:adder", Endpoint: "add", Duration: time.Millisecond, GasUsed: 40},
+					{StepID: "add-2", Contract: "sc:adder", Endpoint: "add", Duration: time.Millisecond, GasUsed: 40},
+				},
+			},
+		},
+	}
+
+	profile := scenio.NewScenariosProfile(report)
+	require.Equal(t,
+		[]*scenio.ProfileEntry{
+			{Key: "a.scen.json", TxCount: 3, Duration: 3 * time.Millisecond, GasUsed: 135},
+			{Key: "b.scen.json", TxCount: 2, Duration: 2 * time.Millisecond, GasUsed: 80},
+		},
+		profile.Scenarios)
+	require.Equal(t,
+		[]*scenio.ProfileEntry{
+			{Key: "sc:adder", TxCount: 4, Duration: 4 * time.Millisecond, GasUsed: 210},
+		},
+		profile.Contracts)
+	require.Equal(t,
+		[]*scenio.ProfileEntry{
+			{Key: "sc:adder add", TxCount: 3, Duration: 3 * time.Millisecond, GasUsed: 110},
+			{Key: "sc:adder init", TxCount: 1, Duration: time.Millisecond, GasUsed: 100},
+		},
+		profile.Endpoints)
+
+	var table bytes.Buffer
+	err := scenio.WriteScenariosProfile(profile, scenio.ProfileFormatTable, &table)
+	require.Nil(t, err)
+	require.True(t, strings.Contains(table.String(), "sc:adder add"))
+
+	var jsonProfile bytes.Buffer
+	err = scenio.WriteScenariosProfile(profile, scenio.ProfileFormatJSON, &jsonProfile)
+	require.Nil(t, err)
+	require.True(t, strings.Contains(jsonProfile.String(), `"gasUsed": 210`))
+}
@@ -0,0 +1,63 @@
+package executortest
+
+import (
+	"bytes"
+	"path"
+	"testing"
+
+	scenexec "github.com/multiversx/mx-chain-scenario-go/scenario/executor"
+	scenio "github.com/multiversx/mx-chain-scenario-go/scenario/io"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestController() *scenio.ScenarioController {
+	vmBuilder := &DummyVMBuilder{}
+	return scenio.NewScenarioController(
+		scenexec.NewScenarioExecutor(vmBuilder),
+		scenio.NewDefaultFileResolver(),
+		vmBuilder.GetVMType(),
+	)
+}
+
+func TestScenarioResultFailedStep(t *testing.T) {
+	controller := newTestController()
+	result := controller.RunSingleJSONScenarioWithResult(
+		path.Join(getTestRoot(), "scenarios-self-test/set-check/set-check-nonce.err.json"),
+		scenio.DefaultRunScenarioOptions())
+
+	require.Equal(t, scenio.ScenarioFailed, result.Status)
+	require.Equal(t, 1, result.FailedStepIndex)
+	require.Equal(t, "check-1", result.FailedStepID)
+	require.Equal(t, "checkState", result.FailedStepType)
+	require.Equal(t,
+		"Check state \"check-1\": mismatch for account \"address:the-address\":\n"+
+			"  bad account nonce. Want: \"1002\". Have: \"1001\"",
+		result.ErrorMessage())
+}
+
+func TestScenariosReportJUnit(t *testing.T) {
+	controller := newTestController()
+	options := scenio.DefaultRunScenarioOptions()
+	options.IncludePatterns = []string{"scenarios-self-test/*.scen.json"}
+	report, err := controller.RunAllJSONScenariosInDirectoryWithReport(
+		getTestRoot(),
+		"scenarios-self-test",
+		".scen.json",
+		[]string{"scenarios-self-test/builtin-func-esdt-transfer.scen.json"},
+		options)
+	require.Nil(t, err)
+	require.Equal(t, 1, report.CountStatus(scenio.ScenarioSkipped))
+	require.Equal(t, 2, report.CountStatus(scenio.ScenarioFailed))
+
+	buffer := &bytes.Buffer{}
+	err = scenio.WriteScenariosReport(report, scenio.ReportFormatJUnit, buffer)
+	require.Nil(t, err)
+	require.Contains(t, buffer.String(), `<testsuite name="scenarios" tests="`)
+	require.Contains(t, buffer.String(), `failures="2" skipped="1"`)
+	require.Contains(t, buffer.String(), `<failure message="Check state &#34;check-1&#34;: mismatch for account &#34;address:A&#34;:" type="checkState">`)
+
+	buffer.Reset()
+	err = scenio.WriteScenariosReport(report, scenio.ReportFormatJSON, buffer)
+	require.Nil(t, err)
+	require.Contains(t, buffer.String(), `"failedStepId": "check-1"`)
+}
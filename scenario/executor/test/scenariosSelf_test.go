@@ -1,20 +1,74 @@
 package executortest
 
 import (
+	"path/filepath"
 	"testing"
+
+	scenexec "github.com/multiversx/mx-chain-scenario-go/scenario/executor"
+	scenio "github.com/multiversx/mx-chain-scenario-go/scenario/io"
+	"github.com/stretchr/testify/require"
 )
 
 // Tests Scenarios consistency, no smart contracts.
+// The scenarios in echo-vm and cross-shard-vm need those VM stand-ins, and run in their own tests.
 func TestScenariosSelfTest(t *testing.T) {
 	ScenariosTest(t).
 		Folder("scenarios-self-test").
 		Exclude("scenarios-self-test/builtin-func-esdt-transfer.scen.json").
 		Exclude("scenarios-self-test/esdt-zero-balance-check-err.scen.json").
 		Exclude("scenarios-self-test/esdt-non-zero-balance-check-err.scen.json").
+		Exclude("scenarios-self-test/echo-vm/*").
+		Exclude("scenarios-self-test/cross-shard-vm/*").
 		Run().
 		CheckNoError()
 }
 
+func TestScenariosSelfTestParallel(t *testing.T) {
+	ScenariosTest(t).
+		Folder("scenarios-self-test").
+		Exclude("scenarios-self-test/builtin-func-esdt-transfer.scen.json").
+		Exclude("scenarios-self-test/esdt-zero-balance-check-err.scen.json").
+		Exclude("scenarios-self-test/esdt-non-zero-balance-check-err.scen.json").
+		Exclude("scenarios-self-test/echo-vm/*").
+		Exclude("scenarios-self-test/cross-shard-vm/*").
+		Parallel(4).
+		Run().
+		CheckNoError()
+}
+
+func TestScenariosParallelFailure(t *testing.T) {
+	vmBuilder := &DummyVMBuilder{}
+	controller := newTestController()
+	controller.RunnerFactory = func() scenio.ScenarioRunner {
+		return scenexec.NewScenarioExecutor(vmBuilder)
+	}
+	options := scenio.DefaultRunScenarioOptions()
+	options.Parallelism = 4
+	report, err := controller.RunAllJSONScenariosInDirectoryWithReport(
+		getTestRoot(),
+		"scenarios-self-test",
+		".scen.json",
+		[]string{
+			"scenarios-self-test/builtin-func-esdt-transfer.scen.json",
+			"scenarios-self-test/echo-vm/*",
+			"scenarios-self-test/cross-shard-vm/*",
+		},
+		options)
+	require.Nil(t, err)
+	require.EqualError(t, report.Err(), "some tests failed")
+
+	var failedPaths []string
+	for _, result := range report.Results {
+		if result.Status == scenio.ScenarioFailed {
+			failedPaths = append(failedPaths, result.Path)
+		}
+	}
+	require.Equal(t, []string{
+		filepath.Join(getTestRoot(), "scenarios-self-test", "esdt-non-zero-balance-check-err.scen.json"),
+		filepath.Join(getTestRoot(), "scenarios-self-test", "esdt-zero-balance-check-err.scen.json"),
+	}, failedPaths)
+}
+
 func TestSetAccountAddressLengthErr1(t *testing.T) {
 	ScenariosTest(t).
 		Folder("scenarios-self-test/set-check").
@@ -66,7 +120,47 @@ func TestScenariosCheckNonceErr(t *testing.T) {
 		File("set-check-nonce.err.json").
 		Run().
 		RequireError(
-			"Check state \"check-1\": bad account nonce. Account: address:the-address. Want: \"1002\". Have: \"1001\"")
+			"Check state \"check-1\": mismatch for account \"address:the-address\":\n" +
+				"  bad account nonce. Want: \"1002\". Have: \"1001\"")
+}
+
+func TestScenariosCheckMultipleErr(t *testing.T) {
+	ScenariosTest(t).
+		Folder("scenarios-self-test/set-check").
+		File("set-check-multiple.err.json").
+		Run().
+		RequireError(
+			"Check state \"check-1\": mismatch for account \"address:the-address\":\n" +
+				"  bad account nonce. Want: \"1002\". Have: \"1001\"")
+}
+
+func TestScenariosCheckMultipleErrContinue(t *testing.T) {
+	ScenariosTest(t).
+		Folder("scenarios-self-test/set-check").
+		File("set-check-multiple.err.json").
+		ContinueOnCheckFailure().
+		Run().
+		RequireError(
+			"2 check failure(s):\n" +
+				"  step 1 (checkState \"check-1\"): Check state \"check-1\": mismatch for account \"address:the-address\":\n" +
+				"    bad account nonce. Want: \"1002\". Have: \"1001\"\n" +
+				"  step 3 (checkState \"check-3\"): Check state \"check-3\": mismatch for account \"address:the-address\":\n" +
+				"    bad account balance. Want: \"2000\". Have: \"1000\"")
+}
+
+func TestScenariosCheckSeveralAccountsErr(t *testing.T) {
+	ScenariosTest(t).
+		Folder("scenarios-self-test/set-check").
+		File("set-check-several-accounts.err.json").
+		Run().
+		RequireError(
+			"Check state \"check-1\": unexpected account address: address:carol\n" +
+				"Check state \"check-1\": mismatch for account \"address:alice\":\n" +
+				"  bad account nonce. Want: \"5\". Have: \"1\"\n" +
+				"  bad storage value for key 0x6b65792d61 (str:key-a). Want: \"str:other-a\". Have: \"0x76616c75652d61 (str:value-a)\"\n" +
+				"Check state \"check-1\": mismatch for account \"address:bob\":\n" +
+				"  bad account balance. Want: \"300\". Have: \"200\"\n" +
+				"Check state \"check-1\": account address:dave expected but not found after running test")
 }
 
 func TestScenariosCheckOwnerErr1(t *testing.T) {
@@ -75,7 +169,8 @@ func TestScenariosCheckOwnerErr1(t *testing.T) {
 		File("set-check-owner.err1.json").
 		Run().
 		RequireError(
-			"Check state \"check-1\": bad account owner. Account: address:child. Want: \"address:other\". Have: \"address:parent\"")
+			"Check state \"check-1\": mismatch for account \"address:child\":\n" +
+				"  bad account owner. Want: \"address:other\". Have: \"address:parent\"")
 }
 
 func TestScenariosCheckOwnerErr2(t *testing.T) {
@@ -84,7 +179,8 @@ func TestScenariosCheckOwnerErr2(t *testing.T) {
 		File("set-check-owner.err2.json").
 		Run().
 		RequireError(
-			"Check state \"check-1\": bad account owner. Account: address:parent. Want: \"address:other\". Have: \"\"")
+			"Check state \"check-1\": mismatch for account \"address:parent\":\n" +
+				"  bad account owner. Want: \"address:other\". Have: \"\"")
 }
 
 func TestScenariosCheckBalanceErr(t *testing.T) {
@@ -93,7 +189,8 @@ func TestScenariosCheckBalanceErr(t *testing.T) {
 		File("set-check-balance.err.json").
 		Run().
 		RequireError(
-			"Check state \"check-1\": bad account balance. Account: address:the-address. Want: \"1,000,002\". Have: \"1000001\"")
+			"Check state \"check-1\": mismatch for account \"address:the-address\":\n" +
+				"  bad account balance. Want: \"1,000,002\". Have: \"1000001\"")
 }
 
 func TestScenariosCheckUsernameErr(t *testing.T) {
@@ -102,7 +199,8 @@ func TestScenariosCheckUsernameErr(t *testing.T) {
 		File("set-check-username.err.json").
 		Run().
 		RequireError(
-			"Check state \"check-1\": bad account username. Account: address:the-address. Want: \"str:wrong.domain\". Have: \"str:theusername.domain\"")
+			"Check state \"check-1\": mismatch for account \"address:the-address\":\n" +
+				"  bad account username. Want: \"str:wrong.domain\". Have: \"str:theusername.domain\"")
 }
 
 func TestScenariosCheckCodeErr(t *testing.T) {
@@ -111,7 +209,8 @@ func TestScenariosCheckCodeErr(t *testing.T) {
 		File("set-check-code.err.json").
 		Run().
 		RequireError(
-			"Check state \"check-1\": bad account code. Account: sc:contract-address. Want: \"file:set-check-code.scen.json\". Have: \"0x7b0a2020202022636f6d...\"")
+			"Check state \"check-1\": mismatch for account \"sc:contract-address\":\n" +
+				"  bad account code. Want: \"file:set-check-code.scen.json\". Have: \"0x7b0a2020202022636f6d...\"")
 }
 
 func TestScenariosCheckCodeMetadataErr(t *testing.T) {
@@ -120,7 +219,8 @@ func TestScenariosCheckCodeMetadataErr(t *testing.T) {
 		File("set-check-codemetadata.err.json").
 		Run().
 		RequireError(
-			"Check state \"check-1\": bad account code metadata. Account: sc:contract-address. Want: \"0x0000\". Have: \"0x0102\"")
+			"Check state \"check-1\": mismatch for account \"sc:contract-address\":\n" +
+				"  bad account code metadata. Want: \"0x0000\". Have: \"0x0102\"")
 }
 
 func TestScenariosCheckStorageErr1(t *testing.T) {
@@ -129,8 +229,8 @@ func TestScenariosCheckStorageErr1(t *testing.T) {
 		File("set-check-storage.err1.json").
 		Run().
 		RequireError(
-			"Check state \"check-1\": wrong account storage for account \"address:the-address\":\n" +
-				"  for key 0x6b65792d63 (str:key-c): Want: \"str:another-value\". Have: \"0x76616c75652d63 (str:value-c)\"")
+			"Check state \"check-1\": mismatch for account \"address:the-address\":\n" +
+				"  bad storage value for key 0x6b65792d63 (str:key-c). Want: \"str:another-value\". Have: \"0x76616c75652d63 (str:value-c)\"")
 }
 
 func TestScenariosCheckStorageErr2(t *testing.T) {
@@ -139,8 +239,8 @@ func TestScenariosCheckStorageErr2(t *testing.T) {
 		File("set-check-storage.err2.json").
 		Run().
 		RequireError(
-			"Check state \"check-1\": wrong account storage for account \"address:the-address\":\n" +
-				"  for key 0x6b65792d63 (str:key-c): Want: \"\". Have: \"0x76616c75652d63 (str:value-c)\"")
+			"Check state \"check-1\": mismatch for account \"address:the-address\":\n" +
+				"  bad storage value for key 0x6b65792d63 (str:key-c). Want: \"\". Have: \"0x76616c75652d63 (str:value-c)\"")
 }
 
 func TestScenariosCheckStorageErr3(t *testing.T) {
@@ -149,8 +249,8 @@ func TestScenariosCheckStorageErr3(t *testing.T) {
 		File("set-check-storage.err3.json").
 		Run().
 		RequireError(
-			"Check state \"check-1\": wrong account storage for account \"address:the-address\":\n" +
-				"  for key 0x6b65792d64 (str:key-d): Want: \"str:value-d\". Have: \"\"")
+			"Check state \"check-1\": mismatch for account \"address:the-address\":\n" +
+				"  bad storage value for key 0x6b65792d64 (str:key-d). Want: \"str:value-d\". Have: \"\"")
 }
 
 func TestScenariosCheckStorageErr4(t *testing.T) {
@@ -159,8 +259,8 @@ func TestScenariosCheckStorageErr4(t *testing.T) {
 		File("set-check-storage.err4.json").
 		Run().
 		RequireError(
-			"Check state \"check-1\": wrong account storage for account \"address:the-address\":\n" +
-				"  for key 0x6b65792d63 (str:key-c): Want: \"\". Have: \"0x76616c75652d63 (str:value-c)\"")
+			"Check state \"check-1\": mismatch for account \"address:the-address\":\n" +
+				"  bad storage value for key 0x6b65792d63 (str:key-c). Want: \"\". Have: \"0x76616c75652d63 (str:value-c)\"")
 }
 
 func TestScenariosCheckStorageErr5(t *testing.T) {
@@ -169,8 +269,8 @@ func TestScenariosCheckStorageErr5(t *testing.T) {
 		File("set-check-storage.err5.json").
 		Run().
 		RequireError(
-			"Check state \"check-1\": wrong account storage for account \"address:the-address\":\n" +
-				"  for key 0x6b65792d62 (str:key-b): Want: \"str:another-b\". Have: \"0x76616c75652d62 (str:value-b)\"")
+			"Check state \"check-1\": mismatch for account \"address:the-address\":\n" +
+				"  bad storage value for key 0x6b65792d62 (str:key-b). Want: \"str:another-b\". Have: \"0x76616c75652d62 (str:value-b)\"")
 }
 
 func TestScenariosCheckESDTErr1(t *testing.T) {
@@ -215,3 +315,19 @@ func TestScenariosTransferEsdtToNonexistingAccount(t *testing.T) {
 		Run().
 		CheckNoError()
 }
+
+func TestScenariosRestoreStateUnknownErr(t *testing.T) {
+	ScenariosTest(t).
+		Folder("scenarios-self-test/set-check").
+		File("restore-state-unknown.err.json").
+		Run().
+		RequireError("no state saved under the name not-saved")
+}
+
+func TestScenariosSaveRestoreESDT(t *testing.T) {
+	ScenariosTest(t).
+		Folder("scenarios-self-test").
+		File("save-restore-esdt.scen.json").
+		Run().
+		CheckNoError()
+}
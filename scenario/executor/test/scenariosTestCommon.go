@@ -25,13 +25,29 @@ func getTestRoot() string {
 	return vmTestRoot
 }
 
+// writeTempFiles writes files to a new temporary directory, by path relative to it, and yields the directory.
+// Used by the tests that write files while running, or that generate the scenarios they run.
+func writeTempFiles(t *testing.T, files map[string]string) string {
+	dirPath := t.TempDir()
+	for filePath, contents := range files {
+		fullPath := filepath.Join(dirPath, filePath)
+		require.Nil(t, os.MkdirAll(filepath.Dir(fullPath), 0755))
+		require.Nil(t, os.WriteFile(fullPath, []byte(contents), 0644))
+	}
+	return dirPath
+}
+
 // ScenariosTestBuilder defines the Scenarios builder component
 type ScenariosTestBuilder struct {
-	t            *testing.T
-	folder       string
-	singleFile   string
-	exclusions   []string
-	currentError error
+	t             *testing.T
+	folder        string
+	singleFile    string
+	exclusions    []string
+	parallelism   int
+	continueMode  bool
+	decodeWithABI bool
+	vmBuilder     scenexec.VMBuilder
+	currentError  error
 }
 
 // ScenariosTest will create a new ScenariosTestBuilder instance
@@ -40,6 +56,7 @@ func ScenariosTest(t *testing.T) *ScenariosTestBuilder {
 		t:          t,
 		folder:     "",
 		singleFile: "",
+		vmBuilder:  &DummyVMBuilder{},
 	}
 }
 
@@ -61,9 +78,33 @@ func (mtb *ScenariosTestBuilder) Exclude(path string) *ScenariosTestBuilder {
 	return mtb
 }
 
+// Parallel sets the number of scenarios to run concurrently
+func (mtb *ScenariosTestBuilder) Parallel(parallelism int) *ScenariosTestBuilder {
+	mtb.parallelism = parallelism
+	return mtb
+}
+
+// ContinueOnCheckFailure makes the scenarios report all failed checks
+func (mtb *ScenariosTestBuilder) ContinueOnCheckFailure() *ScenariosTestBuilder {
+	mtb.continueMode = true
+	return mtb
+}
+
+// DecodeWithABI makes check failures show the values decoded with the contract ABI
+func (mtb *ScenariosTestBuilder) DecodeWithABI() *ScenariosTestBuilder {
+	mtb.decodeWithABI = true
+	return mtb
+}
+
+// VM sets the builder of the VM stand-in, the DummyVM by default
+func (mtb *ScenariosTestBuilder) VM(vmBuilder scenexec.VMBuilder) *ScenariosTestBuilder {
+	mtb.vmBuilder = vmBuilder
+	return mtb
+}
+
 // Run will start the testing process
 func (mtb *ScenariosTestBuilder) Run() *ScenariosTestBuilder {
-	vmBuilder := &DummyVMBuilder{}
+	vmBuilder := mtb.vmBuilder
 	executor := scenexec.NewScenarioExecutor(vmBuilder)
 	defer executor.Close()
 
@@ -72,6 +113,13 @@ func (mtb *ScenariosTestBuilder) Run() *ScenariosTestBuilder {
 		scenio.NewDefaultFileResolver(),
 		vmBuilder.GetVMType(),
 	)
+	runner.RunnerFactory = func() scenio.ScenarioRunner {
+		return scenexec.NewScenarioExecutor(vmBuilder)
+	}
+	options := scenio.DefaultRunScenarioOptions()
+	options.Parallelism = mtb.parallelism
+	options.ContinueOnCheckFailure = mtb.continueMode
+	options.DecodeWithABI = mtb.decodeWithABI
 
 	if len(mtb.singleFile) > 0 {
 		fullPath := path.Join(getTestRoot(), mtb.folder)
@@ -79,14 +127,14 @@ func (mtb *ScenariosTestBuilder) Run() *ScenariosTestBuilder {
 
 		mtb.currentError = runner.RunSingleJSONScenario(
 			fullPath,
-			scenio.DefaultRunScenarioOptions())
+			options)
 	} else {
 		mtb.currentError = runner.RunAllJSONScenariosInDirectory(
 			getTestRoot(),
 			mtb.folder,
 			".scen.json",
 			mtb.exclusions,
-			scenio.DefaultRunScenarioOptions())
+			options)
 	}
 
 	return mtb
@@ -105,3 +153,9 @@ func (mtb *ScenariosTestBuilder) RequireError(expectedErrorMsg string) *Scenario
 	require.EqualError(mtb.t, mtb.currentError, expectedErrorMsg)
 	return mtb
 }
+
+// RequireErrorContains asserts that the error contains the expected message
+func (mtb *ScenariosTestBuilder) RequireErrorContains(expectedErrorMsg string) *ScenariosTestBuilder {
+	require.ErrorContains(mtb.t, mtb.currentError, expectedErrorMsg)
+	return mtb
+}
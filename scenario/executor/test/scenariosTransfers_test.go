@@ -0,0 +1,49 @@
+package executortest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	scenio "github.com/multiversx/mx-chain-scenario-go/scenario/io"
+	"github.com/stretchr/testify/require"
+)
+
+func runTransfers(t *testing.T, fileName string) *ScenariosTestBuilder {
+	return ScenariosTest(t).
+		Folder("scenarios-self-test/cross-shard-vm").
+		File(fileName).
+		VM(&CrossShardVMBuilder{}).
+		Run()
+}
+
+func TestTransfers(t *testing.T) {
+	runTransfers(t, "transfers.scen.json").CheckNoError()
+}
+
+func TestTransfersMismatch(t *testing.T) {
+	runTransfers(t, "transfers-value.err.json").RequireError(
+		`bad transfer. Tx 'send-tokens'. Destination: sc:near. Transfer index: 0. bad token value. Token index: 0. Want: "200". Have: "100"`)
+	runTransfers(t, "transfers-unexpected.err.json").RequireError(
+		`unexpected transfer. Tx 'send-tokens'. Destination: sc:near`)
+	runTransfers(t, "transfers-count.err.json").RequireError(
+		`wrong number of transfers. Tx 'async-call'. Destination: sc:far. Want: 0. Have: 1`)
+}
+
+func TestTransfersUpdateExpectations(t *testing.T) {
+	outdated, err := os.ReadFile(filepath.Join(
+		getTestRoot(), "scenarios-self-test", "cross-shard-vm", "transfers-unexpected.err.json"))
+	require.Nil(t, err)
+	scenarioPath := filepath.Join(writeTempFiles(t, map[string]string{
+		"transfers.scen.json": string(outdated),
+	}), "transfers.scen.json")
+
+	options := scenio.DefaultRunScenarioOptions()
+	options.UpdateExpectations = true
+	result := newCrossShardTestController().RunSingleJSONScenarioWithResult(scenarioPath, options)
+	require.Nil(t, result.Err)
+	require.Equal(t, 2, result.UpdatedSteps)
+
+	err = newCrossShardTestController().RunSingleJSONScenario(scenarioPath, scenio.DefaultRunScenarioOptions())
+	require.Nil(t, err)
+}
@@ -0,0 +1,35 @@
+package executortest
+
+import (
+	"testing"
+)
+
+func runTypedArguments(t *testing.T, fileName string) *ScenariosTestBuilder {
+	return ScenariosTest(t).
+		Folder("scenarios-self-test/echo-vm").
+		File(fileName).
+		VM(&EchoVMBuilder{}).
+		Run()
+}
+
+func TestTypedArgumentsNamed(t *testing.T) {
+	runTypedArguments(t, "typed-arguments-named.scen.json").CheckNoError()
+}
+
+func TestTypedArgumentsList(t *testing.T) {
+	runTypedArguments(t, "typed-arguments-list.scen.json").CheckNoError()
+
+	// standard types need no ABI
+	runTypedArguments(t, "typed-arguments-standard-type.scen.json").CheckNoError()
+}
+
+func TestTypedArgumentsErrors(t *testing.T) {
+	runTypedArguments(t, "typed-arguments-missing.err.json").RequireError(
+		"invalid arguments. Tx 'echo': missing argument point")
+	runTypedArguments(t, "typed-arguments-unknown.err.json").RequireError(
+		"invalid arguments. Tx 'echo': unknown argument other")
+	runTypedArguments(t, "typed-arguments-bad-value.err.json").RequireError(
+		`invalid arguments. Tx 'echo': argument point: Point.y: negative number "-5" for unsigned type`)
+	runTypedArguments(t, "typed-arguments-no-abi.err.json").RequireError(
+		`invalid arguments. Tx 'echo': named arguments need the contract ABI, with the endpoint "echoPoint"`)
+}
@@ -0,0 +1,196 @@
+package executortest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	scenexec "github.com/multiversx/mx-chain-scenario-go/scenario/executor"
+	scenio "github.com/multiversx/mx-chain-scenario-go/scenario/io"
+	"github.com/stretchr/testify/require"
+)
+
+const outdatedExpectationsScenario = `{
+    "name": "outdated expectations",
+    "steps": [
+        {
+            "step": "setState",
+            "accounts": {
+                "address:A": {
+                    "nonce": "0",
+                    "balance": "150"
+                },
+                "sc:echo": {
+                    "nonce": "0",
+                    "balance": "0",
+                    "code": "0x0100"
+                }
+            }
+        },
+        {
+            "step": "scCall",
+            "id": "1",
+            "tx": {
+                "from": "address:A",
+                "to": "sc:echo",
+                "function": "echo",
+                "arguments": [
+                    "5",
+                    "str:hi"
+                ],
+                "gasLimit": "1000",
+                "gasPrice": "0"
+            },
+            "expect": {
+                "out": [
+                    "1"
+                ],
+                "status": "4",
+                "message": "*",
+                "logs": [],
+                "gas": "500"
+            }
+        },
+        {
+            "step": "checkState",
+            "id": "check-1",
+            "accounts": {
+                "address:A": {
+                    "nonce": "*",
+                    "balance": "70",
+                    "storage": {},
+                    "code": ""
+                },
+                "address:C": {
+                    "nonce": "0"
+                }
+            }
+        }
+    ]
+}
+`
+
+const updatedExpectationsScenario = `{
+    "name": "outdated expectations",
+    "steps": [
+        {
+            "step": "setState",
+            "accounts": {
+                "address:A": {
+                    "nonce": "0",
+                    "balance": "150"
+                },
+                "sc:echo": {
+                    "nonce": "0",
+                    "balance": "0",
+                    "code": "0x0100"
+                }
+            }
+        },
+        {
+            "step": "scCall",
+            "id": "1",
+            "tx": {
+                "from": "address:A",
+                "to": "sc:echo",
+                "function": "echo",
+                "arguments": [
+                    "5",
+                    "str:hi"
+                ],
+                "gasLimit": "1000",
+                "gasPrice": "0"
+            },
+            "expect": {
+                "out": [
+                    "5",
+                    "str:hi"
+                ],
+                "status": "0",
+                "message": "*",
+                "logs": [
+                    {
+                        "address": "sc:echo",
+                        "endpoint": "str:echo",
+                        "topics": [
+                            "5",
+                            "str:hi"
+                        ],
+                        "data": []
+                    }
+                ],
+                "gas": "900"
+            }
+        },
+        {
+            "step": "checkState",
+            "id": "check-1",
+            "accounts": {
+                "address:A": {
+                    "nonce": "*",
+                    "balance": "150",
+                    "storage": {},
+                    "code": ""
+                },
+                "sc:echo": {
+                    "nonce": "0",
+                    "balance": "0",
+                    "code": "0x0100",
+                    "codeMetadata": "0x0506"
+                }
+            }
+        }
+    ]
+}
+`
+
+func newEchoTestController() *scenio.ScenarioController {
+	vmBuilder := &EchoVMBuilder{}
+	return scenio.NewScenarioController(
+		scenexec.NewScenarioExecutor(vmBuilder),
+		scenio.NewDefaultFileResolver(),
+		vmBuilder.GetVMType(),
+	)
+}
+
+func TestUpdateExpectations(t *testing.T) {
+	scenarioPath := filepath.Join(t.TempDir(), "outdated.scen.json")
+	require.Nil(t, os.WriteFile(scenarioPath, []byte(outdatedExpectationsScenario), 0644))
+
+	controller := newEchoTestController()
+	err := controller.RunSingleJSONScenario(scenarioPath, scenio.DefaultRunScenarioOptions())
+	require.NotNil(t, err)
+
+	options := scenio.DefaultRunScenarioOptions()
+	options.UpdateExpectations = true
+	result := newEchoTestController().RunSingleJSONScenarioWithResult(scenarioPath, options)
+	require.Nil(t, result.Err)
+	require.Equal(t, 2, result.UpdatedSteps)
+
+	updated, err := os.ReadFile(scenarioPath)
+	require.Nil(t, err)
+	require.Equal(t, updatedExpectationsScenario, string(updated))
+
+	err = newEchoTestController().RunSingleJSONScenario(scenarioPath, scenio.DefaultRunScenarioOptions())
+	require.Nil(t, err)
+}
+
+func TestUpdateExpectationsUpToDate(t *testing.T) {
+	original, err := os.ReadFile(filepath.Join(getTestRoot(), "scenarios-self-test", "transfer-egld.scen.json"))
+	require.Nil(t, err)
+	scenarioPath := filepath.Join(t.TempDir(), "transfer-egld.scen.json")
+	require.Nil(t, os.WriteFile(scenarioPath, original, 0644))
+
+	executor := scenexec.NewScenarioExecutor(&DummyVMBuilder{})
+	controller := scenio.NewScenarioController(executor, scenio.NewDefaultFileResolver(), scenexec.TestVMType)
+	options := scenio.DefaultRunScenarioOptions()
+	options.UpdateExpectations = true
+	result := controller.RunSingleJSONScenarioWithResult(scenarioPath, options)
+	require.Nil(t, result.Err)
+	require.Equal(t, 0, result.UpdatedSteps)
+	require.Empty(t, executor.ExpectationUpdates())
+
+	unchanged, err := os.ReadFile(scenarioPath)
+	require.Nil(t, err)
+	require.Equal(t, string(original), string(unchanged))
+}
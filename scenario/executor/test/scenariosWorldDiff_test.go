@@ -0,0 +1,52 @@
+package executortest
+
+import (
+	"path/filepath"
+	"testing"
+
+	scenexec "github.com/multiversx/mx-chain-scenario-go/scenario/executor"
+	er "github.com/multiversx/mx-chain-scenario-go/scenario/expression/reconstructor"
+	scenio "github.com/multiversx/mx-chain-scenario-go/scenario/io"
+	worldmock "github.com/multiversx/mx-chain-scenario-go/worldmock"
+	"github.com/stretchr/testify/require"
+)
+
+const expectedWorldDiff = `sc:contract
+  storage str:new: (none) -> str:abc
+  storage str:old: 1 -> (none)
+  storage str:sum: 5 -> 6
+address:A
+  nonce: 0 -> 1
+  balance: 150 -> 50
+  esdt str:NFT-123456 nonce 1 attributes: str:rare -> str:common
+  esdt str:TOK-123456: 20 -> 10
+address:B
+  balance: 0 -> 100
+  esdt str:TOK-123456: 0 -> 10
+address:C (created)
+  balance: 0 -> 5
+address:D (deleted)
+`
+
+func runWorldDiffScenario(t *testing.T, fileName string) worldmock.AccountMap {
+	scenarioPath := filepath.Join(getTestRoot(), "scenarios-self-test", "world-diff", fileName)
+	vmBuilder := &DummyVMBuilder{}
+	executor := scenexec.NewScenarioExecutor(vmBuilder)
+	controller := scenio.NewScenarioController(executor, scenio.NewDefaultFileResolver(), vmBuilder.GetVMType())
+	err := controller.RunSingleJSONScenario(scenarioPath, scenio.DefaultRunScenarioOptions())
+	require.Nil(t, err)
+	return executor.World.AcctMap
+}
+
+func TestWorldDiff(t *testing.T) {
+	before := runWorldDiffScenario(t, "before.scen.json")
+	after := runWorldDiffScenario(t, "after.scen.json")
+
+	diff, err := worldmock.DiffAccountMaps(before, after)
+	require.Nil(t, err)
+	require.Equal(t, expectedWorldDiff, scenexec.WorldDiffString(diff, er.ExprReconstructor{}))
+
+	diff, err = worldmock.DiffAccountMaps(after, after.Clone())
+	require.Nil(t, err)
+	require.True(t, diff.IsEmpty())
+}
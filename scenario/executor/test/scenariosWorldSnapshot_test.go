@@ -0,0 +1,193 @@
+package executortest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	scenexec "github.com/multiversx/mx-chain-scenario-go/scenario/executor"
+	scenio "github.com/multiversx/mx-chain-scenario-go/scenario/io"
+	"github.com/stretchr/testify/require"
+)
+
+const worldSetupScenario = `{
+    "steps": [
+        {
+            "step": "setState",
+            "accounts": {
+                "address:A": {
+                    "nonce": "3",
+                    "balance": "1000",
+                    "esdt": {
+                        "str:TOK-123456": "150",
+                        "str:NFT-123456": {
+                            "instances": [
+                                {
+                                    "nonce": "1",
+                                    "balance": "1",
+                                    "attributes": "str:attr"
+                                }
+                            ]
+                        }
+                    },
+                    "username": "str:a.elrond"
+                },
+                "sc:contract": {
+                    "nonce": "0",
+                    "balance": "0",
+                    "storage": {
+                        "str:sum": "5"
+                    },
+                    "code": "0x0100",
+                    "owner": "address:A"
+                }
+            },
+            "newAddresses": [
+                {
+                    "creatorAddress": "address:A",
+                    "creatorNonce": "3",
+                    "newAddress": "sc:new"
+                }
+            ],
+            "previousBlockInfo": {
+                "blockNonce": "222",
+                "blockRandomSeed": "0x42BA9AE77C08604DD7EB9D209488B88DD5A301D9C9F3D4A6B4B40E95AA6F4A1E20519698D3F774052F475B6877449CF3"
+            },
+            "currentBlockInfo": {
+                "blockTimestampMs": "511000",
+                "blockNonce": "522",
+                "blockRound": "533",
+                "blockEpoch": "544"
+            },
+            "blockHashes": [
+                "0x24a30e4305ac41674b26493c800c05f507e98d3b8bceb0a314f9b9bc43622736"
+            ]
+        }
+    ]
+}
+`
+
+const worldCheckScenario = `{
+    "steps": [
+        {
+            "step": "checkState",
+            "accounts": {
+                "address:A": {
+                    "nonce": "3",
+                    "balance": "1000",
+                    "esdt": {
+                        "str:TOK-123456": "150",
+                        "str:NFT-123456": {
+                            "instances": [
+                                {
+                                    "nonce": "1",
+                                    "balance": "1",
+                                    "attributes": "str:attr"
+                                }
+                            ]
+                        }
+                    },
+                    "username": "str:a.elrond"
+                },
+                "sc:contract": {
+                    "nonce": "0",
+                    "balance": "0",
+                    "storage": {
+                        "str:sum": "5"
+                    },
+                    "code": "0x0100",
+                    "owner": "address:A"
+                },
+                "+": ""
+            }
+        }
+    ]
+}
+`
+
+func TestWorldSnapshotSaveLoad(t *testing.T) {
+	tempDir := t.TempDir()
+	setupPath := filepath.Join(tempDir, "setup.scen.json")
+	checkPath := filepath.Join(tempDir, "check.scen.json")
+	snapshotPath := filepath.Join(tempDir, "snapshots", "world.json")
+	require.Nil(t, os.WriteFile(setupPath, []byte(worldSetupScenario), 0644))
+	require.Nil(t, os.WriteFile(checkPath, []byte(worldCheckScenario), 0644))
+
+	setupExecutor := scenexec.NewScenarioExecutor(&DummyVMBuilder{})
+	setupController := scenio.NewScenarioController(setupExecutor, scenio.NewDefaultFileResolver(), scenexec.TestVMType)
+	options := scenio.DefaultRunScenarioOptions()
+	options.SaveWorldFile = snapshotPath
+	require.Nil(t, setupController.RunSingleJSONScenario(setupPath, options))
+
+	loadedExecutor := scenexec.NewScenarioExecutor(&DummyVMBuilder{})
+	require.Nil(t, loadedExecutor.LoadWorldSnapshotFile(snapshotPath))
+	require.Equal(t, setupExecutor.World.ToWorldSnapshot(), loadedExecutor.World.ToWorldSnapshot())
+	require.Equal(t, setupExecutor.World.CurrentBlockInfo, loadedExecutor.World.CurrentBlockInfo)
+	require.Equal(t, setupExecutor.World.PreviousBlockInfo, loadedExecutor.World.PreviousBlockInfo)
+	require.Equal(t, setupExecutor.World.NewAddressMocks, loadedExecutor.World.NewAddressMocks)
+
+	options = scenio.DefaultRunScenarioOptions()
+	options.LoadWorldFile = snapshotPath
+	err := newTestController().RunSingleJSONScenario(checkPath, options)
+	require.Nil(t, err)
+
+	err = newTestController().RunSingleJSONScenario(checkPath, scenio.DefaultRunScenarioOptions())
+	require.NotNil(t, err)
+}
+
+func TestWorldSnapshotBadFile(t *testing.T) {
+	snapshotPath := filepath.Join(t.TempDir(), "world.json")
+	require.Nil(t, os.WriteFile(snapshotPath, []byte(`{"version": 99}`), 0644))
+
+	executor := scenexec.NewScenarioExecutor(&DummyVMBuilder{})
+	err := executor.LoadWorldSnapshotFile(snapshotPath)
+	require.ErrorContains(t, err, "unsupported world snapshot version 99")
+}
+
+func TestWorldSnapshotLoadKeepsSelfShard(t *testing.T) {
+	tempDir := t.TempDir()
+	setupPath := filepath.Join(tempDir, "setup.scen.json")
+	checkPath := filepath.Join(tempDir, "check.scen.json")
+	snapshotPath := filepath.Join(tempDir, "world.json")
+	require.Nil(t, os.WriteFile(setupPath, []byte(worldSetupScenario), 0644))
+	require.Nil(t, os.WriteFile(checkPath, []byte(worldCheckScenario), 0644))
+
+	setupExecutor := scenexec.NewScenarioExecutor(&DummyVMBuilder{})
+	setupController := scenio.NewScenarioController(setupExecutor, scenio.NewDefaultFileResolver(), scenexec.TestVMType)
+	require.Nil(t, setupController.RunSingleJSONScenario(setupPath, scenio.DefaultRunScenarioOptions()))
+	setupExecutor.World.SelfShardID = 2
+	require.Nil(t, setupExecutor.SaveWorldSnapshotFile(snapshotPath))
+
+	executor := scenexec.NewScenarioExecutor(&DummyVMBuilder{})
+	controller := scenio.NewScenarioController(executor, scenio.NewDefaultFileResolver(), scenexec.TestVMType)
+	options := scenio.DefaultRunScenarioOptions()
+	options.LoadWorldFile = snapshotPath
+	require.Nil(t, controller.RunSingleJSONScenario(checkPath, options))
+	require.Equal(t, uint32(2), executor.World.SelfShardID)
+}
+
+func TestWorldSnapshotDirectoryRunErr(t *testing.T) {
+	snapshotPath := filepath.Join(t.TempDir(), "world.json")
+
+	options := scenio.DefaultRunScenarioOptions()
+	options.SaveWorldFile = snapshotPath
+	err := newTestController().RunAllJSONScenariosInDirectory(
+		getTestRoot(),
+		"scenarios-self-test/set-check",
+		".scen.json",
+		[]string{},
+		options)
+	require.EqualError(t, err, "world snapshots can only be saved when running a single scenario")
+	require.NoFileExists(t, snapshotPath)
+
+	options = scenio.DefaultRunScenarioOptions()
+	options.LoadWorldFile = snapshotPath
+	options.Parallelism = 4
+	err = newTestController().RunAllJSONScenariosInDirectory(
+		getTestRoot(),
+		"scenarios-self-test/set-check",
+		".scen.json",
+		[]string{},
+		options)
+	require.EqualError(t, err, "world snapshots cannot be loaded in parallel scenario runs")
+}
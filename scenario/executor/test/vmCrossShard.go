@@ -0,0 +1,206 @@
+package executortest
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strconv"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/data/vm"
+	scenarioexec "github.com/multiversx/mx-chain-scenario-go/scenario/executor"
+	worldmock "github.com/multiversx/mx-chain-scenario-go/worldmock"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+// crossShardGasCost is the gas consumed by every call to the CrossShardVM.
+const crossShardGasCost = 100
+
+// crossShardGasLocked is the gas an asynchronous call of the CrossShardVM keeps for its callback.
+const crossShardGasLocked = 1000
+
+var _ scenarioexec.VMInterface = (*CrossShardVM)(nil)
+var _ scenarioexec.VMBuilder = (*CrossShardVMBuilder)(nil)
+
+// CrossShardVM is a VM stand-in for testing cross-shard flows. Every contract has the same functions:
+//   - "call" calls a function of another contract, synchronously in the same shard, asynchronously otherwise;
+//   - "ping" returns "pong";
+//   - "fail" always fails;
+//   - "callBack" saves the arguments it receives;
+//   - "sendTokens" sends tokens to another account, optionally calling one of its functions.
+//
+// Each function writes to storage what it did.
+type CrossShardVM struct {
+	DummyVM
+	world *worldmock.MockWorld
+}
+
+// RunSmartContractCreate -
+func (*CrossShardVM) RunSmartContractCreate(input *vmcommon.ContractCreateInput) (*vmcommon.VMOutput, error) {
+	return nil, errors.New("cannot deploy on the CrossShardVM")
+}
+
+// RunSmartContractCall runs one of the functions of the CrossShardVM contracts.
+func (csvm *CrossShardVM) RunSmartContractCall(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	if input.GasProvided < crossShardGasCost {
+		return failedCrossShardOutput(vmcommon.OutOfGas, "not enough gas"), nil
+	}
+
+	output := &vmcommon.VMOutput{
+		ReturnData:     make([][]byte, 0),
+		ReturnCode:     vmcommon.Ok,
+		GasRemaining:   input.GasProvided - crossShardGasCost,
+		GasRefund:      big.NewInt(0),
+		OutputAccounts: make(map[string]*vmcommon.OutputAccount),
+	}
+	contract := crossShardOutputAccount(output, input.RecipientAddr)
+	contract.BalanceDelta = input.CallValue
+
+	switch input.Function {
+	case "call":
+		if len(input.Arguments) < 2 {
+			return failedCrossShardOutput(vmcommon.UserError, "wrong number of arguments"), nil
+		}
+		return csvm.call(input, output)
+	case "ping":
+		output.ReturnData = [][]byte{[]byte("pong")}
+		setCrossShardStorage(contract, "pinged", []byte("yes"))
+	case "fail":
+		return failedCrossShardOutput(vmcommon.UserError, "ping failed"), nil
+	case "sendTokens":
+		if len(input.Arguments) < 3 {
+			return failedCrossShardOutput(vmcommon.UserError, "wrong number of arguments"), nil
+		}
+		sendTokens(input, output)
+	case "callBack":
+		for i, argument := range input.Arguments {
+			setCrossShardStorage(contract, "callback-arg-"+strconv.Itoa(i), argument)
+		}
+	default:
+		return failedCrossShardOutput(vmcommon.FunctionNotFound, "invalid function (not found)"), nil
+	}
+
+	return output, nil
+}
+
+// call runs the other contract right away if it is in the same shard, or sends it an asynchronous call otherwise.
+func (csvm *CrossShardVM) call(input *vmcommon.ContractCallInput, output *vmcommon.VMOutput) (*vmcommon.VMOutput, error) {
+	destination := input.Arguments[0]
+	function := string(input.Arguments[1])
+	arguments := input.Arguments[2:]
+	contract := crossShardOutputAccount(output, input.RecipientAddr)
+
+	if csvm.world.ComputeId(destination) == csvm.world.SelfId() {
+		setCrossShardStorage(contract, "call-mode", []byte("sync"))
+		innerOutput, err := csvm.RunSmartContractCall(&vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallerAddr:  input.RecipientAddr,
+				Arguments:   arguments,
+				CallValue:   big.NewInt(0),
+				CallType:    vm.DirectCall,
+				GasProvided: output.GasRemaining,
+			},
+			RecipientAddr: destination,
+			Function:      function,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if innerOutput.ReturnCode != vmcommon.Ok {
+			return innerOutput, nil
+		}
+		for address, innerAccount := range innerOutput.OutputAccounts {
+			if address == string(input.RecipientAddr) {
+				continue
+			}
+			output.OutputAccounts[address] = innerAccount
+		}
+		output.ReturnData = innerOutput.ReturnData
+		output.GasRemaining = innerOutput.GasRemaining
+		return output, nil
+	}
+
+	setCrossShardStorage(contract, "call-mode", []byte("async"))
+	data := function
+	for _, argument := range arguments {
+		data += "@" + hex.EncodeToString(argument)
+	}
+	gasLimit := output.GasRemaining / 2
+	destinationAccount := crossShardOutputAccount(output, destination)
+	destinationAccount.OutputTransfers = append(destinationAccount.OutputTransfers, vmcommon.OutputTransfer{
+		Value:         big.NewInt(0),
+		GasLimit:      gasLimit,
+		GasLocked:     crossShardGasLocked,
+		Data:          []byte(data),
+		CallType:      vm.AsynchronousCall,
+		SenderAddress: input.RecipientAddr,
+	})
+	output.GasRemaining -= gasLimit
+	return output, nil
+}
+
+// sendTokens transfers an amount of a fungible token, with the arguments: destination, token, amount,
+// then optionally the function to call and its arguments.
+func sendTokens(input *vmcommon.ContractCallInput, output *vmcommon.VMOutput) {
+	destination := input.Arguments[0]
+	data := core.BuiltInFunctionESDTTransfer +
+		"@" + hex.EncodeToString(input.Arguments[1]) +
+		"@" + hex.EncodeToString(input.Arguments[2])
+	gasLimit := uint64(0)
+	if len(input.Arguments) > 3 {
+		data += "@" + hex.EncodeToString(input.Arguments[3])
+		for _, argument := range input.Arguments[4:] {
+			data += "@" + hex.EncodeToString(argument)
+		}
+		gasLimit = output.GasRemaining / 2
+	}
+
+	destinationAccount := crossShardOutputAccount(output, destination)
+	destinationAccount.OutputTransfers = append(destinationAccount.OutputTransfers, vmcommon.OutputTransfer{
+		Value:         big.NewInt(0),
+		GasLimit:      gasLimit,
+		Data:          []byte(data),
+		CallType:      vm.DirectCall,
+		SenderAddress: input.RecipientAddr,
+	})
+	output.GasRemaining -= gasLimit
+}
+
+func crossShardOutputAccount(output *vmcommon.VMOutput, address []byte) *vmcommon.OutputAccount {
+	account, found := output.OutputAccounts[string(address)]
+	if !found {
+		account = &vmcommon.OutputAccount{
+			Address:        address,
+			BalanceDelta:   big.NewInt(0),
+			StorageUpdates: make(map[string]*vmcommon.StorageUpdate),
+		}
+		output.OutputAccounts[string(address)] = account
+	}
+	return account
+}
+
+func setCrossShardStorage(account *vmcommon.OutputAccount, key string, value []byte) {
+	account.StorageUpdates[key] = &vmcommon.StorageUpdate{
+		Offset: []byte(key),
+		Data:   value,
+	}
+}
+
+func failedCrossShardOutput(returnCode vmcommon.ReturnCode, message string) *vmcommon.VMOutput {
+	return &vmcommon.VMOutput{
+		ReturnCode:    returnCode,
+		ReturnMessage: message,
+		GasRefund:     big.NewInt(0),
+	}
+}
+
+// CrossShardVMBuilder is the builder for a CrossShardVM.
+type CrossShardVMBuilder struct {
+	DummyVMBuilder
+}
+
+// NewVM creates a CrossShardVM.
+func (*CrossShardVMBuilder) NewVM(world *worldmock.MockWorld, gasSchedule map[string]map[string]uint64) (scenarioexec.VMInterface, error) {
+	return &CrossShardVM{world: world}, nil
+}
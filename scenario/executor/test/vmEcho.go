@@ -0,0 +1,78 @@
+package executortest
+
+import (
+	"errors"
+	"math/big"
+
+	scenarioexec "github.com/multiversx/mx-chain-scenario-go/scenario/executor"
+	worldmock "github.com/multiversx/mx-chain-scenario-go/worldmock"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+// echoGasCost is the gas consumed by every call to the EchoVM.
+const echoGasCost = 100
+
+// echoEventsFunction is the EchoVM function that logs every argument separately, as an event named after it.
+const echoEventsFunction = "emitEvents"
+
+var _ scenarioexec.VMInterface = (*EchoVM)(nil)
+var _ scenarioexec.VMBuilder = (*EchoVMBuilder)(nil)
+
+// EchoVM is a VM stand-in that returns the arguments of every call and logs them under the function name.
+// Used for tests that need transaction outputs, without real contracts.
+type EchoVM struct {
+	DummyVM
+}
+
+// RunSmartContractCreate -
+func (*EchoVM) RunSmartContractCreate(input *vmcommon.ContractCreateInput) (*vmcommon.VMOutput, error) {
+	return nil, errors.New("cannot deploy on the EchoVM")
+}
+
+// RunSmartContractCall yields the arguments as return data, and a log with the function name and arguments.
+// The emitEvents function instead logs one event per argument.
+func (*EchoVM) RunSmartContractCall(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	if input.GasProvided < echoGasCost {
+		return &vmcommon.VMOutput{
+			ReturnCode:    vmcommon.OutOfGas,
+			ReturnMessage: "not enough gas",
+			GasRefund:     big.NewInt(0),
+		}, nil
+	}
+
+	logs := []*vmcommon.LogEntry{
+		{
+			Identifier: []byte(input.Function),
+			Address:    input.RecipientAddr,
+			Topics:     input.Arguments,
+		},
+	}
+	if input.Function == echoEventsFunction {
+		logs = make([]*vmcommon.LogEntry, 0, len(input.Arguments))
+		for _, argument := range input.Arguments {
+			logs = append(logs, &vmcommon.LogEntry{
+				Identifier: argument,
+				Address:    input.RecipientAddr,
+			})
+		}
+	}
+
+	return &vmcommon.VMOutput{
+		ReturnData:   input.Arguments,
+		ReturnCode:   vmcommon.Ok,
+		GasRemaining: input.GasProvided - echoGasCost,
+		GasRefund:    big.NewInt(0),
+		Logs:         logs,
+	}, nil
+}
+
+// EchoVMBuilder is the builder for an EchoVM.
+type EchoVMBuilder struct {
+	DummyVMBuilder
+}
+
+// NewVM creates an EchoVM.
+func (*EchoVMBuilder) NewVM(world *worldmock.MockWorld, gasSchedule map[string]map[string]uint64) (scenarioexec.VMInterface, error) {
+	return &EchoVM{}, nil
+}
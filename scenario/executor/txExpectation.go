@@ -0,0 +1,126 @@
+package scenexec
+
+import (
+	"math/big"
+
+	er "github.com/multiversx/mx-chain-scenario-go/scenario/expression/reconstructor"
+	scenmodel "github.com/multiversx/mx-chain-scenario-go/scenario/model"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+// txResultFromOutput builds the expect block that the VM output of a transaction matches exactly.
+// All values are written as expressions that scenario files can parse back.
+func (ae *ScenarioExecutor) txResultFromOutput(output *vmcommon.VMOutput) *scenmodel.TransactionResult {
+	out := scenmodel.JSONCheckValueList{}
+	for _, returnData := range output.ReturnData {
+		out.Values = append(out.Values, ae.checkBytesExpression(returnData, er.NoHint))
+	}
+
+	message := scenmodel.JSONCheckBytesUnspecified()
+	if len(output.ReturnMessage) > 0 {
+		message = ae.checkBytesExpression([]byte(output.ReturnMessage), er.StrHint)
+	}
+
+	refund := output.GasRefund
+	if refund == nil {
+		refund = big.NewInt(0)
+	}
+
+	return &scenmodel.TransactionResult{
+		Out: out,
+		Status: scenmodel.JSONCheckBigInt{
+			Value:    big.NewInt(int64(output.ReturnCode)),
+			Original: ae.exprReconstructor.ReconstructFromUint64(uint64(output.ReturnCode)),
+		},
+		Message: message,
+		Gas: scenmodel.JSONCheckUint64{
+			Value:    output.GasRemaining,
+			Original: ae.exprReconstructor.ReconstructFromUint64(output.GasRemaining),
+		},
+		Refund: scenmodel.JSONCheckBigInt{
+			Value:    refund,
+			Original: ae.exprReconstructor.ReconstructFromBigInt(refund),
+		},
+		Logs: ae.logListFromOutput(output.Logs),
+
+		// transfers are only written out when an expectation lists them, see transfersFromOutput
+		Transfers: scenmodel.CheckTransfersUnspecified(),
+	}
+}
+
+func (ae *ScenarioExecutor) logListFromOutput(outputLogs []*vmcommon.LogEntry) scenmodel.LogList {
+	logList := scenmodel.LogList{}
+	for _, outputLog := range outputLogs {
+		logEntry := &scenmodel.LogEntry{
+			Address:  ae.checkBytesExpression(outputLog.Address, er.AddressHint),
+			Endpoint: ae.checkBytesExpression(outputLog.Identifier, er.StrHint),
+		}
+		for _, topic := range outputLog.Topics {
+			logEntry.Topics.Values = append(logEntry.Topics.Values, ae.checkBytesExpression(topic, er.NoHint))
+		}
+		for _, data := range outputLog.Data {
+			logEntry.Data.Values = append(logEntry.Data.Values, ae.checkBytesExpression(data, er.NoHint))
+		}
+		logList.List = append(logList.List, logEntry)
+	}
+	return logList
+}
+
+func (ae *ScenarioExecutor) checkBytesExpression(value []byte, hint er.ExprReconstructorHint) scenmodel.JSONCheckBytes {
+	return scenmodel.JSONCheckBytesReconstructed(value, ae.exprReconstructor.ReconstructExpression(value, hint))
+}
+
+// transfersFromOutput yields the transfers of a transaction as an exact expectation.
+func (ae *ScenarioExecutor) transfersFromOutput(output *vmcommon.VMOutput) scenmodel.CheckTransfers {
+	result := scenmodel.CheckTransfers{}
+	destinations := make(map[string]*scenmodel.CheckTransferDestination)
+	for _, transfer := range sentTransfers(output) {
+		destination, found := destinations[string(transfer.to)]
+		if !found {
+			destination = &scenmodel.CheckTransferDestination{
+				To: scenmodel.NewJSONBytesFromString(transfer.to, ae.exprReconstructor.ReconstructExpression(transfer.to, er.AddressHint)),
+			}
+			destinations[string(transfer.to)] = destination
+			result.Destinations = append(result.Destinations, destination)
+		}
+
+		checkTransfer := &scenmodel.CheckTransfer{
+			From: ae.checkBytesExpression(transfer.from, er.AddressHint),
+			EGLDValue: scenmodel.JSONCheckBigInt{
+				Value:    transfer.egldValue,
+				Original: ae.exprReconstructor.ReconstructFromBigInt(transfer.egldValue),
+			},
+			ESDTValue: make([]*scenmodel.CheckESDTTransfer, 0, len(transfer.esdtTransfers)),
+			Function:  ae.checkBytesExpression([]byte(transfer.function), er.StrHint),
+			CallType:  transfer.callType.ToString(),
+			GasLimit: scenmodel.JSONCheckUint64{
+				Value:    transfer.gasLimit,
+				Original: ae.exprReconstructor.ReconstructFromUint64(transfer.gasLimit),
+			},
+			GasLocked: scenmodel.JSONCheckUint64{
+				Value:    transfer.gasLocked,
+				Original: ae.exprReconstructor.ReconstructFromUint64(transfer.gasLocked),
+			},
+		}
+		for _, esdtTransfer := range transfer.esdtTransfers {
+			checkTransfer.ESDTValue = append(checkTransfer.ESDTValue, &scenmodel.CheckESDTTransfer{
+				TokenIdentifier: scenmodel.NewJSONBytesFromString(
+					esdtTransfer.ESDTTokenName,
+					ae.exprReconstructor.ReconstructExpression(esdtTransfer.ESDTTokenName, er.StrHint)),
+				Nonce: scenmodel.JSONCheckUint64{
+					Value:    esdtTransfer.ESDTTokenNonce,
+					Original: ae.exprReconstructor.ReconstructFromUint64(esdtTransfer.ESDTTokenNonce),
+				},
+				Value: scenmodel.JSONCheckBigInt{
+					Value:    esdtTransfer.ESDTValue,
+					Original: ae.exprReconstructor.ReconstructFromBigInt(esdtTransfer.ESDTValue),
+				},
+			})
+		}
+		for _, argument := range transfer.arguments {
+			checkTransfer.Arguments.Values = append(checkTransfer.Arguments.Values, ae.checkBytesExpression(argument, er.NoHint))
+		}
+		destination.Transfers = append(destination.Transfers, checkTransfer)
+	}
+	return result
+}
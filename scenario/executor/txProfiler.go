@@ -0,0 +1,59 @@
+package scenexec
+
+import (
+	"time"
+
+	er "github.com/multiversx/mx-chain-scenario-go/scenario/expression/reconstructor"
+	scenio "github.com/multiversx/mx-chain-scenario-go/scenario/io"
+	scenmodel "github.com/multiversx/mx-chain-scenario-go/scenario/model"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+var _ scenio.TxProfiler = (*ScenarioExecutor)(nil)
+
+// TxProfiles yields the time and gas of all transactions executed in the last scenario, including external steps.
+func (ae *ScenarioExecutor) TxProfiles() []*scenio.TxProfile {
+	return ae.txProfiles
+}
+
+func (ae *ScenarioExecutor) recordTxProfile(
+	step *scenmodel.TxStep,
+	duration time.Duration,
+	output *vmcommon.VMOutput) {
+
+	profile := &scenio.TxProfile{
+		StepID:    step.TxIdent,
+		StepType:  step.StepTypeName(),
+		StepIndex: ae.currentStepIndex,
+		Duration:  duration,
+	}
+	// simple transfers run no code, so they use no gas, even though their output has no gas remaining
+	if !isSimpleTransfer(step.Tx) && step.Tx.GasLimit.Value > output.GasRemaining {
+		profile.GasUsed = step.Tx.GasLimit.Value - output.GasRemaining
+	}
+
+	switch step.Tx.Type {
+	case scenmodel.ScDeploy:
+		profile.Contract = ae.deployedContract(output)
+		profile.Endpoint = "init"
+	case scenmodel.ScCall, scenmodel.ScQuery:
+		profile.Contract = ae.exprReconstructor.Reconstruct(step.Tx.To.Value, er.AddressHint)
+		profile.Endpoint = step.Tx.Function
+	}
+
+	ae.txProfiles = append(ae.txProfiles, profile)
+}
+
+func isSimpleTransfer(tx *scenmodel.Transaction) bool {
+	return tx.Type == scenmodel.Transfer && len(tx.ESDTValue) == 0
+}
+
+// deployedContract finds the new contract among the output accounts, as the only one receiving code.
+func (ae *ScenarioExecutor) deployedContract(output *vmcommon.VMOutput) string {
+	for _, outputAccount := range output.OutputAccounts {
+		if len(outputAccount.Code) > 0 {
+			return ae.exprReconstructor.Reconstruct(outputAccount.Address, er.AddressHint)
+		}
+	}
+	return ""
+}
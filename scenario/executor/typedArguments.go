@@ -0,0 +1,113 @@
+package scenexec
+
+import (
+	"fmt"
+
+	oj "github.com/multiversx/mx-chain-scenario-go/orderedjson"
+	scenabi "github.com/multiversx/mx-chain-scenario-go/scenario/abi"
+	ei "github.com/multiversx/mx-chain-scenario-go/scenario/expression/interpreter"
+	er "github.com/multiversx/mx-chain-scenario-go/scenario/expression/reconstructor"
+	scenmodel "github.com/multiversx/mx-chain-scenario-go/scenario/model"
+)
+
+// encodeTypedArguments sets the arguments of a transaction given as typed values, encoded with the contract ABI.
+// Values with an explicit type of the standard library, such as BigUint or List<u32>, need no ABI.
+func (ae *ScenarioExecutor) encodeTypedArguments(tx *scenmodel.Transaction) error {
+	if tx.TypedArguments == nil {
+		return nil
+	}
+
+	abi, endpoint := ae.txEndpoint(tx)
+	encoder := &ei.ABIEncoder{
+		Interpreter: &ei.ExprInterpreter{
+			FileResolver: ae.fileResolver,
+			VMType:       ae.GetVMType(),
+		},
+		ABI: abi,
+	}
+
+	var values [][]byte
+	var err error
+	if tx.TypedArguments.Named {
+		if endpoint == nil {
+			return fmt.Errorf("named arguments need the contract ABI, with the endpoint \"%s\"", tx.Function)
+		}
+		values, err = encodeNamedArguments(encoder, tx.TypedArguments.List, endpoint.Inputs)
+	} else {
+		values, err = encodeArgumentList(encoder, tx.TypedArguments.List)
+	}
+	if err != nil {
+		return err
+	}
+
+	tx.Arguments = make([]scenmodel.JSONBytesFromTree, len(values))
+	for i, value := range values {
+		tx.Arguments[i] = scenmodel.JSONBytesFromTree{
+			Value:    value,
+			Original: &oj.OJsonString{Value: ae.exprReconstructor.ReconstructExpression(value, er.NoHint)},
+		}
+	}
+	return nil
+}
+
+func encodeArgumentList(encoder *ei.ABIEncoder, typedArguments []*scenmodel.TypedArgument) ([][]byte, error) {
+	var values [][]byte
+	for i, typedArgument := range typedArguments {
+		if typedArgument.Plain != nil {
+			values = append(values, typedArgument.Plain.Value)
+			continue
+		}
+		encoded, err := encoder.EncodeArgument(typedArgument.Value, typedArgument.Type)
+		if err != nil {
+			return nil, fmt.Errorf("argument %d: %w", i, err)
+		}
+		values = append(values, encoded...)
+	}
+	return values, nil
+}
+
+// encodeNamedArguments encodes the arguments in the order of the endpoint inputs.
+// Only optional and variadic inputs can be left out.
+func encodeNamedArguments(encoder *ei.ABIEncoder, typedArguments []*scenmodel.TypedArgument, inputs []*scenabi.Param) ([][]byte, error) {
+	argumentsByName := make(map[string]*scenmodel.TypedArgument)
+	for _, typedArgument := range typedArguments {
+		argumentsByName[typedArgument.Name] = typedArgument
+	}
+
+	var values [][]byte
+	for _, input := range inputs {
+		typeName := input.Type
+		typeExpr, err := scenabi.ParseTypeExpression(typeName)
+		if err != nil {
+			return nil, err
+		}
+		if input.MultiArg && typeExpr.Name != "variadic" {
+			typeName = "variadic<" + typeName + ">"
+		}
+
+		typedArgument, given := argumentsByName[input.Name]
+		if !given {
+			if input.MultiArg || typeExpr.Name == "optional" || typeExpr.Name == "variadic" {
+				continue
+			}
+			return nil, fmt.Errorf("missing argument %s", input.Name)
+		}
+		delete(argumentsByName, input.Name)
+
+		if len(typedArgument.Type) > 0 {
+			typeName = typedArgument.Type
+		}
+		encoded, err := encoder.EncodeArgument(typedArgument.Value, typeName)
+		if err != nil {
+			return nil, fmt.Errorf("argument %s: %w", input.Name, err)
+		}
+		values = append(values, encoded...)
+	}
+
+	for _, typedArgument := range typedArguments {
+		if _, unknown := argumentsByName[typedArgument.Name]; unknown {
+			return nil, fmt.Errorf("unknown argument %s", typedArgument.Name)
+		}
+	}
+	return values, nil
+}
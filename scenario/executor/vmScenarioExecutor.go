@@ -3,6 +3,7 @@ package scenexec
 import (
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	logger "github.com/multiversx/mx-chain-logger-go"
+	scenabi "github.com/multiversx/mx-chain-scenario-go/scenario/abi"
 	fr "github.com/multiversx/mx-chain-scenario-go/scenario/expression/fileresolver"
 	er "github.com/multiversx/mx-chain-scenario-go/scenario/expression/reconstructor"
 	scenio "github.com/multiversx/mx-chain-scenario-go/scenario/io"
@@ -18,13 +19,28 @@ var TestVMType = []byte{0, 0}
 
 // ScenarioExecutor parses, interprets and executes both .test.json tests and .scen.json scenarios with VM.
 type ScenarioExecutor struct {
-	World             *worldmock.MockWorld
-	vmBuilder         VMBuilder
-	vm                VMInterface
-	checkGas          bool
-	scenarioTraceGas  []bool
-	fileResolver      fr.FileResolver
-	exprReconstructor er.ExprReconstructor
+	World                  *worldmock.MockWorld
+	vmBuilder              VMBuilder
+	vm                     VMInterface
+	checkGas               bool
+	scenarioTraceGas       []bool
+	fileResolver           fr.FileResolver
+	exprReconstructor      er.ExprReconstructor
+	scenarioDepth          int
+	continueOnCheckFailure bool
+	checkFailures          []*scenio.StepError
+	txProfiles             []*scenio.TxProfile
+	gasTraces              []*scenio.TxGasTrace
+	gasTraceHandler        func(*scenio.TxGasTrace)
+	observers              []ScenarioObserver
+	updateExpectations     bool
+	expectationUpdates     []*scenio.ExpectationUpdate
+	currentStepIndex       int
+	checkpoints            map[string]*worldmock.WorldCheckpoint
+	codePaths              map[string]string
+	decodeWithABI          bool
+	abis                   map[string]*scenabi.ABI
+	topLevelScenario       *scenmodel.Scenario
 }
 
 var _ scenio.ScenarioRunner = (*ScenarioExecutor)(nil)
@@ -34,13 +50,28 @@ func NewScenarioExecutor(vmBuilder VMBuilder) *ScenarioExecutor {
 	world := vmBuilder.NewMockWorld()
 
 	return &ScenarioExecutor{
-		World:             world,
-		vm:                nil,
-		vmBuilder:         vmBuilder,
-		checkGas:          true,
-		scenarioTraceGas:  make([]bool, 0),
-		fileResolver:      nil,
-		exprReconstructor: er.ExprReconstructor{},
+		World:                  world,
+		vm:                     nil,
+		vmBuilder:              vmBuilder,
+		checkGas:               true,
+		scenarioTraceGas:       make([]bool, 0),
+		fileResolver:           nil,
+		exprReconstructor:      er.ExprReconstructor{},
+		scenarioDepth:          0,
+		continueOnCheckFailure: false,
+		checkFailures:          nil,
+		txProfiles:             nil,
+		gasTraces:              nil,
+		gasTraceHandler:        nil,
+		observers:              nil,
+		updateExpectations:     false,
+		expectationUpdates:     nil,
+		currentStepIndex:       0,
+		checkpoints:            make(map[string]*worldmock.WorldCheckpoint),
+		codePaths:              make(map[string]string),
+		decodeWithABI:          false,
+		abis:                   make(map[string]*scenabi.ABI),
+		topLevelScenario:       nil,
 	}
 }
 
@@ -0,0 +1,81 @@
+package scenexec
+
+import (
+	"fmt"
+	"strings"
+
+	er "github.com/multiversx/mx-chain-scenario-go/scenario/expression/reconstructor"
+	worldmock "github.com/multiversx/mx-chain-scenario-go/worldmock"
+)
+
+const noValueInDiff = "(none)"
+
+// WorldDiffString renders a world diff with one change per line, values being written as scenario expressions.
+func WorldDiffString(diff *worldmock.WorldDiff, exprReconstructor er.ExprReconstructor) string {
+	if diff.IsEmpty() {
+		return "no changes\n"
+	}
+
+	var sb strings.Builder
+	for _, accountDiff := range diff.Accounts {
+		sb.WriteString(exprReconstructor.ReconstructExpression(accountDiff.Address, er.AddressHint))
+		if accountDiff.Created {
+			sb.WriteString(" (created)")
+		}
+		if accountDiff.Deleted {
+			sb.WriteString(" (deleted)")
+		}
+		sb.WriteString("\n")
+
+		if accountDiff.NonceChanged() {
+			sb.WriteString(fmt.Sprintf("  nonce: %d -> %d\n", accountDiff.NonceBefore, accountDiff.NonceAfter))
+		}
+		if accountDiff.BalanceChanged() {
+			sb.WriteString(fmt.Sprintf("  balance: %s -> %s\n",
+				exprReconstructor.ReconstructFromBigInt(accountDiff.BalanceBefore),
+				exprReconstructor.ReconstructFromBigInt(accountDiff.BalanceAfter)))
+		}
+		for _, storageDiff := range accountDiff.Storage {
+			sb.WriteString(fmt.Sprintf("  storage %s: %s -> %s\n",
+				exprReconstructor.ReconstructExpression(storageDiff.Key, er.NoHint),
+				diffValueExpression(exprReconstructor, storageDiff.Before),
+				diffValueExpression(exprReconstructor, storageDiff.After)))
+		}
+		for _, esdtDiff := range accountDiff.ESDT {
+			token := exprReconstructor.ReconstructExpression(esdtDiff.TokenIdentifier, er.StrHint)
+			if esdtDiff.Nonce > 0 {
+				token = fmt.Sprintf("%s nonce %d", token, esdtDiff.Nonce)
+			}
+			if esdtDiff.BalanceChanged() {
+				sb.WriteString(fmt.Sprintf("  esdt %s: %s -> %s\n",
+					token,
+					exprReconstructor.ReconstructFromBigInt(esdtDiff.BalanceBefore),
+					exprReconstructor.ReconstructFromBigInt(esdtDiff.BalanceAfter)))
+			}
+			if esdtDiff.AttributesChanged() {
+				sb.WriteString(fmt.Sprintf("  esdt %s attributes: %s -> %s\n",
+					token,
+					diffValueExpression(exprReconstructor, esdtDiff.AttributesBefore),
+					diffValueExpression(exprReconstructor, esdtDiff.AttributesAfter)))
+			}
+		}
+	}
+	return sb.String()
+}
+
+func diffValueExpression(exprReconstructor er.ExprReconstructor, value []byte) string {
+	if value == nil {
+		return noValueInDiff
+	}
+	return exprReconstructor.ReconstructExpression(value, er.NoHint)
+}
+
+// printTxDiff prints the changes made by a transaction to the world, given the accounts from before it.
+func (ae *ScenarioExecutor) printTxDiff(txIndex string, before worldmock.AccountMap) error {
+	diff, err := worldmock.DiffAccountMaps(before, ae.World.AcctMap)
+	if err != nil {
+		return fmt.Errorf("could not compute the changes of tx %s: %w", txIndex, err)
+	}
+	fmt.Printf("world changes after tx %s:\n%s", txIndex, WorldDiffString(diff, ae.exprReconstructor))
+	return nil
+}
@@ -0,0 +1,17 @@
+package scenexec
+
+import (
+	scenio "github.com/multiversx/mx-chain-scenario-go/scenario/io"
+)
+
+var _ scenio.WorldSnapshotter = (*ScenarioExecutor)(nil)
+
+// SaveWorldSnapshotFile writes the complete state of the world to a file, to be loaded back later.
+func (ae *ScenarioExecutor) SaveWorldSnapshotFile(toPath string) error {
+	return ae.World.SaveWorldSnapshotFile(toPath)
+}
+
+// LoadWorldSnapshotFile replaces the state of the world with one saved by SaveWorldSnapshotFile.
+func (ae *ScenarioExecutor) LoadWorldSnapshotFile(fromPath string) error {
+	return ae.World.LoadWorldSnapshotFile(fromPath)
+}
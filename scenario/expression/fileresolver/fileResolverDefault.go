@@ -49,6 +49,7 @@ func (fr *DefaultFileResolver) Clone() FileResolver {
 	return &DefaultFileResolver{
 		contextPath:              fr.contextPath,
 		contractPathReplacements: fr.contractPathReplacements,
+		allowMissingFiles:        fr.allowMissingFiles,
 	}
 }
 
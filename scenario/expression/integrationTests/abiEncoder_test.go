@@ -0,0 +1,104 @@
+package scenjsontest
+
+import (
+	"testing"
+
+	oj "github.com/multiversx/mx-chain-scenario-go/orderedjson"
+	scenabi "github.com/multiversx/mx-chain-scenario-go/scenario/abi"
+	mei "github.com/multiversx/mx-chain-scenario-go/scenario/expression/interpreter"
+	"github.com/stretchr/testify/require"
+)
+
+func abiEncoder(t *testing.T) *mei.ABIEncoder {
+	abi, err := scenabi.ParseABI([]byte(typedTestABI))
+	require.Nil(t, err)
+	ei := interpreter()
+	return &mei.ABIEncoder{
+		Interpreter: &ei,
+		ABI:         abi,
+	}
+}
+
+func encodeJSON(t *testing.T, enc *mei.ABIEncoder, jsonValue string, typeName string) ([][]byte, error) {
+	// scalar values cannot be parsed on their own
+	wrapper, err := oj.ParseOrderedJSON([]byte(`{"value": ` + jsonValue + `}`))
+	require.Nil(t, err)
+	return enc.EncodeArgument(wrapper.(*oj.OJsonMap).Get("value"), typeName)
+}
+
+func TestABIEncoder(t *testing.T) {
+	enc := abiEncoder(t)
+
+	for _, testCase := range []struct {
+		jsonValue  string
+		typeName   string
+		expression string
+	}{
+		{`"0"`, "BigUint", ""},
+		{`"1_000"`, "BigUint", "1000"},
+		{`"-5"`, "BigInt", "-5"},
+		{`"-2"`, "i8", "-2"},
+		{`"0xff"`, "u16", "255"},
+		{`true`, "bool", "true"},
+		{`"false"`, "bool", "false"},
+		{`"str:abc"`, "ManagedBuffer", "str:abc"},
+		{`"TOK-123456"`, "TokenIdentifier", "str:TOK-123456"},
+		{`["1", "2"]`, "List<u32>", "u32:1|u32:2"},
+		{`[["1", "None"]]`, "ManagedVec<Pair>", "u8:1|u8:0"},
+		{`"None"`, "Option<u64>", ""},
+		{`"7"`, "Option<u64>", "u8:1|u64:7"},
+		{`["1", "-3"]`, "Pair", "u8:1|u8:1|i16:-3"},
+		{`{"0": "1", "1": "None"}`, "Pair", "u8:1|u8:0"},
+		{`["4", "9"]`, "tuple<u8,BigUint>", "u8:4|biguint:9"},
+		{`"None"`, "Action", ""},
+		{`{"Send": {"to": "address:bob"}}`, "Action", "u8:1|address:bob"},
+		{`"Green"`, "Color", "str:Green"},
+		{`["Red"]`, "List<Color>", "nested:str:Red"},
+	} {
+		result, err := encodeJSON(t, enc, testCase.jsonValue, testCase.typeName)
+		require.Nil(t, err, testCase.jsonValue)
+		require.Equal(t, [][]byte{typedTestInterpret(t, testCase.expression)}, result, testCase.jsonValue)
+	}
+}
+
+func TestABIEncoderMultiValues(t *testing.T) {
+	enc := abiEncoder(t)
+
+	result, err := encodeJSON(t, enc, `[["1", true], ["2", false]]`, "variadic<multi<u8,bool>>")
+	require.Nil(t, err)
+	require.Equal(t, [][]byte{{1}, {1}, {2}, {}}, result)
+
+	result, err = encodeJSON(t, enc, `["5", "6"]`, "counted-variadic<u8>")
+	require.Nil(t, err)
+	require.Equal(t, [][]byte{{2}, {5}, {6}}, result)
+
+	result, err = encodeJSON(t, enc, `"None"`, "optional<u8>")
+	require.Nil(t, err)
+	require.Empty(t, result)
+}
+
+func TestABIEncoderErrors(t *testing.T) {
+	enc := abiEncoder(t)
+
+	for _, testCase := range []struct {
+		jsonValue string
+		typeName  string
+	}{
+		{`"256"`, "u8"},
+		{`"-1"`, "BigUint"},
+		{`"128"`, "i8"},
+		{`"abc"`, "u32"},
+		{`"maybe"`, "bool"},
+		{`"1"`, "List<u32>"},
+		{`["1"]`, "Pair"},
+		{`{"0": "1"}`, "Pair"},
+		{`{"0": "1", "1": "None", "2": "3"}`, "Pair"},
+		{`"Send"`, "Action"},
+		{`"Blue"`, "Color"},
+		{`"str:abc"`, "H256"},
+		{`"1"`, "Unknown"},
+	} {
+		_, err := encodeJSON(t, enc, testCase.jsonValue, testCase.typeName)
+		require.NotNil(t, err, testCase.jsonValue)
+	}
+}
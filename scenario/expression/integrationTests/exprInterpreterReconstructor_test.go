@@ -665,3 +665,34 @@ func TestInterpretSubTree2(t *testing.T) {
 	expected = append(expected, []byte("field2elem3b")...)
 	require.Equal(t, expected, result)
 }
+
+func TestReconstructExpression(t *testing.T) {
+	ei := interpreter()
+	er := reconstructor()
+
+	for _, expression := range []string{
+		"address:owner",
+		"sc:adder",
+		"str:hello world",
+		"1234",
+		"0xff00",
+		"str:a|str:b",
+		"",
+	} {
+		value, err := ei.InterpretString(expression)
+		require.Nil(t, err)
+		for _, hint := range []mer.ExprReconstructorHint{mer.NoHint, mer.NumberHint, mer.StrHint, mer.AddressHint, mer.HexHint} {
+			reconstructed := er.ReconstructExpression(value, hint)
+			roundTrip, err := ei.InterpretString(reconstructed)
+			require.Nil(t, err)
+			require.Equal(t, value, roundTrip, "expression %s, reconstructed as %s", expression, reconstructed)
+		}
+	}
+
+	value, _ := ei.InterpretString("sc:adder")
+	require.Equal(t, "sc:adder", er.ReconstructExpression(value, mer.NoHint))
+	require.Equal(t, "str:hello", er.ReconstructExpression([]byte("hello"), mer.NoHint))
+	require.Equal(t, "1234", er.ReconstructExpression([]byte{0x04, 0xd2}, mer.NoHint))
+	require.Equal(t, "0x0005", er.ReconstructExpression([]byte{0x00, 0x05}, mer.NumberHint))
+	require.Equal(t, "0x617c62", er.ReconstructExpression([]byte("a|b"), mer.StrHint))
+}
@@ -0,0 +1,108 @@
+package scenjsontest
+
+import (
+	"testing"
+
+	scenabi "github.com/multiversx/mx-chain-scenario-go/scenario/abi"
+	"github.com/stretchr/testify/require"
+)
+
+const typedTestABI = `{
+    "types": {
+        "Pair": {
+            "type": "struct",
+            "fields": [{"name": "0", "type": "u8"}, {"name": "1", "type": "Option<i16>"}]
+        },
+        "Action": {
+            "type": "enum",
+            "variants": [
+                {"name": "None", "discriminant": 0},
+                {"name": "Send", "discriminant": 1, "fields": [{"name": "to", "type": "Address"}]}
+            ]
+        },
+        "Color": {
+            "type": "explicit-enum",
+            "variants": [{"name": "Red"}, {"name": "Green"}]
+        }
+    }
+}`
+
+func typedTestInterpret(t *testing.T, expression string) []byte {
+	ei := interpreter()
+	value, err := ei.InterpretString(expression)
+	require.Nil(t, err)
+	return value
+}
+
+func TestReconstructTyped(t *testing.T) {
+	abi, err := scenabi.ParseABI([]byte(typedTestABI))
+	require.Nil(t, err)
+	er := reconstructor()
+
+	for _, testCase := range []struct {
+		expression string
+		typeName   string
+		expected   string
+	}{
+		{"", "BigUint", "0"},
+		{"-5", "BigInt", "-5"},
+		{"-2", "i8", "-2"},
+		{"true", "bool", "true"},
+		{"str:abc", "ManagedBuffer", `"abc"`},
+		{"0x00ff", "ManagedBuffer", "0x00ff"},
+		{"u32:1|u32:2", "List<u32>", "[1, 2]"},
+		{"u8:1|u8:0|u8:2|u8:0", "ManagedVec<Pair>", "[Pair(1, None), Pair(2, None)]"},
+		{"", "Option<u64>", "None"},
+		{"u8:1|u64:7", "Option<u64>", "Some(7)"},
+		{"u8:1|u8:1|i16:-3", "Pair", "Pair(1, Some(-3))"},
+		{"u8:4|biguint:9", "tuple<u8,BigUint>", "(4, 9)"},
+		{"", "Action", "Action::None"},
+		{"u8:1|address:bob", "Action", "Action::Send { to: address:bob }"},
+		{"str:Green", "Color", "Color::Green"},
+	} {
+		value := typedTestInterpret(t, testCase.expression)
+		result, err := er.ReconstructTyped(value, testCase.typeName, abi)
+		require.Nil(t, err, testCase.expression)
+		require.Equal(t, testCase.expected, result, testCase.expression)
+	}
+}
+
+func TestReconstructTypedErrors(t *testing.T) {
+	abi, err := scenabi.ParseABI([]byte(typedTestABI))
+	require.Nil(t, err)
+	er := reconstructor()
+
+	_, err = er.ReconstructTyped([]byte{1, 2}, "u8", abi)
+	require.NotNil(t, err)
+	_, err = er.ReconstructTyped([]byte{1}, "Pair", abi)
+	require.NotNil(t, err)
+	_, err = er.ReconstructTyped([]byte{2}, "Action", abi)
+	require.NotNil(t, err)
+	_, err = er.ReconstructTyped([]byte{1}, "Unknown", abi)
+	require.NotNil(t, err)
+	_, err = er.ReconstructTyped([]byte{1}, "List<u8", abi)
+	require.NotNil(t, err)
+}
+
+func TestReconstructTypedList(t *testing.T) {
+	abi, err := scenabi.ParseABI([]byte(typedTestABI))
+	require.Nil(t, err)
+	er := reconstructor()
+
+	params := []*scenabi.Param{
+		{Name: "color", Type: "Color"},
+		{Name: "amount", Type: "optional<BigUint>"},
+		{Name: "pairs", Type: "variadic<multi<u8,bool>>"},
+	}
+	values := [][]byte{[]byte("Red"), {5}, {1}, {1}, {2}, {}}
+	result, err := er.ReconstructTypedList(values, params, abi)
+	require.Nil(t, err)
+	require.Equal(t, "[color: Color::Red, amount: 5, pairs: [(1, true), (2, false)]]", result)
+
+	result, err = er.ReconstructTypedList([][]byte{[]byte("Red")}, params, abi)
+	require.Nil(t, err)
+	require.Equal(t, "[color: Color::Red, pairs: []]", result)
+
+	_, err = er.ReconstructTypedList([][]byte{[]byte("Red"), {5}, {1}}, params, abi)
+	require.NotNil(t, err)
+}
@@ -0,0 +1,456 @@
+package scenexpressioninterpreter
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	oj "github.com/multiversx/mx-chain-scenario-go/orderedjson"
+	scenabi "github.com/multiversx/mx-chain-scenario-go/scenario/abi"
+	twos "github.com/multiversx/mx-components-big-int/twos-complement"
+)
+
+const optionNone = "None"
+
+// ABIEncoder converts typed JSON values into endpoint arguments, following the ABI of a contract.
+// The JSON format of the values:
+// - numbers and booleans: strings, e.g. "5", "-3", "0x1f", "1_000", "true";
+// - TokenIdentifier, EgldOrEsdtTokenIdentifier and utf-8 string: the plain text;
+// - other byte arrays, such as ManagedBuffer or Address: scenario expressions, e.g. "str:abc", "address:alice";
+// - lists, tuples and arrays: JSON lists;
+// - structs: maps from field names to values, or lists for tuple structs;
+// - enums: the variant name, or a map from the variant name to its fields;
+// - Option: "None", or the inner value;
+// - variadic and multi values: JSON lists; optional values: "None" or the inner value.
+type ABIEncoder struct {
+	Interpreter *ExprInterpreter
+	ABI         *scenabi.ABI
+}
+
+// EncodeArgument encodes a value into the arguments it takes up: one for most types, any number for multi-value types.
+func (enc *ABIEncoder) EncodeArgument(value oj.OJsonObject, typeName string) ([][]byte, error) {
+	typeExpr, err := scenabi.ParseTypeExpression(typeName)
+	if err != nil {
+		return nil, err
+	}
+	return enc.encodeMulti(value, typeExpr)
+}
+
+func (enc *ABIEncoder) encodeMulti(value oj.OJsonObject, typeExpr *scenabi.TypeExpression) ([][]byte, error) {
+	switch typeExpr.Name {
+	case "variadic", "counted-variadic":
+		items, err := valueList(value, -1)
+		if err != nil {
+			return nil, err
+		}
+		var arguments [][]byte
+		if typeExpr.Name == "counted-variadic" {
+			arguments = append(arguments, big.NewInt(int64(len(items))).Bytes())
+		}
+		for _, item := range items {
+			itemArguments, err := enc.encodeMulti(item, typeExpr.Args[0])
+			if err != nil {
+				return nil, err
+			}
+			arguments = append(arguments, itemArguments...)
+		}
+		return arguments, nil
+	case "optional":
+		if isNone(value) {
+			return nil, nil
+		}
+		return enc.encodeMulti(value, typeExpr.Args[0])
+	case "multi":
+		items, err := valueList(value, len(typeExpr.Args))
+		if err != nil {
+			return nil, err
+		}
+		var arguments [][]byte
+		for i, item := range items {
+			itemArguments, err := enc.encodeMulti(item, typeExpr.Args[i])
+			if err != nil {
+				return nil, err
+			}
+			arguments = append(arguments, itemArguments...)
+		}
+		return arguments, nil
+	}
+
+	argument, err := enc.topEncode(value, typeExpr)
+	if err != nil {
+		return nil, err
+	}
+	return [][]byte{argument}, nil
+}
+
+func (enc *ABIEncoder) topEncode(value oj.OJsonObject, typeExpr *scenabi.TypeExpression) ([]byte, error) {
+	if width, signed, isNumber := typeExpr.NumberWidth(); isNumber {
+		number, err := valueNumber(value, width, signed)
+		if err != nil {
+			return nil, err
+		}
+		return topNumberBytes(number, signed), nil
+	}
+
+	switch {
+	case typeExpr.Name == "BigUint" || typeExpr.Name == "BigInt":
+		signed := typeExpr.Name == "BigInt"
+		number, err := valueNumber(value, 0, signed)
+		if err != nil {
+			return nil, err
+		}
+		return topNumberBytes(number, signed), nil
+	case typeExpr.Name == "bool":
+		boolValue, err := valueBool(value)
+		if err != nil {
+			return nil, err
+		}
+		if boolValue {
+			return []byte{1}, nil
+		}
+		return []byte{}, nil
+	case typeExpr.IsBytes():
+		return enc.valueBytes(value, typeExpr)
+	case typeExpr.Name == "Option":
+		if isNone(value) {
+			return []byte{}, nil
+		}
+		return enc.nestedEncode(value, typeExpr)
+	case typeExpr.IsList():
+		items, err := valueList(value, -1)
+		if err != nil {
+			return nil, err
+		}
+		var result []byte
+		for _, item := range items {
+			encodedItem, err := enc.nestedEncode(item, typeExpr.Args[0])
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, encodedItem...)
+		}
+		return result, nil
+	}
+
+	typeDescription := enc.ABI.CustomType(typeExpr.Name)
+	if typeDescription != nil {
+		switch typeDescription.Type {
+		case scenabi.TypeExplicitEnum:
+			variant, err := enc.valueVariant(value, typeExpr.Name, typeDescription)
+			if err != nil {
+				return nil, err
+			}
+			return []byte(variant.Name), nil
+		case scenabi.TypeEnum:
+			variant, err := enc.valueVariant(value, typeExpr.Name, typeDescription)
+			if err != nil {
+				return nil, err
+			}
+			if len(variant.Fields) == 0 {
+				return big.NewInt(int64(variant.Discriminant)).Bytes(), nil
+			}
+		}
+	}
+
+	return enc.nestedEncode(value, typeExpr)
+}
+
+func (enc *ABIEncoder) nestedEncode(value oj.OJsonObject, typeExpr *scenabi.TypeExpression) ([]byte, error) {
+	if width, signed, isNumber := typeExpr.NumberWidth(); isNumber {
+		number, err := valueNumber(value, width, signed)
+		if err != nil {
+			return nil, err
+		}
+		return twos.ToBytesOfLength(number, width)
+	}
+
+	switch {
+	case typeExpr.Name == "BigUint" || typeExpr.Name == "BigInt":
+		signed := typeExpr.Name == "BigInt"
+		number, err := valueNumber(value, 0, signed)
+		if err != nil {
+			return nil, err
+		}
+		return lengthPrefixed(topNumberBytes(number, signed)), nil
+	case typeExpr.Name == "bool":
+		boolValue, err := valueBool(value)
+		if err != nil {
+			return nil, err
+		}
+		if boolValue {
+			return []byte{1}, nil
+		}
+		return []byte{0}, nil
+	case typeExpr.IsAddress() || typeExpr.Name == "H256":
+		return enc.valueFixedBytes(value, 32)
+	case typeExpr.IsBytes():
+		result, err := enc.valueBytes(value, typeExpr)
+		if err != nil {
+			return nil, err
+		}
+		return lengthPrefixed(result), nil
+	case typeExpr.Name == "Option":
+		if isNone(value) {
+			return []byte{0}, nil
+		}
+		result, err := enc.nestedEncode(value, typeExpr.Args[0])
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte{1}, result...), nil
+	case typeExpr.IsList():
+		items, err := valueList(value, -1)
+		if err != nil {
+			return nil, err
+		}
+		result := lengthPrefix(len(items))
+		return enc.nestedSequence(result, items, typeExpr.Args[0].Repeat(len(items)))
+	case typeExpr.Name == "tuple":
+		items, err := valueList(value, len(typeExpr.Args))
+		if err != nil {
+			return nil, err
+		}
+		return enc.nestedSequence(nil, items, typeExpr.Args)
+	}
+
+	if length, isArray := typeExpr.ArrayLength(); isArray {
+		if typeExpr.Args[0].Name == "u8" {
+			return enc.valueFixedBytes(value, length)
+		}
+		items, err := valueList(value, length)
+		if err != nil {
+			return nil, err
+		}
+		return enc.nestedSequence(nil, items, typeExpr.Args[0].Repeat(length))
+	}
+
+	typeDescription := enc.ABI.CustomType(typeExpr.Name)
+	if typeDescription == nil {
+		return nil, fmt.Errorf("unknown type %s", typeExpr)
+	}
+	switch typeDescription.Type {
+	case scenabi.TypeStruct:
+		return enc.nestedFields(nil, value, typeExpr.Name, typeDescription.Fields)
+	case scenabi.TypeExplicitEnum:
+		variant, err := enc.valueVariant(value, typeExpr.Name, typeDescription)
+		if err != nil {
+			return nil, err
+		}
+		return lengthPrefixed([]byte(variant.Name)), nil
+	default:
+		variant, err := enc.valueVariant(value, typeExpr.Name, typeDescription)
+		if err != nil {
+			return nil, err
+		}
+		var fieldsValue oj.OJsonObject
+		if variantMap, isMap := value.(*oj.OJsonMap); isMap {
+			fieldsValue = variantMap.OrderedKV[0].Value
+		}
+		return enc.nestedFields([]byte{byte(variant.Discriminant)}, fieldsValue, typeExpr.Name+"::"+variant.Name, variant.Fields)
+	}
+}
+
+func (enc *ABIEncoder) nestedSequence(result []byte, items []oj.OJsonObject, types []*scenabi.TypeExpression) ([]byte, error) {
+	for i, item := range items {
+		encodedItem, err := enc.nestedEncode(item, types[i])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, encodedItem...)
+	}
+	return result, nil
+}
+
+// nestedFields encodes the fields of a struct or of an enum variant, given as a map, or as a list for tuple structs.
+func (enc *ABIEncoder) nestedFields(result []byte, value oj.OJsonObject, name string, fields []*scenabi.Field) ([]byte, error) {
+	if len(fields) == 0 {
+		return result, nil
+	}
+
+	fieldValues := make([]oj.OJsonObject, len(fields))
+	switch fieldsValue := value.(type) {
+	case *oj.OJsonList:
+		items, err := valueList(fieldsValue, len(fields))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		copy(fieldValues, items)
+	case *oj.OJsonMap:
+		for _, kvp := range fieldsValue.OrderedKV {
+			fieldIndex := fieldIndexByName(fields, kvp.Key)
+			if fieldIndex < 0 {
+				return nil, fmt.Errorf("%s has no field %s", name, kvp.Key)
+			}
+			fieldValues[fieldIndex] = kvp.Value
+		}
+	default:
+		return nil, fmt.Errorf("%s: fields should be a map or a list", name)
+	}
+
+	for i, field := range fields {
+		if fieldValues[i] == nil {
+			return nil, fmt.Errorf("%s: missing field %s", name, field.Name)
+		}
+		fieldType, err := scenabi.ParseTypeExpression(field.Type)
+		if err != nil {
+			return nil, err
+		}
+		encodedField, err := enc.nestedEncode(fieldValues[i], fieldType)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", name, field.Name, err)
+		}
+		result = append(result, encodedField...)
+	}
+	return result, nil
+}
+
+func fieldIndexByName(fields []*scenabi.Field, name string) int {
+	for i, field := range fields {
+		if field.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// valueVariant finds the enum variant of a value, given as the variant name, or as a map with the variant name as single key.
+func (enc *ABIEncoder) valueVariant(value oj.OJsonObject, typeName string, typeDescription *scenabi.TypeDescription) (*scenabi.Variant, error) {
+	var variantName string
+	switch variantValue := value.(type) {
+	case *oj.OJsonString:
+		variantName = variantValue.Value
+	case *oj.OJsonMap:
+		if len(variantValue.OrderedKV) != 1 {
+			return nil, fmt.Errorf("%s: variant map should have a single key", typeName)
+		}
+		variantName = variantValue.OrderedKV[0].Key
+	default:
+		return nil, fmt.Errorf("%s: variant should be a string or a map", typeName)
+	}
+
+	variant, err := typeDescription.VariantByName(variantName)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", typeName, err)
+	}
+	if _, isString := value.(*oj.OJsonString); isString && len(variant.Fields) > 0 {
+		return nil, fmt.Errorf("%s::%s needs fields", typeName, variant.Name)
+	}
+	return variant, nil
+}
+
+func (enc *ABIEncoder) valueBytes(value oj.OJsonObject, typeExpr *scenabi.TypeExpression) ([]byte, error) {
+	if typeExpr.IsList() {
+		if items, isList := value.(*oj.OJsonList); isList {
+			return enc.topEncode(items, &scenabi.TypeExpression{Name: "List", Args: typeExpr.Args})
+		}
+	}
+	str, err := valueString(value)
+	if err != nil {
+		return nil, err
+	}
+	switch typeExpr.Name {
+	case "TokenIdentifier", "EgldOrEsdtTokenIdentifier", "utf-8 string":
+		return []byte(str), nil
+	}
+	return enc.Interpreter.InterpretString(str)
+}
+
+func (enc *ABIEncoder) valueFixedBytes(value oj.OJsonObject, length int) ([]byte, error) {
+	str, err := valueString(value)
+	if err != nil {
+		return nil, err
+	}
+	result, err := enc.Interpreter.InterpretString(str)
+	if err != nil {
+		return nil, err
+	}
+	if len(result) != length {
+		return nil, fmt.Errorf("\"%s\" has %d bytes instead of %d", str, len(result), length)
+	}
+	return result, nil
+}
+
+func valueString(value oj.OJsonObject) (string, error) {
+	str, isString := value.(*oj.OJsonString)
+	if !isString {
+		return "", errors.New("value should be a string")
+	}
+	return str.Value, nil
+}
+
+func valueBool(value oj.OJsonObject) (bool, error) {
+	if boolValue, isBool := value.(*oj.OJsonBool); isBool {
+		return bool(*boolValue), nil
+	}
+	str, err := valueString(value)
+	if err != nil {
+		return false, errors.New("value should be a bool")
+	}
+	result, err := strconv.ParseBool(str)
+	if err != nil {
+		return false, fmt.Errorf("invalid bool \"%s\"", str)
+	}
+	return result, nil
+}
+
+// valueNumber parses a number and checks that it fits the type. Width 0 means no size limit.
+func valueNumber(value oj.OJsonObject, width int, signed bool) (*big.Int, error) {
+	str, err := valueString(value)
+	if err != nil {
+		return nil, err
+	}
+	number, ok := big.NewInt(0).SetString(str, 0)
+	if !ok {
+		return nil, fmt.Errorf("invalid number \"%s\"", str)
+	}
+	if !signed && number.Sign() < 0 {
+		return nil, fmt.Errorf("negative number \"%s\" for unsigned type", str)
+	}
+	if width > 0 {
+		bitLength := width * 8
+		if signed {
+			bitLength--
+		}
+		if number.BitLen() > bitLength {
+			return nil, fmt.Errorf("number \"%s\" does not fit in %d bytes", str, width)
+		}
+	}
+	return number, nil
+}
+
+// valueList yields the items of a JSON list, which should have the given length, if not negative.
+func valueList(value oj.OJsonObject, length int) ([]oj.OJsonObject, error) {
+	list, isList := value.(*oj.OJsonList)
+	if !isList {
+		return nil, errors.New("value should be a list")
+	}
+	items := list.AsList()
+	if length >= 0 && len(items) != length {
+		return nil, fmt.Errorf("list should have %d items, not %d", length, len(items))
+	}
+	return items, nil
+}
+
+func isNone(value oj.OJsonObject) bool {
+	str, isString := value.(*oj.OJsonString)
+	return isString && str.Value == optionNone
+}
+
+func topNumberBytes(number *big.Int, signed bool) []byte {
+	if number.Sign() == 0 {
+		return []byte{}
+	}
+	if signed {
+		return twos.ToBytes(number)
+	}
+	return number.Bytes()
+}
+
+func lengthPrefix(length int) []byte {
+	return twos.CopyAlignRight(big.NewInt(int64(length)).Bytes(), 4)
+}
+
+func lengthPrefixed(value []byte) []byte {
+	return append(lengthPrefix(len(value)), value...)
+}
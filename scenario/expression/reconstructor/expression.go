@@ -0,0 +1,66 @@
+package scenexpressionreconstructor
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	ei "github.com/multiversx/mx-chain-scenario-go/scenario/expression/interpreter"
+)
+
+// ReconstructExpression yields a scenario value expression that the interpreter converts back to exactly the same bytes.
+// Unlike Reconstruct, the result can be written to scenario files.
+// The hint decides the preferred representation, hex is used when nothing else fits.
+func (er *ExprReconstructor) ReconstructExpression(value []byte, hint ExprReconstructorHint) string {
+	if len(value) == 0 {
+		return ""
+	}
+
+	for _, candidate := range er.expressionCandidates(value, hint) {
+		if interpretsTo(candidate, value) {
+			return candidate
+		}
+	}
+	return fmt.Sprintf("0x%s", hex.EncodeToString(value))
+}
+
+func (er *ExprReconstructor) expressionCandidates(value []byte, hint ExprReconstructorHint) []string {
+	switch hint {
+	case NumberHint:
+		return []string{decimalExpression(value)}
+	case StrHint:
+		return []string{fmt.Sprintf("str:%s", string(value))}
+	case AddressHint:
+		return []string{addressPretty(value, er.Bech32Addr)}
+	case CodeHint, HexHint:
+		return nil
+	default:
+		var candidates []string
+		if len(value) == 32 {
+			candidates = append(candidates, addressPretty(value, er.Bech32Addr))
+		}
+		if canInterpretAsString(value) {
+			candidates = append(candidates, fmt.Sprintf("str:%s", string(value)))
+		}
+		if len(value) < maxBytesInterpretedAsNumber {
+			candidates = append(candidates, decimalExpression(value))
+		}
+		return candidates
+	}
+}
+
+func decimalExpression(value []byte) string {
+	return big.NewInt(0).SetBytes(value).String()
+}
+
+// interpretsTo checks that an expression is interpreted as the expected value.
+// Smart contract addresses embed the VM type, which is taken from the value itself.
+func interpretsTo(expression string, expected []byte) bool {
+	interpreter := ei.ExprInterpreter{}
+	if len(expected) == 32 {
+		interpreter.VMType = expected[ei.SCAddressNumLeadingZeros:ei.SCAddressReservedPrefixLength]
+	}
+	actual, err := interpreter.InterpretString(expression)
+	return err == nil && bytes.Equal(actual, expected)
+}
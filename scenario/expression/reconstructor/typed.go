@@ -0,0 +1,438 @@
+package scenexpressionreconstructor
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	scenabi "github.com/multiversx/mx-chain-scenario-go/scenario/abi"
+	twos "github.com/multiversx/mx-components-big-int/twos-complement"
+)
+
+var errInputTooShort = errors.New("input too short")
+
+// ReconstructTyped decodes a top-encoded value of an ABI type into a typed representation,
+// e.g. `MyStruct { amount: 5, token: "TOKEN-123456" }`.
+func (er *ExprReconstructor) ReconstructTyped(value []byte, typeName string, abi *scenabi.ABI) (string, error) {
+	typeExpr, err := scenabi.ParseTypeExpression(typeName)
+	if err != nil {
+		return "", err
+	}
+	return er.typedDecoder(abi).topDecode(value, typeExpr)
+}
+
+// ReconstructTypedList decodes the top-encoded arguments or results of an endpoint, as "[name: value, ...]".
+// Multi-value types, such as variadic<T>, take up as many values as they need.
+func (er *ExprReconstructor) ReconstructTypedList(values [][]byte, params []*scenabi.Param, abi *scenabi.ABI) (string, error) {
+	decoder := er.typedDecoder(abi)
+	var items []string
+	for _, param := range params {
+		typeExpr, err := scenabi.ParseTypeExpression(param.Type)
+		if err != nil {
+			return "", err
+		}
+		if (param.MultiArg || param.MultiResult) && typeExpr.Name != "variadic" {
+			typeExpr = &scenabi.TypeExpression{Name: "variadic", Args: []*scenabi.TypeExpression{typeExpr}}
+		}
+
+		var item string
+		item, values, err = decoder.multiDecode(values, typeExpr)
+		if err != nil {
+			return "", paramError(param, err)
+		}
+		if len(item) > 0 {
+			items = append(items, namedItem(param.Name, item))
+		}
+	}
+	if len(values) > 0 {
+		return "", fmt.Errorf("%d values left over", len(values))
+	}
+	return "[" + strings.Join(items, ", ") + "]", nil
+}
+
+// ReconstructTypedNested decodes a sequence of nested-encoded values, e.g. the arguments in a storage key.
+func (er *ExprReconstructor) ReconstructTypedNested(data []byte, params []*scenabi.Param, abi *scenabi.ABI) (string, error) {
+	decoder := er.typedDecoder(abi)
+	var items []string
+	for _, param := range params {
+		typeExpr, err := scenabi.ParseTypeExpression(param.Type)
+		if err != nil {
+			return "", err
+		}
+		var item string
+		item, data, err = decoder.nestedDecode(data, typeExpr)
+		if err != nil {
+			return "", paramError(param, err)
+		}
+		items = append(items, namedItem(param.Name, item))
+	}
+	if len(data) > 0 {
+		return "", fmt.Errorf("%d bytes left over", len(data))
+	}
+	return strings.Join(items, ", "), nil
+}
+
+func paramError(param *scenabi.Param, err error) error {
+	if len(param.Name) == 0 {
+		return fmt.Errorf("%s: %w", param.Type, err)
+	}
+	return fmt.Errorf("%s (%s): %w", param.Name, param.Type, err)
+}
+
+func namedItem(name string, item string) string {
+	if len(name) == 0 {
+		return item
+	}
+	return name + ": " + item
+}
+
+type typedDecoder struct {
+	er  *ExprReconstructor
+	abi *scenabi.ABI
+}
+
+func (er *ExprReconstructor) typedDecoder(abi *scenabi.ABI) *typedDecoder {
+	return &typedDecoder{
+		er:  er,
+		abi: abi,
+	}
+}
+
+func (td *typedDecoder) topDecode(data []byte, typeExpr *scenabi.TypeExpression) (string, error) {
+	if width, signed, isNumber := typeExpr.NumberWidth(); isNumber {
+		if len(data) > width {
+			return "", fmt.Errorf("%d bytes are too many for %s", len(data), typeExpr.Name)
+		}
+		return numberPretty(data, signed), nil
+	}
+
+	switch {
+	case typeExpr.Name == "BigUint":
+		return numberPretty(data, false), nil
+	case typeExpr.Name == "BigInt":
+		return numberPretty(data, true), nil
+	case typeExpr.Name == "bool":
+		switch {
+		case len(data) == 0:
+			return "false", nil
+		case len(data) == 1 && data[0] == 1:
+			return "true", nil
+		}
+		return "", fmt.Errorf("invalid bool 0x%s", hex.EncodeToString(data))
+	case typeExpr.IsBytes():
+		return td.bytesPretty(data, typeExpr), nil
+	case typeExpr.Name == "Option":
+		if len(data) == 0 {
+			return "None", nil
+		}
+		return td.nestedDecodeAll(data, typeExpr)
+	case typeExpr.IsList():
+		var items []string
+		for len(data) > 0 {
+			var item string
+			var err error
+			item, data, err = td.nestedDecode(data, typeExpr.Args[0])
+			if err != nil {
+				return "", err
+			}
+			items = append(items, item)
+		}
+		return "[" + strings.Join(items, ", ") + "]", nil
+	}
+
+	typeDescription := td.abi.CustomType(typeExpr.Name)
+	if typeDescription != nil {
+		switch typeDescription.Type {
+		case scenabi.TypeExplicitEnum:
+			return typeExpr.Name + "::" + string(data), nil
+		case scenabi.TypeEnum:
+			if len(data) == 0 {
+				return td.variantPretty(typeExpr.Name, typeDescription, 0, nil)
+			}
+		}
+	}
+
+	return td.nestedDecodeAll(data, typeExpr)
+}
+
+// nestedDecodeAll decodes a nested value that takes up all the data.
+func (td *typedDecoder) nestedDecodeAll(data []byte, typeExpr *scenabi.TypeExpression) (string, error) {
+	result, rest, err := td.nestedDecode(data, typeExpr)
+	if err != nil {
+		return "", err
+	}
+	if len(rest) > 0 {
+		return "", fmt.Errorf("%d bytes left over", len(rest))
+	}
+	return result, nil
+}
+
+func (td *typedDecoder) nestedDecode(data []byte, typeExpr *scenabi.TypeExpression) (string, []byte, error) {
+	if width, signed, isNumber := typeExpr.NumberWidth(); isNumber {
+		if len(data) < width {
+			return "", nil, errInputTooShort
+		}
+		return numberPretty(data[:width], signed), data[width:], nil
+	}
+
+	switch {
+	case typeExpr.Name == "BigUint" || typeExpr.Name == "BigInt":
+		value, rest, err := lengthPrefixed(data)
+		if err != nil {
+			return "", nil, err
+		}
+		return numberPretty(value, typeExpr.Name == "BigInt"), rest, nil
+	case typeExpr.Name == "bool":
+		if len(data) < 1 {
+			return "", nil, errInputTooShort
+		}
+		if data[0] > 1 {
+			return "", nil, fmt.Errorf("invalid bool 0x%02x", data[0])
+		}
+		return strconv.FormatBool(data[0] == 1), data[1:], nil
+	case typeExpr.IsAddress():
+		if len(data) < 32 {
+			return "", nil, errInputTooShort
+		}
+		return addressPretty(data[:32], td.er.Bech32Addr), data[32:], nil
+	case typeExpr.Name == "H256":
+		if len(data) < 32 {
+			return "", nil, errInputTooShort
+		}
+		return "0x" + hex.EncodeToString(data[:32]), data[32:], nil
+	case typeExpr.IsBytes():
+		value, rest, err := lengthPrefixed(data)
+		if err != nil {
+			return "", nil, err
+		}
+		return td.bytesPretty(value, typeExpr), rest, nil
+	case typeExpr.Name == "Option":
+		if len(data) < 1 {
+			return "", nil, errInputTooShort
+		}
+		switch data[0] {
+		case 0:
+			return "None", data[1:], nil
+		case 1:
+			item, rest, err := td.nestedDecode(data[1:], typeExpr.Args[0])
+			if err != nil {
+				return "", nil, err
+			}
+			return "Some(" + item + ")", rest, nil
+		}
+		return "", nil, fmt.Errorf("invalid Option marker 0x%02x", data[0])
+	case typeExpr.IsList():
+		count, rest, err := nestedLength(data)
+		if err != nil {
+			return "", nil, err
+		}
+		if count > len(rest) {
+			return "", nil, errInputTooShort
+		}
+		return td.nestedSequence(rest, typeExpr.Args[0].Repeat(count), "[", "]")
+	case typeExpr.Name == "tuple":
+		return td.nestedSequence(data, typeExpr.Args, "(", ")")
+	}
+
+	if length, isArray := typeExpr.ArrayLength(); isArray {
+		if typeExpr.Args[0].Name == "u8" {
+			if len(data) < length {
+				return "", nil, errInputTooShort
+			}
+			return "0x" + hex.EncodeToString(data[:length]), data[length:], nil
+		}
+		return td.nestedSequence(data, typeExpr.Args[0].Repeat(length), "[", "]")
+	}
+
+	typeDescription := td.abi.CustomType(typeExpr.Name)
+	if typeDescription == nil {
+		return "", nil, fmt.Errorf("unknown type %s", typeExpr)
+	}
+	switch typeDescription.Type {
+	case scenabi.TypeStruct:
+		return td.nestedFields(data, typeExpr.Name, typeDescription.Fields)
+	case scenabi.TypeExplicitEnum:
+		value, rest, err := lengthPrefixed(data)
+		if err != nil {
+			return "", nil, err
+		}
+		return typeExpr.Name + "::" + string(value), rest, nil
+	default:
+		if len(data) < 1 {
+			return "", nil, errInputTooShort
+		}
+		return td.nestedVariant(typeExpr.Name, typeDescription, int(data[0]), data[1:])
+	}
+}
+
+func (td *typedDecoder) nestedSequence(data []byte, types []*scenabi.TypeExpression, opening string, closing string) (string, []byte, error) {
+	items := make([]string, len(types))
+	for i, itemType := range types {
+		var err error
+		items[i], data, err = td.nestedDecode(data, itemType)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+	return opening + strings.Join(items, ", ") + closing, data, nil
+}
+
+// nestedFields decodes the fields of a struct or of an enum variant.
+// Fields named by their index, as in tuple structs, are written in parentheses.
+func (td *typedDecoder) nestedFields(data []byte, name string, fields []*scenabi.Field) (string, []byte, error) {
+	if len(fields) == 0 {
+		return name, data, nil
+	}
+
+	items := make([]string, len(fields))
+	isTuple := true
+	for i, field := range fields {
+		fieldType, err := scenabi.ParseTypeExpression(field.Type)
+		if err != nil {
+			return "", nil, err
+		}
+		var item string
+		item, data, err = td.nestedDecode(data, fieldType)
+		if err != nil {
+			return "", nil, fmt.Errorf("%s.%s: %w", name, field.Name, err)
+		}
+		if field.Name != strconv.Itoa(i) {
+			isTuple = false
+		}
+		items[i] = namedItem(field.Name, item)
+	}
+
+	if isTuple {
+		for i := range items {
+			items[i] = strings.TrimPrefix(items[i], strconv.Itoa(i)+": ")
+		}
+		return name + "(" + strings.Join(items, ", ") + ")", data, nil
+	}
+	return name + " { " + strings.Join(items, ", ") + " }", data, nil
+}
+
+func (td *typedDecoder) nestedVariant(
+	typeName string,
+	typeDescription *scenabi.TypeDescription,
+	discriminant int,
+	data []byte,
+) (string, []byte, error) {
+	variant, err := typeDescription.VariantByDiscriminant(discriminant)
+	if err != nil {
+		return "", nil, fmt.Errorf("%s: %w", typeName, err)
+	}
+	return td.nestedFields(data, typeName+"::"+variant.Name, variant.Fields)
+}
+
+func (td *typedDecoder) variantPretty(
+	typeName string,
+	typeDescription *scenabi.TypeDescription,
+	discriminant int,
+	data []byte,
+) (string, error) {
+	result, rest, err := td.nestedVariant(typeName, typeDescription, discriminant, data)
+	if err != nil {
+		return "", err
+	}
+	if len(rest) > 0 {
+		return "", fmt.Errorf("%d bytes left over", len(rest))
+	}
+	return result, nil
+}
+
+// multiDecode decodes the values taken up by an argument or result, returning the remaining values.
+// An empty result means that an optional value was missing.
+func (td *typedDecoder) multiDecode(values [][]byte, typeExpr *scenabi.TypeExpression) (string, [][]byte, error) {
+	switch typeExpr.Name {
+	case "variadic":
+		var items []string
+		for len(values) > 0 {
+			var item string
+			var err error
+			item, values, err = td.multiDecode(values, typeExpr.Args[0])
+			if err != nil {
+				return "", nil, err
+			}
+			items = append(items, item)
+		}
+		return "[" + strings.Join(items, ", ") + "]", values, nil
+	case "optional":
+		if len(values) == 0 {
+			return "", values, nil
+		}
+		return td.multiDecode(values, typeExpr.Args[0])
+	case "multi":
+		items := make([]string, len(typeExpr.Args))
+		for i, itemType := range typeExpr.Args {
+			var err error
+			items[i], values, err = td.multiDecode(values, itemType)
+			if err != nil {
+				return "", nil, err
+			}
+		}
+		return "(" + strings.Join(items, ", ") + ")", values, nil
+	case "counted-variadic":
+		if len(values) == 0 {
+			return "", nil, errors.New("missing count")
+		}
+		count := int(big.NewInt(0).SetBytes(values[0]).Int64())
+		values = values[1:]
+		if count < 0 || count > len(values) {
+			return "", nil, fmt.Errorf("bad count %d", count)
+		}
+		items := make([]string, count)
+		for i := range items {
+			var err error
+			items[i], values, err = td.multiDecode(values, typeExpr.Args[0])
+			if err != nil {
+				return "", nil, err
+			}
+		}
+		return "[" + strings.Join(items, ", ") + "]", values, nil
+	}
+
+	if len(values) == 0 {
+		return "", nil, errors.New("missing value")
+	}
+	item, err := td.topDecode(values[0], typeExpr)
+	return item, values[1:], err
+}
+
+func (td *typedDecoder) bytesPretty(value []byte, typeExpr *scenabi.TypeExpression) string {
+	if len(value) == 0 {
+		return "\"\""
+	}
+	if typeExpr.Name == "utf-8 string" || canInterpretAsString(value) {
+		return strconv.Quote(string(value))
+	}
+	return "0x" + hex.EncodeToString(value)
+}
+
+func numberPretty(value []byte, signed bool) string {
+	if signed {
+		return twos.FromBytes(value).String()
+	}
+	return big.NewInt(0).SetBytes(value).String()
+}
+
+func lengthPrefixed(data []byte) ([]byte, []byte, error) {
+	length, rest, err := nestedLength(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(rest) < length {
+		return nil, nil, errInputTooShort
+	}
+	return rest[:length], rest[length:], nil
+}
+
+func nestedLength(data []byte) (int, []byte, error) {
+	if len(data) < 4 {
+		return 0, nil, errInputTooShort
+	}
+	return int(binary.BigEndian.Uint32(data[:4])), data[4:], nil
+}
@@ -0,0 +1,110 @@
+package scenio
+
+import (
+	"fmt"
+	"os"
+
+	oj "github.com/multiversx/mx-chain-scenario-go/orderedjson"
+)
+
+// ExpectationUpdate holds the actual values of the expectations of a step that did not match.
+// Values are ordered JSON, ready to replace the original ones in the scenario file.
+type ExpectationUpdate struct {
+	StepIndex int
+
+	// Expect maps fields of the expect block of a transaction step to their actual values.
+	Expect *oj.OJsonMap
+
+	// Accounts maps account keys of a checkState step to the account fields to replace.
+	// Accounts not yet in the step are added whole.
+	Accounts *oj.OJsonMap
+
+	// RemovedAccounts are the account keys of a checkState step missing from the world.
+	RemovedAccounts []string
+}
+
+// ExpectationUpdater is implemented by runners that can collect the actual values of failed expectations,
+// instead of failing the scenario.
+type ExpectationUpdater interface {
+	ExpectationUpdates() []*ExpectationUpdate
+}
+
+// UpdateScenarioExpectations rewrites the expectations of a scenario file in place.
+// Everything else in the file, including the order of keys, is kept as it is.
+func UpdateScenarioExpectations(scenarioPath string, updates []*ExpectationUpdate) error {
+	data, err := os.ReadFile(scenarioPath)
+	if err != nil {
+		return err
+	}
+
+	scenarioOJ, err := oj.ParseOrderedJSON(data)
+	if err != nil {
+		return err
+	}
+	scenarioMap, isMap := scenarioOJ.(*oj.OJsonMap)
+	if !isMap {
+		return fmt.Errorf("scenario %s is not a JSON object", scenarioPath)
+	}
+	stepsList, isList := scenarioMap.Get("steps").(*oj.OJsonList)
+	if !isList {
+		return fmt.Errorf("scenario %s has no steps list", scenarioPath)
+	}
+	steps := stepsList.AsList()
+
+	for _, update := range updates {
+		if update.StepIndex < 0 || update.StepIndex >= len(steps) {
+			return fmt.Errorf("scenario %s has no step %d", scenarioPath, update.StepIndex)
+		}
+		stepMap, isMap := steps[update.StepIndex].(*oj.OJsonMap)
+		if !isMap {
+			return fmt.Errorf("step %d of scenario %s is not a JSON object", update.StepIndex, scenarioPath)
+		}
+		applyExpectationUpdate(stepMap, update)
+	}
+
+	return os.WriteFile(scenarioPath, []byte(oj.JSONString(scenarioMap)+"\n"), 0644)
+}
+
+func applyExpectationUpdate(stepMap *oj.OJsonMap, update *ExpectationUpdate) {
+	if update.Expect != nil && update.Expect.Size() > 0 {
+		expectMap, isMap := stepMap.Get("expect").(*oj.OJsonMap)
+		if !isMap {
+			expectMap = oj.NewMap()
+			stepMap.Set("expect", expectMap)
+		}
+		setAll(expectMap, update.Expect)
+	}
+
+	if (update.Accounts == nil || update.Accounts.Size() == 0) && len(update.RemovedAccounts) == 0 {
+		return
+	}
+	accountsMap, isMap := stepMap.Get("accounts").(*oj.OJsonMap)
+	if !isMap {
+		accountsMap = oj.NewMap()
+		stepMap.Set("accounts", accountsMap)
+	}
+	for _, removedKey := range update.RemovedAccounts {
+		accountsMap.Remove(removedKey)
+	}
+	if update.Accounts == nil {
+		return
+	}
+	for _, accountUpdate := range update.Accounts.OrderedKV {
+		accountFields, isMap := accountUpdate.Value.(*oj.OJsonMap)
+		if !isMap {
+			continue
+		}
+		accountMap, isMap := accountsMap.Get(accountUpdate.Key).(*oj.OJsonMap)
+		if !isMap {
+			accountsMap.Put(accountUpdate.Key, accountFields)
+			continue
+		}
+		setAll(accountMap, accountFields)
+	}
+}
+
+func setAll(target *oj.OJsonMap, source *oj.OJsonMap) {
+	for _, kv := range source.OrderedKV {
+		target.Set(kv.Key, kv.Value)
+	}
+}
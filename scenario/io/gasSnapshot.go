@@ -0,0 +1,160 @@
+package scenio
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// GasSnapshot maps scenario paths to the gas used by each of their transactions, by tx id.
+// Scenario paths are relative to the directory the scenarios were run from, so that snapshots can be shared.
+type GasSnapshot map[string]map[string]uint64
+
+// NewGasSnapshot collects the gas used by the transactions of all scenarios in the report.
+// Transactions without an id are named by the index of their step in the scenario, "step-N",
+// and repeated ids get a "#N" suffix.
+func NewGasSnapshot(report *ScenariosReport, basePath string) GasSnapshot {
+	snapshot := make(GasSnapshot)
+	for _, result := range report.Results {
+		if len(result.TxProfiles) == 0 {
+			continue
+		}
+
+		scenarioGas := make(map[string]uint64)
+		for _, txProfile := range result.TxProfiles {
+			txKey := txProfile.StepID
+			if len(txKey) == 0 {
+				txKey = fmt.Sprintf("step-%d", txProfile.StepIndex)
+			}
+			uniqueKey := txKey
+			for repeat := 2; ; repeat++ {
+				if _, exists := scenarioGas[uniqueKey]; !exists {
+					break
+				}
+				uniqueKey = fmt.Sprintf("%s#%d", txKey, repeat)
+			}
+			scenarioGas[uniqueKey] = txProfile.GasUsed
+		}
+		snapshot[snapshotScenarioKey(result.Path, basePath)] = scenarioGas
+	}
+	return snapshot
+}
+
+func snapshotScenarioKey(scenarioPath string, basePath string) string {
+	relativePath, err := filepath.Rel(basePath, scenarioPath)
+	if err != nil {
+		return filepath.ToSlash(scenarioPath)
+	}
+	return filepath.ToSlash(relativePath)
+}
+
+// LoadGasSnapshot reads a gas snapshot from a JSON file.
+func LoadGasSnapshot(fromPath string) (GasSnapshot, error) {
+	data, err := os.ReadFile(fromPath)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := make(GasSnapshot)
+	err = json.Unmarshal(data, &snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("bad gas snapshot file %s: %w", fromPath, err)
+	}
+	return snapshot, nil
+}
+
+// WriteGasSnapshotFile saves a gas snapshot as JSON, with sorted keys.
+func WriteGasSnapshotFile(snapshot GasSnapshot, toPath string) error {
+	data, err := json.MarshalIndent(snapshot, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(toPath), os.ModePerm)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(toPath, append(data, '\n'), 0644)
+}
+
+// GasRegression is a transaction that used more gas than in the baseline, beyond the tolerance.
+type GasRegression struct {
+	Scenario    string
+	TxID        string
+	BaselineGas uint64
+	CurrentGas  uint64
+}
+
+// String yields a one line description of the regression.
+func (gr *GasRegression) String() string {
+	increase := "new cost"
+	if gr.BaselineGas > 0 {
+		increase = fmt.Sprintf("%+.2f%%", 100*(float64(gr.CurrentGas)/float64(gr.BaselineGas)-1))
+	}
+	return fmt.Sprintf("%s, tx %s: gas used %d, baseline %d (%s)",
+		gr.Scenario, gr.TxID, gr.CurrentGas, gr.BaselineGas, increase)
+}
+
+// FindGasRegressions compares the current gas usage with a baseline.
+// Transactions missing from the baseline are not compared.
+// The tolerance is the allowed increase, in percent of the baseline gas.
+func FindGasRegressions(baseline GasSnapshot, current GasSnapshot, tolerancePercent float64) []*GasRegression {
+	var regressions []*GasRegression
+	for scenarioKey, scenarioGas := range current {
+		baselineScenarioGas, found := baseline[scenarioKey]
+		if !found {
+			continue
+		}
+		for txID, currentGas := range scenarioGas {
+			baselineGas, found := baselineScenarioGas[txID]
+			if !found {
+				continue
+			}
+			allowedGas := float64(baselineGas) * (1 + tolerancePercent/100)
+			if float64(currentGas) > allowedGas {
+				regressions = append(regressions, &GasRegression{
+					Scenario:    scenarioKey,
+					TxID:        txID,
+					BaselineGas: baselineGas,
+					CurrentGas:  currentGas,
+				})
+			}
+		}
+	}
+
+	sort.Slice(regressions, func(i, j int) bool {
+		if regressions[i].Scenario != regressions[j].Scenario {
+			return regressions[i].Scenario < regressions[j].Scenario
+		}
+		return regressions[i].TxID < regressions[j].TxID
+	})
+	return regressions
+}
+
+// UnmatchedGasBaseline lists the baseline scenarios and transactions missing from the current snapshot,
+// as "scenario" or "scenario, tx id", sorted.
+// It also yields the number of baseline transactions that were found, so that a baseline made for other paths,
+// which matches nothing, can be told apart from one that has no regressions.
+func UnmatchedGasBaseline(baseline GasSnapshot, current GasSnapshot) ([]string, int) {
+	var unmatched []string
+	matchedTxs := 0
+	for scenarioKey, baselineScenarioGas := range baseline {
+		scenarioGas, found := current[scenarioKey]
+		if !found {
+			unmatched = append(unmatched, scenarioKey)
+			continue
+		}
+		for txID := range baselineScenarioGas {
+			if _, found := scenarioGas[txID]; found {
+				matchedTxs++
+			} else {
+				unmatched = append(unmatched, fmt.Sprintf("%s, tx %s", scenarioKey, txID))
+			}
+		}
+	}
+
+	sort.Strings(unmatched)
+	return unmatched, matchedTxs
+}
@@ -0,0 +1,92 @@
+package scenio
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// GasTraceFunction is the gas used by all calls to a VM API function, during a transaction.
+type GasTraceFunction struct {
+	Function  string   `json:"function"`
+	CallCount int      `json:"callCount"`
+	TotalGas  uint64   `json:"totalGas"`
+	Gas       []uint64 `json:"gas"`
+}
+
+// GasTraceContract groups the API function gas traces of a contract.
+type GasTraceContract struct {
+	Address   string              `json:"address"`
+	TotalGas  uint64              `json:"totalGas"`
+	Functions []*GasTraceFunction `json:"functions"`
+}
+
+// TxGasTrace is the gas trace captured by the VM for a transaction step.
+type TxGasTrace struct {
+	StepID    string              `json:"stepId,omitempty"`
+	StepType  string              `json:"stepType"`
+	Contracts []*GasTraceContract `json:"contracts"`
+}
+
+// GasTracer is implemented by runners that capture VM gas traces for the steps where gas tracing is enabled.
+type GasTracer interface {
+	// TxGasTraces yields the gas traces of the transactions in the last scenario run, including external steps.
+	TxGasTraces() []*TxGasTrace
+}
+
+type jsonScenarioGasTraces struct {
+	Scenario string        `json:"scenario"`
+	Steps    []*TxGasTrace `json:"steps"`
+}
+
+// WriteGasTracesJSON writes the gas traces of all scenarios in the report as JSON.
+// Scenarios without gas traces are left out.
+func WriteGasTracesJSON(report *ScenariosReport, writer io.Writer) error {
+	jTraces := make([]jsonScenarioGasTraces, 0, len(report.Results))
+	for _, result := range report.Results {
+		if len(result.GasTraces) == 0 {
+			continue
+		}
+		jTraces = append(jTraces, jsonScenarioGasTraces{
+			Scenario: result.Path,
+			Steps:    result.GasTraces,
+		})
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "    ")
+	return encoder.Encode(jTraces)
+}
+
+// WriteGasTracesFolded writes the gas traces in the folded stack format understood by flame graph tools:
+// one "scenario;step;contract;function gas" line for every API function called in a transaction.
+func WriteGasTracesFolded(report *ScenariosReport, writer io.Writer) error {
+	for _, result := range report.Results {
+		for _, txTrace := range result.GasTraces {
+			stepName := txTrace.StepType
+			if len(txTrace.StepID) > 0 {
+				stepName = fmt.Sprintf("%s %s", txTrace.StepType, txTrace.StepID)
+			}
+			for _, contractTrace := range txTrace.Contracts {
+				for _, functionTrace := range contractTrace.Functions {
+					_, err := fmt.Fprintf(writer, "%s;%s;%s;%s %d\n",
+						foldedFrame(result.Path),
+						foldedFrame(stepName),
+						foldedFrame(contractTrace.Address),
+						foldedFrame(functionTrace.Function),
+						functionTrace.TotalGas)
+					if err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// foldedFrame removes the frame separator from names.
+func foldedFrame(name string) string {
+	return strings.ReplaceAll(name, ";", "_")
+}
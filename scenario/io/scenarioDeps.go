@@ -0,0 +1,96 @@
+package scenio
+
+import (
+	"path/filepath"
+	"sort"
+
+	fr "github.com/multiversx/mx-chain-scenario-go/scenario/expression/fileresolver"
+	scenjparse "github.com/multiversx/mx-chain-scenario-go/scenario/json/parse"
+	scenmodel "github.com/multiversx/mx-chain-scenario-go/scenario/model"
+)
+
+// dependencyRecorder wraps a FileResolver and records the absolute paths of all the files it resolves.
+// Clones share the same record, so that files resolved in external steps are also captured.
+type dependencyRecorder struct {
+	fr.FileResolver
+	paths map[string]struct{}
+}
+
+var _ fr.FileResolver = (*dependencyRecorder)(nil)
+
+// Clone creates a new recorder, wrapping a clone of the underlying resolver, with the same record.
+func (dr *dependencyRecorder) Clone() fr.FileResolver {
+	return &dependencyRecorder{
+		FileResolver: dr.FileResolver.Clone(),
+		paths:        dr.paths,
+	}
+}
+
+// ResolveFileValue records the resolved path, then delegates to the underlying resolver.
+func (dr *dependencyRecorder) ResolveFileValue(value string) ([]byte, error) {
+	if len(value) > 0 {
+		dr.record(dr.ResolveAbsolutePath(value))
+	}
+	return dr.FileResolver.ResolveFileValue(value)
+}
+
+func (dr *dependencyRecorder) record(filePath string) {
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		absPath = filePath
+	}
+	dr.paths[absPath] = struct{}{}
+}
+
+// ScenarioDependencies yields the absolute paths of all the files a scenario depends on:
+// the scenario file itself, its external steps (recursively)
+// and all files referenced via "file:" and "mxsc:" values.
+// If parsing fails, the dependencies found up to that point are returned alongside the error.
+func (r *ScenarioController) ScenarioDependencies(scenarioPath string) ([]string, error) {
+	parser := r.Parser.Clone()
+	recorder := &dependencyRecorder{
+		FileResolver: parser.ExprInterpreter.FileResolver,
+		paths:        make(map[string]struct{}),
+	}
+	if recorder.FileResolver == nil {
+		recorder.FileResolver = NewDefaultFileResolver()
+	}
+	parser.ExprInterpreter.FileResolver = recorder
+
+	err := collectScenarioDependencies(parser, recorder, scenarioPath)
+
+	dependencies := make([]string, 0, len(recorder.paths))
+	for dependency := range recorder.paths {
+		dependencies = append(dependencies, dependency)
+	}
+	sort.Strings(dependencies)
+	return dependencies, err
+}
+
+func collectScenarioDependencies(parser scenjparse.Parser, recorder *dependencyRecorder, scenarioPath string) error {
+	absPath, err := filepath.Abs(scenarioPath)
+	if err != nil {
+		return err
+	}
+	if _, visited := recorder.paths[absPath]; visited {
+		return nil
+	}
+	recorder.record(absPath)
+
+	scenario, err := ParseScenariosScenario(parser, absPath)
+	if err != nil {
+		return err
+	}
+
+	for _, generalStep := range scenario.Steps {
+		if step, isExternal := generalStep.(*scenmodel.ExternalStepsStep); isExternal {
+			// the context is reset by every parse, so the path is resolved relative to the current file
+			recorder.SetContext(absPath)
+			err = collectScenarioDependencies(parser, recorder, recorder.ResolveAbsolutePath(step.Path))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -7,6 +7,8 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/TwiN/go-color"
 )
@@ -20,39 +22,226 @@ func (r *ScenarioController) RunAllJSONScenariosInDirectory(
 	excludedFilePatterns []string,
 	options *RunScenarioOptions) error {
 
+	report, err := r.RunAllJSONScenariosInDirectoryWithReport(
+		generalTestPath,
+		specificTestPath,
+		allowedSuffix,
+		excludedFilePatterns,
+		options)
+	if err != nil {
+		return err
+	}
+
+	return report.Err()
+}
+
+// RunAllJSONScenariosInDirectoryWithReport works like RunAllJSONScenariosInDirectory,
+// but also yields the structured results of all scenarios.
+// The returned error only signals problems walking the directory, not scenario failures.
+func (r *ScenarioController) RunAllJSONScenariosInDirectoryWithReport(
+	generalTestPath string,
+	specificTestPath string,
+	allowedSuffix string,
+	excludedFilePatterns []string,
+	options *RunScenarioOptions) (*ScenariosReport, error) {
+
+	startTime := time.Now()
 	mainDirPath := path.Join(generalTestPath, specificTestPath)
-	var nrPassed, nrFailed, nrSkipped int
+	testFilePaths, err := collectScenarioPaths(mainDirPath, allowedSuffix)
+	if err != nil {
+		return nil, err
+	}
+	testFilePaths = r.filterScenarioPaths(testFilePaths, generalTestPath, options)
+
+	return r.runScenariosWithReport(startTime, testFilePaths, generalTestPath, excludedFilePatterns, options)
+}
+
+// RunJSONScenariosWithReport runs the given scenario files and yields their structured results.
+// Paths are displayed relative to generalTestPath.
+func (r *ScenarioController) RunJSONScenariosWithReport(
+	generalTestPath string,
+	testFilePaths []string,
+	options *RunScenarioOptions) (*ScenariosReport, error) {
+
+	return r.runScenariosWithReport(time.Now(), testFilePaths, generalTestPath, []string{}, options)
+}
 
+func (r *ScenarioController) runScenariosWithReport(
+	startTime time.Time,
+	testFilePaths []string,
+	generalTestPath string,
+	excludedFilePatterns []string,
+	options *RunScenarioOptions) (*ScenariosReport, error) {
+
+	err := checkWorldSnapshotOptions(options)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*ScenarioResult
+	if options.Parallelism > 1 {
+		results, err = r.runScenariosParallel(testFilePaths, generalTestPath, excludedFilePatterns, options)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		results = r.runScenariosSequential(testFilePaths, generalTestPath, excludedFilePatterns, options)
+	}
+
+	report := &ScenariosReport{
+		Results:  results,
+		Duration: time.Since(startTime),
+	}
+	printScenariosSummary(report)
+	return report, nil
+}
+
+// checkWorldSnapshotOptions rejects the world snapshot options that cannot work when running several scenarios:
+// they would all save to the same file, and parallel workers would all read the loaded one at once.
+func checkWorldSnapshotOptions(options *RunScenarioOptions) error {
+	if len(options.SaveWorldFile) > 0 {
+		return errors.New("world snapshots can only be saved when running a single scenario")
+	}
+	if len(options.LoadWorldFile) > 0 && options.Parallelism > 1 {
+		return errors.New("world snapshots cannot be loaded in parallel scenario runs")
+	}
+	return nil
+}
+
+func collectScenarioPaths(mainDirPath string, allowedSuffix string) ([]string, error) {
+	var testFilePaths []string
 	err := filepath.Walk(mainDirPath, func(testFilePath string, info os.FileInfo, err error) error {
 		if strings.HasSuffix(testFilePath, allowedSuffix) {
-			fmt.Printf("Scenario: %s ... ", shortenTestPath(testFilePath, generalTestPath))
-			if isExcluded(excludedFilePatterns, testFilePath, generalTestPath) {
-				nrSkipped++
-				fmt.Printf("  %s\n", color.Ize(color.Yellow, "skip"))
-			} else {
-				r.Executor.Reset()
-				r.RunsNewTest = true
-				testErr := r.RunSingleJSONScenario(testFilePath, options)
-				if testErr == nil {
-					nrPassed++
-					fmt.Printf("  %s\n", color.Ize(color.Green, "ok"))
-				} else {
-					nrFailed++
-					fmt.Printf("  %s %s\n", color.Ize(color.Red, "FAIL:"), testErr.Error())
-				}
-			}
+			testFilePaths = append(testFilePaths, testFilePath)
 		}
 		return nil
 	})
-	if err != nil {
-		return err
+	return testFilePaths, err
+}
+
+func (r *ScenarioController) runScenariosSequential(
+	testFilePaths []string,
+	generalTestPath string,
+	excludedFilePatterns []string,
+	options *RunScenarioOptions) []*ScenarioResult {
+
+	var results []*ScenarioResult
+	for _, testFilePath := range testFilePaths {
+		fmt.Printf("Scenario: %s ... ", shortenTestPath(testFilePath, generalTestPath))
+		result := r.runScenarioInDirectory(testFilePath, generalTestPath, excludedFilePatterns, options)
+		printScenarioOutcome(result)
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// runScenariosParallel distributes the scenarios to a pool of workers.
+// The first worker uses the controller's own runner, the others get theirs from the RunnerFactory.
+// Results are printed in the same order as in the sequential run, after all workers finish.
+func (r *ScenarioController) runScenariosParallel(
+	testFilePaths []string,
+	generalTestPath string,
+	excludedFilePatterns []string,
+	options *RunScenarioOptions) ([]*ScenarioResult, error) {
+
+	if r.RunnerFactory == nil {
+		return nil, errors.New("parallel scenario execution requires a runner factory")
 	}
-	fmt.Printf("Done. Passed: %d. Failed: %d. Skipped: %d.\n", nrPassed, nrFailed, nrSkipped)
-	if nrFailed > 0 {
-		return errors.New("some tests failed")
+
+	numWorkers := options.Parallelism
+	if numWorkers > len(testFilePaths) {
+		numWorkers = len(testFilePaths)
 	}
 
-	return nil
+	results := make([]*ScenarioResult, len(testFilePaths))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < numWorkers; i++ {
+		worker := r
+		if i > 0 {
+			worker = r.newWorkerController()
+		}
+		wg.Add(1)
+		go func(worker *ScenarioController) {
+			defer wg.Done()
+			if worker != r {
+				defer closeRunner(worker.Executor)
+			}
+			for index := range indexes {
+				results[index] = worker.runScenarioInDirectory(
+					testFilePaths[index],
+					generalTestPath,
+					excludedFilePatterns,
+					options)
+			}
+		}(worker)
+	}
+	for index := range testFilePaths {
+		indexes <- index
+	}
+	close(indexes)
+	wg.Wait()
+
+	for _, result := range results {
+		fmt.Printf("Scenario: %s ... ", shortenTestPath(result.Path, generalTestPath))
+		printScenarioOutcome(result)
+	}
+
+	return results, nil
+}
+
+// newWorkerController creates a controller with a fresh runner and its own parser,
+// so that it can run scenarios independently of the others.
+func (r *ScenarioController) newWorkerController() *ScenarioController {
+	return &ScenarioController{
+		Executor:      r.RunnerFactory(),
+		RunnerFactory: r.RunnerFactory,
+		Parser:        r.Parser.Clone(),
+	}
+}
+
+func closeRunner(runner ScenarioRunner) {
+	if closer, isCloser := runner.(interface{ Close() }); isCloser {
+		closer.Close()
+	}
+}
+
+func (r *ScenarioController) runScenarioInDirectory(
+	testFilePath string,
+	generalTestPath string,
+	excludedFilePatterns []string,
+	options *RunScenarioOptions) *ScenarioResult {
+
+	if r.isScenarioExcluded(testFilePath, generalTestPath, excludedFilePatterns, options) {
+		return newScenarioResult(testFilePath, ScenarioSkipped, 0, nil)
+	}
+
+	r.Executor.Reset()
+	r.RunsNewTest = true
+	return r.RunSingleJSONScenarioWithResult(testFilePath, options)
+}
+
+func printScenarioOutcome(result *ScenarioResult) {
+	switch result.Status {
+	case ScenarioSkipped:
+		fmt.Printf("  %s\n", color.Ize(color.Yellow, "skip"))
+	case ScenarioPassed:
+		if result.UpdatedSteps > 0 {
+			fmt.Printf("  %s, expectations updated in %d step(s)\n", color.Ize(color.Green, "ok"), result.UpdatedSteps)
+			return
+		}
+		fmt.Printf("  %s\n", color.Ize(color.Green, "ok"))
+	default:
+		fmt.Printf("  %s %s\n", color.Ize(color.Red, "FAIL:"), result.ErrorMessage())
+	}
+}
+
+func printScenariosSummary(report *ScenariosReport) {
+	fmt.Printf("Done. Passed: %d. Failed: %d. Skipped: %d.\n",
+		report.CountStatus(ScenarioPassed),
+		report.CountStatus(ScenarioFailed),
+		report.CountStatus(ScenarioSkipped))
 }
 
 func isExcluded(excludedFilePatterns []string, testPath string, generalTestPath string) bool {
@@ -0,0 +1,130 @@
+package scenio
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	scenmodel "github.com/multiversx/mx-chain-scenario-go/scenario/model"
+)
+
+// ValidateFilePatterns checks that all patterns are well-formed globs.
+func ValidateFilePatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		_, err := filepath.Match(pattern, "")
+		if err != nil {
+			return fmt.Errorf("bad file pattern %s: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// matchesAnyPattern checks a scenario path against glob patterns.
+// Patterns are matched against the path relative to the test directory.
+// Patterns without a path separator are also matched against the file name alone.
+func matchesAnyPattern(patterns []string, testPath string, generalTestPath string) bool {
+	relativePath := shortenTestPath(testPath, generalTestPath)
+	fileName := filepath.Base(testPath)
+	for _, pattern := range patterns {
+		if match, _ := filepath.Match(pattern, relativePath); match {
+			return true
+		}
+		if !strings.Contains(pattern, "/") {
+			if match, _ := filepath.Match(pattern, fileName); match {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// filterScenarioPaths keeps only the scenarios selected by the include patterns, the name and the step id filters.
+// Excluded scenarios are not removed here, since they are reported as skipped.
+func (r *ScenarioController) filterScenarioPaths(
+	testFilePaths []string,
+	generalTestPath string,
+	options *RunScenarioOptions) []string {
+
+	var selected []string
+	for _, testFilePath := range testFilePaths {
+		if len(options.IncludePatterns) > 0 &&
+			!matchesAnyPattern(options.IncludePatterns, testFilePath, generalTestPath) {
+			continue
+		}
+		if !r.matchesScenarioFilters(testFilePath, options) {
+			continue
+		}
+		selected = append(selected, testFilePath)
+	}
+	return selected
+}
+
+// matchesScenarioFilters parses the scenario to check its name and the ids of its steps.
+// Scenarios that cannot be parsed are kept, so that the parse error gets reported when running them.
+func (r *ScenarioController) matchesScenarioFilters(testFilePath string, options *RunScenarioOptions) bool {
+	if options.NameFilter == nil && options.StepIDFilter == nil {
+		return true
+	}
+
+	scenario, err := ParseScenariosScenario(r.Parser, testFilePath)
+	if err != nil {
+		return true
+	}
+	if options.NameFilter != nil && !options.NameFilter.MatchString(scenario.Name) {
+		return false
+	}
+	return options.StepIDFilter == nil || hasMatchingStepID(scenario, options.StepIDFilter)
+}
+
+func hasMatchingStepID(scenario *scenmodel.Scenario, stepIDFilter *regexp.Regexp) bool {
+	for _, generalStep := range scenario.Steps {
+		stepID := ""
+		switch step := generalStep.(type) {
+		case *scenmodel.SetStateStep:
+			stepID = step.SetStateIdent
+		case *scenmodel.CheckStateStep:
+			stepID = step.CheckStateIdent
+		case *scenmodel.TxStep:
+			stepID = step.TxIdent
+		}
+		if len(stepID) > 0 && stepIDFilter.MatchString(stepID) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *ScenarioController) isScenarioExcluded(
+	testFilePath string,
+	generalTestPath string,
+	excludedFilePatterns []string,
+	options *RunScenarioOptions) bool {
+
+	return isExcluded(excludedFilePatterns, testFilePath, generalTestPath) ||
+		matchesAnyPattern(options.ExcludePatterns, testFilePath, generalTestPath)
+}
+
+// ListJSONScenariosInDirectory yields the scenarios that RunAllJSONScenariosInDirectory would run,
+// with the same arguments, without running them.
+func (r *ScenarioController) ListJSONScenariosInDirectory(
+	generalTestPath string,
+	specificTestPath string,
+	allowedSuffix string,
+	excludedFilePatterns []string,
+	options *RunScenarioOptions) ([]string, error) {
+
+	testFilePaths, err := collectScenarioPaths(path.Join(generalTestPath, specificTestPath), allowedSuffix)
+	if err != nil {
+		return nil, err
+	}
+
+	var toRun []string
+	for _, testFilePath := range r.filterScenarioPaths(testFilePaths, generalTestPath, options) {
+		if !r.isScenarioExcluded(testFilePath, generalTestPath, excludedFilePatterns, options) {
+			toRun = append(toRun, testFilePath)
+		}
+	}
+	return toRun, nil
+}
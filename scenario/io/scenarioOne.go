@@ -1,33 +1,107 @@
 package scenio
 
 import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
 	scenmodel "github.com/multiversx/mx-chain-scenario-go/scenario/model"
 )
 
 // RunScenarioOptions defines the scenario options component
 type RunScenarioOptions struct {
 	ForceTraceGas bool
+
+	// Parallelism is the number of workers running scenarios concurrently in a directory.
+	// Values of 0 or 1 mean that scenarios are run one after the other.
+	Parallelism int
+
+	// ContinueOnCheckFailure makes the runner report all failed checks of a scenario,
+	// instead of stopping at the first one.
+	ContinueOnCheckFailure bool
+
+	// IncludePatterns, if not empty, restricts directory runs to the scenarios matching at least one of the globs.
+	IncludePatterns []string
+
+	// ExcludePatterns are globs of scenarios to skip in directory runs.
+	ExcludePatterns []string
+
+	// NameFilter, if set, restricts directory runs to the scenarios with a matching "name" field.
+	NameFilter *regexp.Regexp
+
+	// StepIDFilter, if set, restricts directory runs to the scenarios with a step whose "id" matches.
+	// Only the steps of the scenario file itself are considered, not those of its external steps.
+	StepIDFilter *regexp.Regexp
+
+	// UpdateExpectations rewrites the scenario files with the actual values of mismatched expectations,
+	// instead of failing.
+	UpdateExpectations bool
+
+	// LoadWorldFile, if not empty, is a world snapshot loaded before running each scenario,
+	// so that a shared setup does not need to be run again. Not supported in parallel directory runs.
+	LoadWorldFile string
+
+	// SaveWorldFile, if not empty, receives a snapshot of the world after running a scenario, even if it failed.
+	// Only supported when running a single scenario.
+	SaveWorldFile string
+
+	// DecodeWithABI loads the contract ABIs, from .mxsc.json or .abi.json files,
+	// to show storage values, results and events as typed values in failed checks.
+	DecodeWithABI bool
+}
+
+// WorldSnapshotter is implemented by runners that can save the state of their world to a file and load it back.
+type WorldSnapshotter interface {
+	LoadWorldSnapshotFile(fromPath string) error
+	SaveWorldSnapshotFile(toPath string) error
 }
 
 func applyScenarioOptions(scenario *scenmodel.Scenario, options *RunScenarioOptions) {
 	if options.ForceTraceGas {
 		scenario.TraceGas = true
 	}
+	if options.ContinueOnCheckFailure {
+		scenario.ContinueOnCheckFailure = true
+	}
+	if options.UpdateExpectations {
+		scenario.UpdateExpectations = true
+	}
+	if options.DecodeWithABI {
+		scenario.DecodeWithABI = true
+	}
 }
 
 // DefaultRunScenarioOptions creates a new RunScenarioOptions instance
 func DefaultRunScenarioOptions() *RunScenarioOptions {
 	return &RunScenarioOptions{
-		ForceTraceGas: false,
+		ForceTraceGas:          false,
+		Parallelism:            0,
+		ContinueOnCheckFailure: false,
+		IncludePatterns:        nil,
+		ExcludePatterns:        nil,
+		NameFilter:             nil,
+		StepIDFilter:           nil,
+		UpdateExpectations:     false,
+		LoadWorldFile:          "",
+		SaveWorldFile:          "",
+		DecodeWithABI:          false,
 	}
 }
 
 // RunSingleJSONScenario parses and prepares test, then calls testCallback.
 func (r *ScenarioController) RunSingleJSONScenario(contextPath string, options *RunScenarioOptions) error {
+	_, err := r.runSingleJSONScenario(contextPath, options)
+	return err
+}
+
+// runSingleJSONScenario runs a scenario, then rewrites its expectations if required.
+// It also yields the number of steps whose expectations were updated.
+func (r *ScenarioController) runSingleJSONScenario(contextPath string, options *RunScenarioOptions) (int, error) {
 	scenario, parseErr := ParseScenariosScenario(r.Parser, contextPath)
 
 	if parseErr != nil {
-		return parseErr
+		return 0, parseErr
 	}
 
 	if r.RunsNewTest {
@@ -37,5 +111,79 @@ func (r *ScenarioController) RunSingleJSONScenario(contextPath string, options *
 
 	applyScenarioOptions(scenario, options)
 
-	return r.Executor.RunScenario(scenario, r.Parser.ExprInterpreter.FileResolver)
+	err := r.loadWorldSnapshot(options)
+	if err != nil {
+		return 0, err
+	}
+	err = r.Executor.RunScenario(scenario, r.Parser.ExprInterpreter.FileResolver)
+	saveErr := r.saveWorldSnapshot(options)
+	if err == nil {
+		err = saveErr
+	}
+	if !options.UpdateExpectations {
+		return 0, err
+	}
+
+	updater, isUpdater := r.Executor.(ExpectationUpdater)
+	if !isUpdater {
+		return 0, err
+	}
+	updates := updater.ExpectationUpdates()
+	if len(updates) == 0 {
+		return 0, err
+	}
+	updateErr := UpdateScenarioExpectations(contextPath, updates)
+	if updateErr != nil {
+		return 0, fmt.Errorf("could not update expectations of %s: %w", contextPath, updateErr)
+	}
+	return len(updates), err
+}
+
+func (r *ScenarioController) loadWorldSnapshot(options *RunScenarioOptions) error {
+	if len(options.LoadWorldFile) == 0 {
+		return nil
+	}
+	snapshotter, isSnapshotter := r.Executor.(WorldSnapshotter)
+	if !isSnapshotter {
+		return errors.New("the scenario runner cannot load world snapshots")
+	}
+	return snapshotter.LoadWorldSnapshotFile(options.LoadWorldFile)
+}
+
+func (r *ScenarioController) saveWorldSnapshot(options *RunScenarioOptions) error {
+	if len(options.SaveWorldFile) == 0 {
+		return nil
+	}
+	snapshotter, isSnapshotter := r.Executor.(WorldSnapshotter)
+	if !isSnapshotter {
+		return errors.New("the scenario runner cannot save world snapshots")
+	}
+	err := snapshotter.SaveWorldSnapshotFile(options.SaveWorldFile)
+	if err != nil {
+		return fmt.Errorf("could not save world snapshot: %w", err)
+	}
+	return nil
+}
+
+// RunSingleJSONScenarioWithResult works like RunSingleJSONScenario,
+// but yields a structured result, including the running time and the failing step, if any.
+func (r *ScenarioController) RunSingleJSONScenarioWithResult(contextPath string, options *RunScenarioOptions) *ScenarioResult {
+	startTime := time.Now()
+	updatedSteps, err := r.runSingleJSONScenario(contextPath, options)
+	duration := time.Since(startTime)
+
+	var result *ScenarioResult
+	if err != nil {
+		result = newScenarioResult(contextPath, ScenarioFailed, duration, err)
+	} else {
+		result = newScenarioResult(contextPath, ScenarioPassed, duration, nil)
+	}
+	if profiler, isProfiler := r.Executor.(TxProfiler); isProfiler {
+		result.TxProfiles = profiler.TxProfiles()
+	}
+	if tracer, isTracer := r.Executor.(GasTracer); isTracer {
+		result.GasTraces = tracer.TxGasTraces()
+	}
+	result.UpdatedSteps = updatedSteps
+	return result
 }
@@ -0,0 +1,168 @@
+package scenio
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+	"time"
+)
+
+// TxProfile is the wall-clock time and gas measured for a single transaction step.
+type TxProfile struct {
+	StepID   string
+	StepType string
+
+	// StepIndex is the index of the step in the top level scenario.
+	// Transactions in external steps get the index of the externalSteps step.
+	StepIndex int
+
+	// Contract is the called or deployed contract address, empty for transactions that do not reach a contract.
+	Contract string
+	Endpoint string
+
+	Duration time.Duration
+	GasUsed  uint64
+}
+
+// TxProfiler is implemented by runners that measure the transaction steps they execute.
+type TxProfiler interface {
+	// TxProfiles yields the measurements of the transactions in the last scenario run, including external steps.
+	TxProfiles() []*TxProfile
+}
+
+// ProfileFormat is the format in which a ScenariosProfile is written.
+type ProfileFormat string
+
+const (
+	// ProfileFormatTable is a human readable table, one section per aggregation.
+	ProfileFormatTable ProfileFormat = "table"
+
+	// ProfileFormatJSON is a JSON representation of the profile, meant for comparing runs.
+	ProfileFormatJSON ProfileFormat = "json"
+)
+
+// ParseProfileFormat converts a format name to a ProfileFormat.
+func ParseProfileFormat(name string) (ProfileFormat, error) {
+	switch ProfileFormat(name) {
+	case ProfileFormatTable:
+		return ProfileFormatTable, nil
+	case ProfileFormatJSON:
+		return ProfileFormatJSON, nil
+	default:
+		return "", fmt.Errorf("unknown profile format: %s", name)
+	}
+}
+
+// ProfileEntry aggregates the transactions sharing the same key.
+type ProfileEntry struct {
+	Key      string        `json:"key"`
+	TxCount  int           `json:"txCount"`
+	Duration time.Duration `json:"durationNanos"`
+	GasUsed  uint64        `json:"gasUsed"`
+}
+
+// ScenariosProfile aggregates the transaction measurements of a scenario run
+// per scenario, per contract and per contract endpoint.
+// Entries are sorted by gas used, most expensive first.
+type ScenariosProfile struct {
+	Scenarios []*ProfileEntry `json:"scenarios"`
+	Contracts []*ProfileEntry `json:"contracts"`
+	Endpoints []*ProfileEntry `json:"endpoints"`
+}
+
+// NewScenariosProfile aggregates the transaction profiles of all scenarios in the report.
+func NewScenariosProfile(report *ScenariosReport) *ScenariosProfile {
+	scenarios := newProfileAggregator()
+	contracts := newProfileAggregator()
+	endpoints := newProfileAggregator()
+	for _, result := range report.Results {
+		for _, txProfile := range result.TxProfiles {
+			scenarios.add(result.Path, txProfile)
+			if len(txProfile.Contract) > 0 {
+				contracts.add(txProfile.Contract, txProfile)
+				endpoints.add(fmt.Sprintf("%s %s", txProfile.Contract, txProfile.Endpoint), txProfile)
+			}
+		}
+	}
+
+	return &ScenariosProfile{
+		Scenarios: scenarios.sortedEntries(),
+		Contracts: contracts.sortedEntries(),
+		Endpoints: endpoints.sortedEntries(),
+	}
+}
+
+type profileAggregator struct {
+	entries map[string]*ProfileEntry
+}
+
+func newProfileAggregator() *profileAggregator {
+	return &profileAggregator{
+		entries: make(map[string]*ProfileEntry),
+	}
+}
+
+func (pa *profileAggregator) add(key string, txProfile *TxProfile) {
+	entry, found := pa.entries[key]
+	if !found {
+		entry = &ProfileEntry{Key: key}
+		pa.entries[key] = entry
+	}
+	entry.TxCount++
+	entry.Duration += txProfile.Duration
+	entry.GasUsed += txProfile.GasUsed
+}
+
+func (pa *profileAggregator) sortedEntries() []*ProfileEntry {
+	entries := make([]*ProfileEntry, 0, len(pa.entries))
+	for _, entry := range pa.entries {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].GasUsed != entries[j].GasUsed {
+			return entries[i].GasUsed > entries[j].GasUsed
+		}
+		return entries[i].Key < entries[j].Key
+	})
+	return entries
+}
+
+// WriteScenariosProfile writes the profile in the given format.
+func WriteScenariosProfile(profile *ScenariosProfile, format ProfileFormat, writer io.Writer) error {
+	switch format {
+	case ProfileFormatTable:
+		return writeProfileTable(profile, writer)
+	case ProfileFormatJSON:
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "    ")
+		return encoder.Encode(profile)
+	default:
+		return fmt.Errorf("unknown profile format: %s", format)
+	}
+}
+
+func writeProfileTable(profile *ScenariosProfile, writer io.Writer) error {
+	tw := tabwriter.NewWriter(writer, 0, 4, 2, ' ', 0)
+	sections := []struct {
+		title   string
+		entries []*ProfileEntry
+	}{
+		{title: "SCENARIO", entries: profile.Scenarios},
+		{title: "CONTRACT", entries: profile.Contracts},
+		{title: "ENDPOINT", entries: profile.Endpoints},
+	}
+	for _, section := range sections {
+		_, _ = fmt.Fprintf(tw, "%s\tTXS\tTIME\tGAS\t\n", section.title)
+		for _, entry := range section.entries {
+			_, _ = fmt.Fprintf(tw, "%s\t%d\t%s\t%d\t\n",
+				entry.Key,
+				entry.TxCount,
+				entry.Duration.Round(time.Microsecond),
+				entry.GasUsed)
+		}
+		_, _ = fmt.Fprintln(tw, "\t\t\t\t")
+	}
+	return tw.Flush()
+}
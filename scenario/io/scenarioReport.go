@@ -0,0 +1,212 @@
+package scenio
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ReportFormat is the format in which a ScenariosReport is written.
+type ReportFormat string
+
+const (
+	// ReportFormatJUnit is the JUnit XML format, understood by most CI test dashboards.
+	ReportFormatJUnit ReportFormat = "junit"
+
+	// ReportFormatJSON is a plain JSON representation of the report.
+	ReportFormatJSON ReportFormat = "json"
+)
+
+// ParseReportFormat converts a format name to a ReportFormat.
+func ParseReportFormat(name string) (ReportFormat, error) {
+	switch ReportFormat(name) {
+	case ReportFormatJUnit:
+		return ReportFormatJUnit, nil
+	case ReportFormatJSON:
+		return ReportFormatJSON, nil
+	default:
+		return "", fmt.Errorf("unknown report format: %s", name)
+	}
+}
+
+// WriteScenariosReportFile saves the report to a file, in the given format.
+func WriteScenariosReportFile(report *ScenariosReport, format ReportFormat, toPath string) error {
+	err := os.MkdirAll(filepath.Dir(toPath), os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(toPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	return WriteScenariosReport(report, format, file)
+}
+
+// WriteScenariosReport writes the report in the given format.
+func WriteScenariosReport(report *ScenariosReport, format ReportFormat, writer io.Writer) error {
+	switch format {
+	case ReportFormatJUnit:
+		return writeJUnitReport(report, writer)
+	case ReportFormatJSON:
+		return writeJSONReport(report, writer)
+	default:
+		return fmt.Errorf("unknown report format: %s", format)
+	}
+}
+
+type jsonReport struct {
+	Passed          int                  `json:"passed"`
+	Failed          int                  `json:"failed"`
+	Skipped         int                  `json:"skipped"`
+	DurationSeconds float64              `json:"durationSeconds"`
+	Scenarios       []jsonScenarioResult `json:"scenarios"`
+}
+
+type jsonScenarioResult struct {
+	Path            string  `json:"path"`
+	Status          string  `json:"status"`
+	DurationSeconds float64 `json:"durationSeconds"`
+	Error           string  `json:"error,omitempty"`
+	FailedStepIndex *int    `json:"failedStepIndex,omitempty"`
+	FailedStepID    string  `json:"failedStepId,omitempty"`
+	FailedStepType  string  `json:"failedStepType,omitempty"`
+}
+
+func writeJSONReport(report *ScenariosReport, writer io.Writer) error {
+	jReport := jsonReport{
+		Passed:          report.CountStatus(ScenarioPassed),
+		Failed:          report.CountStatus(ScenarioFailed),
+		Skipped:         report.CountStatus(ScenarioSkipped),
+		DurationSeconds: report.Duration.Seconds(),
+		Scenarios:       make([]jsonScenarioResult, 0, len(report.Results)),
+	}
+	for _, result := range report.Results {
+		jResult := jsonScenarioResult{
+			Path:            result.Path,
+			Status:          string(result.Status),
+			DurationSeconds: result.Duration.Seconds(),
+			Error:           result.ErrorMessage(),
+			FailedStepID:    result.FailedStepID,
+			FailedStepType:  result.FailedStepType,
+		}
+		if result.FailedStepIndex >= 0 {
+			stepIndex := result.FailedStepIndex
+			jResult.FailedStepIndex = &stepIndex
+		}
+		jReport.Scenarios = append(jReport.Scenarios, jResult)
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "    ")
+	return encoder.Encode(jReport)
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *struct{}     `xml:"skipped,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+func writeJUnitReport(report *ScenariosReport, writer io.Writer) error {
+	suite := junitTestSuite{
+		Name:     "scenarios",
+		Tests:    len(report.Results),
+		Failures: report.CountStatus(ScenarioFailed),
+		Skipped:  report.CountStatus(ScenarioSkipped),
+		Time:     formatSeconds(report.Duration.Seconds()),
+	}
+	for _, result := range report.Results {
+		testCase := junitTestCase{
+			Name:      filepath.Base(result.Path),
+			ClassName: filepath.Dir(result.Path),
+			Time:      formatSeconds(result.Duration.Seconds()),
+		}
+		switch result.Status {
+		case ScenarioSkipped:
+			testCase.Skipped = &struct{}{}
+		case ScenarioFailed:
+			testCase.Failure = &junitFailure{
+				Message: firstLine(result.ErrorMessage()),
+				Type:    result.FailedStepType,
+				Text:    junitFailureText(result),
+			}
+		}
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	suites := junitTestSuites{
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Skipped:  suite.Skipped,
+		Time:     suite.Time,
+		Suites:   []junitTestSuite{suite},
+	}
+
+	_, err := io.WriteString(writer, xml.Header)
+	if err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(writer)
+	encoder.Indent("", "    ")
+	err = encoder.Encode(suites)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(writer, "\n")
+	return err
+}
+
+func junitFailureText(result *ScenarioResult) string {
+	if result.FailedStepIndex < 0 {
+		return result.ErrorMessage()
+	}
+	stepDescription := fmt.Sprintf("step %d (%s)", result.FailedStepIndex, result.FailedStepType)
+	if len(result.FailedStepID) > 0 {
+		stepDescription = fmt.Sprintf("step %d (%s \"%s\")", result.FailedStepIndex, result.FailedStepType, result.FailedStepID)
+	}
+	return fmt.Sprintf("%s: %s", stepDescription, result.ErrorMessage())
+}
+
+func formatSeconds(seconds float64) string {
+	return fmt.Sprintf("%.3f", seconds)
+}
+
+func firstLine(message string) string {
+	before, _, _ := strings.Cut(message, "\n")
+	return before
+}
@@ -0,0 +1,113 @@
+package scenio
+
+import (
+	"errors"
+	"time"
+)
+
+// ScenarioStatus is the outcome of running a scenario.
+type ScenarioStatus string
+
+const (
+	// ScenarioPassed indicates that all scenario steps ran successfully.
+	ScenarioPassed ScenarioStatus = "passed"
+
+	// ScenarioFailed indicates that the scenario could not be parsed, or one of its steps failed.
+	ScenarioFailed ScenarioStatus = "failed"
+
+	// ScenarioSkipped indicates that the scenario was excluded from the run.
+	ScenarioSkipped ScenarioStatus = "skipped"
+)
+
+// StepError is returned by runners when a scenario step fails.
+// It keeps the original error message, but adds the position of the step in the scenario.
+type StepError struct {
+	StepIndex int
+	StepID    string
+	StepType  string
+	Err       error
+}
+
+// Error yields the message of the underlying error, unchanged.
+func (se *StepError) Error() string {
+	return se.Err.Error()
+}
+
+// Unwrap yields the underlying error.
+func (se *StepError) Unwrap() error {
+	return se.Err
+}
+
+// ScenarioResult is the structured outcome of running a scenario file.
+type ScenarioResult struct {
+	Path     string
+	Status   ScenarioStatus
+	Duration time.Duration
+	Err      error
+
+	// FailedStepIndex is the index of the failing step in the scenario, -1 if no step failed.
+	FailedStepIndex int
+	FailedStepID    string
+	FailedStepType  string
+
+	// TxProfiles are the transaction measurements, if the runner is a TxProfiler.
+	TxProfiles []*TxProfile
+
+	// GasTraces are the VM gas traces of the transactions, if the runner is a GasTracer and gas tracing was on.
+	GasTraces []*TxGasTrace
+
+	// UpdatedSteps is the number of steps whose expectations were rewritten, in update mode.
+	UpdatedSteps int
+}
+
+func newScenarioResult(path string, status ScenarioStatus, duration time.Duration, err error) *ScenarioResult {
+	result := &ScenarioResult{
+		Path:            path,
+		Status:          status,
+		Duration:        duration,
+		Err:             err,
+		FailedStepIndex: -1,
+	}
+
+	var stepErr *StepError
+	if errors.As(err, &stepErr) {
+		result.FailedStepIndex = stepErr.StepIndex
+		result.FailedStepID = stepErr.StepID
+		result.FailedStepType = stepErr.StepType
+	}
+
+	return result
+}
+
+// ErrorMessage yields the error message of a failed scenario, or "" otherwise.
+func (sr *ScenarioResult) ErrorMessage() string {
+	if sr.Err == nil {
+		return ""
+	}
+	return sr.Err.Error()
+}
+
+// ScenariosReport aggregates the results of a scenario run.
+type ScenariosReport struct {
+	Results  []*ScenarioResult
+	Duration time.Duration
+}
+
+// CountStatus yields the number of scenarios with the given status.
+func (report *ScenariosReport) CountStatus(status ScenarioStatus) int {
+	count := 0
+	for _, result := range report.Results {
+		if result.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// Err yields an error if any of the scenarios failed.
+func (report *ScenariosReport) Err() error {
+	if report.CountStatus(ScenarioFailed) > 0 {
+		return errors.New("some tests failed")
+	}
+	return nil
+}
@@ -17,11 +17,16 @@ type ScenarioRunner interface {
 	RunScenario(*scenmodel.Scenario, fr.FileResolver) error
 }
 
+// ScenarioRunnerFactory creates independent ScenarioRunner instances.
+// Used for running multiple scenarios in parallel, each runner with its own world.
+type ScenarioRunnerFactory func() ScenarioRunner
+
 // ScenarioController is a component that can run json scenarios, using a provided executor.
 type ScenarioController struct {
-	Executor    ScenarioRunner
-	RunsNewTest bool
-	Parser      scenjparse.Parser
+	Executor      ScenarioRunner
+	RunnerFactory ScenarioRunnerFactory
+	RunsNewTest   bool
+	Parser        scenjparse.Parser
 }
 
 // NewScenarioController creates new ScenarioController instance.
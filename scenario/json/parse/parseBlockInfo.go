@@ -59,3 +59,36 @@ func (p *Parser) processBlockInfo(blockInfoRaw oj.OJsonObject) (*scenmodel.Block
 
 	return blockInfo, nil
 }
+
+func (p *Parser) processBlockProduction(blockProductionRaw oj.OJsonObject) (*scenmodel.BlockProduction, error) {
+	blockProductionMap, isMap := blockProductionRaw.(*oj.OJsonMap)
+	if !isMap {
+		return nil, errors.New("unmarshalled block production object is not a map")
+	}
+	blockProduction := &scenmodel.BlockProduction{}
+	var err error
+
+	for _, kvp := range blockProductionMap.OrderedKV {
+		switch kvp.Key {
+		case "roundDurationMs":
+			blockProduction.RoundDurationMs, err = p.processUint64(kvp.Value)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing roundDurationMs: %w", err)
+			}
+		case "txsPerBlock":
+			blockProduction.TxsPerBlock, err = p.processUint64(kvp.Value)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing txsPerBlock: %w", err)
+			}
+		case "roundsPerEpoch":
+			blockProduction.RoundsPerEpoch, err = p.processUint64(kvp.Value)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing roundsPerEpoch: %w", err)
+			}
+		default:
+			return nil, fmt.Errorf("unknown block production field: %s", kvp.Key)
+		}
+	}
+
+	return blockProduction, nil
+}
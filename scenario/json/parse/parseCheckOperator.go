@@ -0,0 +1,94 @@
+package scenjsonparse
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+
+	scenmodel "github.com/multiversx/mx-chain-scenario-go/scenario/model"
+)
+
+// regexOperandPrefix is the only form regex patterns can be written in, the pattern being taken verbatim.
+const regexOperandPrefix = "str:"
+
+type checkOperatorSyntax struct {
+	prefix   string
+	operator scenmodel.CheckOperator
+}
+
+// numberCheckOperators are the operators of number checks. Longer prefixes come first, so ">=" is not taken for ">".
+var numberCheckOperators = []checkOperatorSyntax{
+	{prefix: ">=", operator: scenmodel.CheckOperatorGreaterOrEqual},
+	{prefix: "<=", operator: scenmodel.CheckOperatorLessOrEqual},
+	{prefix: "!=", operator: scenmodel.CheckOperatorNotEqual},
+	{prefix: ">", operator: scenmodel.CheckOperatorGreater},
+	{prefix: "<", operator: scenmodel.CheckOperatorLess},
+	{prefix: "between ", operator: scenmodel.CheckOperatorBetween},
+}
+
+// bytesCheckOperators are the operators of byte checks. Words are followed by a space, to tell them apart from values.
+var bytesCheckOperators = []checkOperatorSyntax{
+	{prefix: "!=", operator: scenmodel.CheckOperatorNotEqual},
+	{prefix: "prefix ", operator: scenmodel.CheckOperatorPrefix},
+	{prefix: "suffix ", operator: scenmodel.CheckOperatorSuffix},
+	{prefix: "contains ", operator: scenmodel.CheckOperatorContains},
+	{prefix: "regex ", operator: scenmodel.CheckOperatorRegex},
+}
+
+// splitCheckOperator separates the operator of a check from its operand.
+// Checks without an operator are equality checks, with the entire string as operand.
+func splitCheckOperator(check string, operators []checkOperatorSyntax) (scenmodel.CheckOperator, string) {
+	for _, syntax := range operators {
+		if strings.HasPrefix(check, syntax.prefix) {
+			return syntax.operator, strings.TrimSpace(check[len(syntax.prefix):])
+		}
+	}
+	return scenmodel.CheckOperatorEqual, check
+}
+
+// parseNumberCheckBounds interprets the operand of a number check: a single value, or 2 for "between".
+func (p *Parser) parseNumberCheckBounds(
+	operator scenmodel.CheckOperator,
+	operand string,
+	format bigIntParseFormat,
+) (value *big.Int, upperBound *big.Int, err error) {
+	if operator != scenmodel.CheckOperatorBetween {
+		value, err = p.parseBigInt(operand, format)
+		return value, nil, err
+	}
+
+	bounds := strings.Fields(operand)
+	if len(bounds) != 2 {
+		return nil, nil, fmt.Errorf("between expects 2 values, got: %s", operand)
+	}
+	value, err = p.parseBigInt(bounds[0], format)
+	if err != nil {
+		return nil, nil, fmt.Errorf("bad lower bound: %w", err)
+	}
+	upperBound, err = p.parseBigInt(bounds[1], format)
+	if err != nil {
+		return nil, nil, fmt.Errorf("bad upper bound: %w", err)
+	}
+	if value.Cmp(upperBound) > 0 {
+		return nil, nil, errors.New("between lower bound is greater than the upper bound")
+	}
+	return value, upperBound, nil
+}
+
+func (p *Parser) parseBytesCheckOperand(operator scenmodel.CheckOperator, operand string) ([]byte, *regexp.Regexp, error) {
+	if operator != scenmodel.CheckOperatorRegex {
+		value, err := p.ExprInterpreter.InterpretString(operand)
+		return value, nil, err
+	}
+
+	if !strings.HasPrefix(operand, regexOperandPrefix) {
+		return nil, nil, fmt.Errorf("regex pattern must start with %s, got: %s", regexOperandPrefix, operand)
+	}
+	regex, err := regexp.Compile(operand[len(regexOperandPrefix):])
+	if err != nil {
+		return nil, nil, fmt.Errorf("bad regex: %w", err)
+	}
+	return nil, regex, nil
+}
@@ -27,12 +27,19 @@ func (p *Parser) processLogList(logsRaw oj.OJsonObject) (scenmodel.LogList, erro
 		List:             nil,
 	}
 	var err error
-	for _, logRaw := range logList.AsList() {
+	for logIndex, logRaw := range logList.AsList() {
 		switch logItem := logRaw.(type) {
 		case *oj.OJsonString:
-			if logItem.Value == "+" {
+			switch {
+			case logItem.Value == "+" && result.Mode == scenmodel.LogCheckOrdered:
 				result.MoreAllowedAtEnd = true
-			} else {
+			case logItem.Value == "+":
+				return scenmodel.LogList{}, fmt.Errorf("\"+\" is only allowed for ordered logs, use %s to allow other logs", scenmodel.LogCheckModeContains)
+			case logItem.Value == scenmodel.LogCheckModeUnordered && logIndex == 0:
+				result.Mode = scenmodel.LogCheckUnordered
+			case logItem.Value == scenmodel.LogCheckModeContains && logIndex == 0:
+				result.Mode = scenmodel.LogCheckContains
+			default:
 				return scenmodel.LogList{}, errors.New("unmarshalled log entry is an invalid string")
 			}
 		case *oj.OJsonMap:
@@ -63,6 +70,15 @@ func (p *Parser) processLogList(logsRaw oj.OJsonObject) (scenmodel.LogList, erro
 					if err != nil {
 						return scenmodel.LogList{}, fmt.Errorf("invalid log data: %w", err)
 					}
+				case "count":
+					if result.Mode == scenmodel.LogCheckOrdered {
+						return scenmodel.LogList{}, fmt.Errorf("log count is only allowed for %s or %s logs",
+							scenmodel.LogCheckModeUnordered, scenmodel.LogCheckModeContains)
+					}
+					logEntry.Count, err = p.processCheckUint64(kvp.Value)
+					if err != nil {
+						return scenmodel.LogList{}, fmt.Errorf("invalid log count: %w", err)
+					}
 				default:
 					return scenmodel.LogList{}, fmt.Errorf("unknown log field: %s", kvp.Key)
 				}
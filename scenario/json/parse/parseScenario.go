@@ -50,6 +50,17 @@ func (p *Parser) ParseScenarioFile(jsonString []byte) (*scenmodel.Scenario, erro
 				return nil, errors.New("scenario traceGas flag is not boolean")
 			}
 			scenario.TraceGas = bool(*traceGasOJ)
+		case "multiShard":
+			multiShardOJ, isBool := kvp.Value.(*oj.OJsonBool)
+			if !isBool {
+				return nil, errors.New("scenario multiShard flag is not boolean")
+			}
+			scenario.MultiShard = bool(*multiShardOJ)
+		case "blockProduction":
+			scenario.BlockProduction, err = p.processBlockProduction(kvp.Value)
+			if err != nil {
+				return nil, fmt.Errorf("bad scenario blockProduction: %w", err)
+			}
 		case "gasSchedule":
 			scenario.GasSchedule, err = p.parseGasSchedule(kvp.Value)
 			if err != nil {
@@ -244,11 +255,46 @@ func (p *Parser) processScenarioStep(stepObj oj.OJsonObject) (scenmodel.Step, er
 				if err != nil {
 					return nil, fmt.Errorf("bad check state step comment: %w", err)
 				}
+			case "path":
+				step.Path, err = p.parseString(kvp.Value)
+				if err != nil {
+					return nil, fmt.Errorf("bad dump state step path: %w", err)
+				}
+			case "format":
+				step.Format, err = p.parseDumpStateFormat(kvp.Value)
+				if err != nil {
+					return nil, fmt.Errorf("bad dump state step format: %w", err)
+				}
+			case "accounts":
+				step.Accounts, err = p.parseAccountAddressList(kvp.Value)
+				if err != nil {
+					return nil, fmt.Errorf("bad dump state step accounts: %w", err)
+				}
 			default:
 				return nil, fmt.Errorf("invalid check state field: %s", kvp.Key)
 			}
 		}
 		return step, nil
+	case scenmodel.StepNameSaveState:
+		step := &scenmodel.SaveStateStep{}
+		step.Comment, step.Name, err = p.parseStateNameStep(stepMap)
+		if err != nil {
+			return nil, fmt.Errorf("bad save state step: %w", err)
+		}
+		return step, nil
+	case scenmodel.StepNameRestoreState:
+		step := &scenmodel.RestoreStateStep{}
+		step.Comment, step.Name, err = p.parseStateNameStep(stepMap)
+		if err != nil {
+			return nil, fmt.Errorf("bad restore state step: %w", err)
+		}
+		return step, nil
+	case scenmodel.StepNameAdvanceBlocks:
+		step, err := p.parseAdvanceBlocksStep(stepMap)
+		if err != nil {
+			return nil, fmt.Errorf("bad advance blocks step: %w", err)
+		}
+		return step, nil
 	case scenmodel.StepNameScCall:
 		return p.parseTxStep(scenmodel.ScCall, stepMap)
 	case scenmodel.StepNameScDeploy:
@@ -266,6 +312,93 @@ func (p *Parser) processScenarioStep(stepObj oj.OJsonObject) (scenmodel.Step, er
 	}
 }
 
+func (p *Parser) parseDumpStateFormat(value oj.OJsonObject) (string, error) {
+	format, err := p.parseString(value)
+	if err != nil {
+		return "", err
+	}
+	switch format {
+	case scenmodel.DumpFormatSetState, scenmodel.DumpFormatCheckState:
+		return format, nil
+	default:
+		return "", fmt.Errorf("invalid format %s, expected %s or %s", format, scenmodel.DumpFormatSetState, scenmodel.DumpFormatCheckState)
+	}
+}
+
+func (p *Parser) parseAccountAddressList(value oj.OJsonObject) ([]scenmodel.JSONBytesFromString, error) {
+	addressesRaw, err := p.processStringList(value)
+	if err != nil {
+		return nil, err
+	}
+	var addresses []scenmodel.JSONBytesFromString
+	for _, addressRaw := range addressesRaw {
+		address, err := p.parseAccountAddress(addressRaw)
+		if err != nil {
+			return nil, fmt.Errorf("bad account address %s: %w", addressRaw, err)
+		}
+		addresses = append(addresses, address)
+	}
+	return addresses, nil
+}
+
+// parseStateNameStep parses the fields of the saveState and restoreState steps, which are the same.
+func (p *Parser) parseStateNameStep(stepMap *oj.OJsonMap) (comment string, name string, err error) {
+	for _, kvp := range stepMap.OrderedKV {
+		switch kvp.Key {
+		case "step":
+		case "comment":
+			comment, err = p.parseString(kvp.Value)
+			if err != nil {
+				return "", "", fmt.Errorf("bad comment: %w", err)
+			}
+		case "name":
+			name, err = p.parseString(kvp.Value)
+			if err != nil {
+				return "", "", fmt.Errorf("bad name: %w", err)
+			}
+		default:
+			return "", "", fmt.Errorf("invalid field: %s", kvp.Key)
+		}
+	}
+	if len(name) == 0 {
+		return "", "", errors.New("missing name")
+	}
+	return comment, name, nil
+}
+
+func (p *Parser) parseAdvanceBlocksStep(stepMap *oj.OJsonMap) (*scenmodel.AdvanceBlocksStep, error) {
+	step := &scenmodel.AdvanceBlocksStep{}
+	var err error
+	for _, kvp := range stepMap.OrderedKV {
+		switch kvp.Key {
+		case "step":
+		case "comment":
+			step.Comment, err = p.parseString(kvp.Value)
+			if err != nil {
+				return nil, fmt.Errorf("bad comment: %w", err)
+			}
+		case "blocks":
+			step.Blocks, err = p.processUint64(kvp.Value)
+			if err != nil {
+				return nil, fmt.Errorf("bad blocks: %w", err)
+			}
+		case "seconds":
+			step.Seconds, err = p.processUint64(kvp.Value)
+			if err != nil {
+				return nil, fmt.Errorf("bad seconds: %w", err)
+			}
+		default:
+			return nil, fmt.Errorf("invalid field: %s", kvp.Key)
+		}
+	}
+	hasBlocks := !step.Blocks.OriginalEmpty()
+	hasSeconds := !step.Seconds.OriginalEmpty()
+	if hasBlocks == hasSeconds {
+		return nil, errors.New("exactly one of blocks and seconds must be given")
+	}
+	return step, nil
+}
+
 func (p *Parser) parseTxStep(txType scenmodel.TransactionType, stepMap *oj.OJsonMap) (*scenmodel.TxStep, error) {
 	step := &scenmodel.TxStep{}
 	var err error
@@ -284,6 +417,11 @@ func (p *Parser) parseTxStep(txType scenmodel.TransactionType, stepMap *oj.OJson
 			if err != nil {
 				return nil, fmt.Errorf("bad tx step displayLogs: %w", err)
 			}
+		case "displayDiff":
+			step.DisplayDiff, err = p.parseBool(kvp.Value)
+			if err != nil {
+				return nil, fmt.Errorf("bad tx step displayDiff: %w", err)
+			}
 		case "comment":
 			step.Comment, err = p.parseString(kvp.Value)
 			if err != nil {
@@ -1,6 +1,7 @@
 package scenjsonparse
 
 import (
+	"strings"
 	"testing"
 
 	scenmodel "github.com/multiversx/mx-chain-scenario-go/scenario/model"
@@ -55,3 +56,73 @@ func TestParseScenario(t *testing.T) {
 	require.Equal(t, "scCall", step.StepTypeName())
 	require.Equal(t, true, step.(*scenmodel.TxStep).DisplayLogs)
 }
+
+func TestParseTypedArguments(t *testing.T) {
+	snippet := `
+	{
+		"step": "scCall",
+		"tx": {
+			"from": "address:A",
+			"to": "sc:B",
+			"function": "f",
+			"typedArguments": %s,
+			"gasLimit": "0x100000",
+			"gasPrice": "0"
+		}
+	}`
+
+	p := Parser{}
+	step, err := p.ParseScenarioStep(strings.Replace(snippet, "%s", `["5", {"type": "u32", "value": "6"}]`, 1))
+	require.Nil(t, err)
+	tx := step.(*scenmodel.TxStep).Tx
+	require.Nil(t, tx.Arguments)
+	require.False(t, tx.TypedArguments.Named)
+	require.Len(t, tx.TypedArguments.List, 2)
+	require.Equal(t, []byte{5}, tx.TypedArguments.List[0].Plain.Value)
+	require.Equal(t, "u32", tx.TypedArguments.List[1].Type)
+
+	step, err = p.ParseScenarioStep(strings.Replace(snippet, "%s", `{"amount": "5", "to": "address:C"}`, 1))
+	require.Nil(t, err)
+	tx = step.(*scenmodel.TxStep).Tx
+	require.True(t, tx.TypedArguments.Named)
+	require.Equal(t, "amount", tx.TypedArguments.List[0].Name)
+	require.Equal(t, "to", tx.TypedArguments.List[1].Name)
+	require.Empty(t, tx.TypedArguments.List[1].Type)
+
+	step, err = p.ParseScenarioStep(strings.Replace(snippet, "%s", `["5", "6"]`, 1))
+	require.Nil(t, err)
+	tx = step.(*scenmodel.TxStep).Tx
+	require.Nil(t, tx.Arguments)
+	require.Len(t, tx.TypedArguments.List, 2)
+
+	_, err = p.ParseScenarioStep(strings.Replace(snippet, "%s", `[{"type": "", "value": "6"}]`, 1))
+	require.NotNil(t, err)
+}
+
+func TestParseArgumentsSubTreeMap(t *testing.T) {
+	// a map with the keys "type" and "value" is a subtree in arguments, its values are concatenated
+	snippet := `
+	{
+		"step": "scCall",
+		"tx": {
+			"from": "address:A",
+			"to": "sc:B",
+			"function": "f",
+			"arguments": ["5", {"type": "u8:1", "value": "u16:2"}],
+			"gasLimit": "0x100000",
+			"gasPrice": "0"
+		}
+	}`
+
+	p := Parser{}
+	step, err := p.ParseScenarioStep(snippet)
+	require.Nil(t, err)
+	tx := step.(*scenmodel.TxStep).Tx
+	require.Nil(t, tx.TypedArguments)
+	require.Len(t, tx.Arguments, 2)
+	require.Equal(t, []byte{5}, tx.Arguments[0].Value)
+	require.Equal(t, []byte{1, 0, 2}, tx.Arguments[1].Value)
+
+	_, err = p.ParseScenarioStep(strings.Replace(snippet, `"gasLimit"`, `"typedArguments": ["6"], "gasLimit"`, 1))
+	require.NotNil(t, err)
+}
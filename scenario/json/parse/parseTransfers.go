@@ -0,0 +1,168 @@
+package scenjsonparse
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/multiversx/mx-chain-core-go/data/vm"
+	oj "github.com/multiversx/mx-chain-scenario-go/orderedjson"
+	scenmodel "github.com/multiversx/mx-chain-scenario-go/scenario/model"
+)
+
+// callTypeNames are the call types transfers can be checked against.
+var callTypeNames = map[string]bool{
+	vm.DirectCallStr:             true,
+	vm.AsynchronousCallStr:       true,
+	vm.AsynchronousCallBackStr:   true,
+	vm.ESDTTransferAndExecuteStr: true,
+	vm.ExecOnDestByCallerStr:     true,
+}
+
+func (p *Parser) processCheckTransfers(transfersRaw oj.OJsonObject) (scenmodel.CheckTransfers, error) {
+	if IsStar(transfersRaw) {
+		return scenmodel.CheckTransfers{
+			IsUnspecified: false,
+			IsStar:        true,
+		}, nil
+	}
+
+	transfersMap, isMap := transfersRaw.(*oj.OJsonMap)
+	if !isMap {
+		return scenmodel.CheckTransfers{}, errors.New("unmarshalled transfers object is not a map")
+	}
+
+	result := scenmodel.CheckTransfers{}
+	for _, kvp := range transfersMap.OrderedKV {
+		if kvp.Key == "+" {
+			result.MoreDestinationsAllowed = true
+			continue
+		}
+
+		to, err := p.parseAccountAddress(kvp.Key)
+		if err != nil {
+			return scenmodel.CheckTransfers{}, fmt.Errorf("invalid transfer destination %s: %w", kvp.Key, err)
+		}
+		transferList, isList := kvp.Value.(*oj.OJsonList)
+		if !isList {
+			return scenmodel.CheckTransfers{}, fmt.Errorf("transfers to %s are not a list", kvp.Key)
+		}
+
+		destination := &scenmodel.CheckTransferDestination{To: to}
+		for _, transferRaw := range transferList.AsList() {
+			transfer, err := p.processCheckTransfer(transferRaw)
+			if err != nil {
+				return scenmodel.CheckTransfers{}, fmt.Errorf("invalid transfer to %s: %w", kvp.Key, err)
+			}
+			destination.Transfers = append(destination.Transfers, transfer)
+		}
+		result.Destinations = append(result.Destinations, destination)
+	}
+
+	return result, nil
+}
+
+func (p *Parser) processCheckTransfer(transferRaw oj.OJsonObject) (*scenmodel.CheckTransfer, error) {
+	transferMap, isMap := transferRaw.(*oj.OJsonMap)
+	if !isMap {
+		return nil, errors.New("unmarshalled transfer is not a map")
+	}
+
+	transfer := scenmodel.NewCheckTransfer()
+	var err error
+	for _, kvp := range transferMap.OrderedKV {
+		switch kvp.Key {
+		case "from":
+			transfer.From, err = p.parseCheckBytes(kvp.Value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid from: %w", err)
+			}
+		case "egldValue":
+			transfer.EGLDValue, err = p.processCheckBigInt(kvp.Value, bigIntUnsignedBytes)
+			if err != nil {
+				return nil, fmt.Errorf("invalid egldValue: %w", err)
+			}
+		case "esdtValue":
+			transfer.ESDTValue, err = p.processCheckESDTTransfers(kvp.Value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid esdtValue: %w", err)
+			}
+		case "function":
+			transfer.Function, err = p.parseCheckBytes(kvp.Value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid function: %w", err)
+			}
+		case "arguments":
+			transfer.Arguments, err = p.parseCheckValueList(kvp.Value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid arguments: %w", err)
+			}
+		case "callType":
+			transfer.CallType, err = p.parseString(kvp.Value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid callType: %w", err)
+			}
+			if !callTypeNames[transfer.CallType] {
+				return nil, fmt.Errorf("unknown callType: %s", transfer.CallType)
+			}
+		case "gasLimit":
+			transfer.GasLimit, err = p.processCheckUint64(kvp.Value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid gasLimit: %w", err)
+			}
+		case "gasLocked":
+			transfer.GasLocked, err = p.processCheckUint64(kvp.Value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid gasLocked: %w", err)
+			}
+		default:
+			return nil, fmt.Errorf("unknown transfer field: %s", kvp.Key)
+		}
+	}
+
+	return transfer, nil
+}
+
+func (p *Parser) processCheckESDTTransfers(esdtRaw oj.OJsonObject) ([]*scenmodel.CheckESDTTransfer, error) {
+	esdtList, isList := esdtRaw.(*oj.OJsonList)
+	if !isList {
+		return nil, errors.New("esdtValue is not a list")
+	}
+
+	esdtTransfers := make([]*scenmodel.CheckESDTTransfer, 0)
+	for _, esdtItemRaw := range esdtList.AsList() {
+		esdtItemMap, isMap := esdtItemRaw.(*oj.OJsonMap)
+		if !isMap {
+			return nil, errors.New("esdtValue item is not a map")
+		}
+
+		esdtTransfer := &scenmodel.CheckESDTTransfer{
+			Nonce: scenmodel.JSONCheckUint64Unspecified(),
+			Value: scenmodel.JSONCheckBigIntUnspecified(),
+		}
+		var err error
+		for _, kvp := range esdtItemMap.OrderedKV {
+			switch kvp.Key {
+			case "tokenIdentifier":
+				esdtTransfer.TokenIdentifier, err = p.processStringAsByteArray(kvp.Value)
+				if err != nil {
+					return nil, fmt.Errorf("invalid ESDT token name: %w", err)
+				}
+			case "nonce":
+				esdtTransfer.Nonce, err = p.processCheckUint64(kvp.Value)
+				if err != nil {
+					return nil, fmt.Errorf("invalid ESDT nonce: %w", err)
+				}
+			case "value":
+				esdtTransfer.Value, err = p.processCheckBigInt(kvp.Value, bigIntUnsignedBytes)
+				if err != nil {
+					return nil, fmt.Errorf("invalid ESDT value: %w", err)
+				}
+			default:
+				return nil, fmt.Errorf("unknown ESDT transfer field: %s", kvp.Key)
+			}
+		}
+		esdtTransfers = append(esdtTransfers, esdtTransfer)
+	}
+
+	return esdtTransfers, nil
+}
@@ -103,6 +103,14 @@ func (p *Parser) processTx(txType scenmodel.TransactionType, blrRaw oj.OJsonObje
 			if txType == scenmodel.Transfer && len(blt.Arguments) > 0 {
 				return nil, errors.New("function arguments not allowed for transfer transactions")
 			}
+		case "typedArguments":
+			blt.TypedArguments, err = p.parseTypedArguments(kvp.Value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid transaction typedArguments: %w", err)
+			}
+			if txType == scenmodel.Transfer {
+				return nil, errors.New("function arguments not allowed for transfer transactions")
+			}
 		case "contractCode":
 			blt.Code, err = p.processStringAsByteArray(kvp.Value)
 			if err != nil {
@@ -139,6 +147,9 @@ func (p *Parser) processTx(txType scenmodel.TransactionType, blrRaw oj.OJsonObje
 			return nil, fmt.Errorf("unknown field in transaction: %s", kvp.Key)
 		}
 	}
+	if len(blt.Arguments) > 0 && blt.TypedArguments != nil {
+		return nil, errors.New("transaction arguments and typedArguments cannot both be set")
+	}
 
 	return &blt, nil
 }
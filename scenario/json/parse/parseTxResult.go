@@ -20,6 +20,8 @@ func (p *Parser) processTxExpectedResult(blrRaw oj.OJsonObject) (*scenmodel.Tran
 		Gas:     scenmodel.JSONCheckUint64Unspecified(),
 		Refund:  scenmodel.JSONCheckBigIntUnspecified(),
 		Logs:    scenmodel.LogList{IsUnspecified: true, IsStar: true},
+
+		Transfers: scenmodel.CheckTransfersUnspecified(),
 	}
 	var err error
 	for _, kvp := range blrMap.OrderedKV {
@@ -44,6 +46,11 @@ func (p *Parser) processTxExpectedResult(blrRaw oj.OJsonObject) (*scenmodel.Tran
 			if err != nil {
 				return nil, err
 			}
+		case "transfers":
+			blr.Transfers, err = p.processCheckTransfers(kvp.Value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid block result transfers: %w", err)
+			}
 		case "gas":
 			blr.Gas, err = p.processCheckUint64(kvp.Value)
 			if err != nil {
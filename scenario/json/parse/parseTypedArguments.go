@@ -0,0 +1,77 @@
+package scenjsonparse
+
+import (
+	"errors"
+	"fmt"
+
+	oj "github.com/multiversx/mx-chain-scenario-go/orderedjson"
+	scenmodel "github.com/multiversx/mx-chain-scenario-go/scenario/model"
+)
+
+// parseTypedArguments parses the typedArguments of a transaction, encoded with the contract ABI later.
+// They are either a map of named values, or a list of plain expressions and values of an explicit type.
+// Unlike in arguments, a map with the keys "type" and "value" is a typed value here, not a subtree to concatenate.
+func (p *Parser) parseTypedArguments(obj oj.OJsonObject) (*scenmodel.TypedArguments, error) {
+	if argMap, isMap := obj.(*oj.OJsonMap); isMap {
+		typedArguments := &scenmodel.TypedArguments{Named: true}
+		for _, kvp := range argMap.OrderedKV {
+			typedArgument, err := parseTypedArgument(kvp.Value)
+			if err != nil {
+				return nil, fmt.Errorf("argument %s: %w", kvp.Key, err)
+			}
+			typedArgument.Name = kvp.Key
+			typedArguments.List = append(typedArguments.List, typedArgument)
+		}
+		return typedArguments, nil
+	}
+
+	argList, isList := obj.(*oj.OJsonList)
+	if !isList {
+		return nil, errors.New("not a JSON list or map")
+	}
+	typedArguments := &scenmodel.TypedArguments{Named: false}
+	for i, argRaw := range argList.AsList() {
+		if isTypedValue(argRaw) {
+			typedArgument, err := parseTypedArgument(argRaw)
+			if err != nil {
+				return nil, fmt.Errorf("argument %d: %w", i, err)
+			}
+			typedArguments.List = append(typedArguments.List, typedArgument)
+			continue
+		}
+
+		plain, err := p.processSubTreeAsByteArray(argRaw)
+		if err != nil {
+			return nil, err
+		}
+		typedArguments.List = append(typedArguments.List, &scenmodel.TypedArgument{Plain: &plain})
+	}
+	return typedArguments, nil
+}
+
+// isTypedValue is true for maps with exactly the keys "type" and "value", e.g. {"type": "MyStruct", "value": {...}}.
+func isTypedValue(obj oj.OJsonObject) bool {
+	valueMap, isMap := obj.(*oj.OJsonMap)
+	if !isMap || len(valueMap.OrderedKV) != 2 {
+		return false
+	}
+	return valueMap.Get(scenmodel.TypedArgumentTypeKey) != nil &&
+		valueMap.Get(scenmodel.TypedArgumentValueKey) != nil
+}
+
+// parseTypedArgument parses a value with an explicit type, or else a value taking the type of the endpoint input.
+func parseTypedArgument(obj oj.OJsonObject) (*scenmodel.TypedArgument, error) {
+	if !isTypedValue(obj) {
+		return &scenmodel.TypedArgument{Value: obj}, nil
+	}
+
+	valueMap := obj.(*oj.OJsonMap)
+	typeName, isString := valueMap.Get(scenmodel.TypedArgumentTypeKey).(*oj.OJsonString)
+	if !isString || len(typeName.Value) == 0 {
+		return nil, errors.New("type should be a non-empty string")
+	}
+	return &scenmodel.TypedArgument{
+		Type:  typeName.Value,
+		Value: valueMap.Get(scenmodel.TypedArgumentValueKey),
+	}, nil
+}
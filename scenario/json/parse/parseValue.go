@@ -25,6 +25,23 @@ func (p *Parser) processCheckBigInt(obj oj.OJsonObject, format bigIntParseFormat
 			Original: "*"}, nil
 	}
 
+	if str, isStr := obj.(*oj.OJsonString); isStr {
+		operator, operand := splitCheckOperator(str.Value, numberCheckOperators)
+		if operator != scenmodel.CheckOperatorEqual {
+			value, upperBound, err := p.parseNumberCheckBounds(operator, operand, format)
+			if err != nil {
+				return scenmodel.JSONCheckBigInt{}, err
+			}
+			return scenmodel.JSONCheckBigInt{
+				Value:      value,
+				IsStar:     false,
+				Original:   str.Value,
+				Operator:   operator,
+				UpperBound: upperBound,
+			}, nil
+		}
+	}
+
 	jbi, err := p.processBigInt(obj, format)
 	if err != nil {
 		return scenmodel.JSONCheckBigInt{}, err
@@ -73,6 +90,13 @@ func (p *Parser) processCheckUint64(obj oj.OJsonObject) (scenmodel.JSONCheckUint
 			Original: "*"}, nil
 	}
 
+	if str, isStr := obj.(*oj.OJsonString); isStr {
+		operator, operand := splitCheckOperator(str.Value, numberCheckOperators)
+		if operator != scenmodel.CheckOperatorEqual {
+			return p.processCheckUint64Operator(str.Value, operator, operand)
+		}
+	}
+
 	ju, err := p.processUint64(obj)
 	if err != nil {
 		return scenmodel.JSONCheckUint64{}, err
@@ -84,6 +108,31 @@ func (p *Parser) processCheckUint64(obj oj.OJsonObject) (scenmodel.JSONCheckUint
 
 }
 
+func (p *Parser) processCheckUint64Operator(
+	original string,
+	operator scenmodel.CheckOperator,
+	operand string,
+) (scenmodel.JSONCheckUint64, error) {
+	value, upperBound, err := p.parseNumberCheckBounds(operator, operand, bigIntUnsignedBytes)
+	if err != nil {
+		return scenmodel.JSONCheckUint64{}, err
+	}
+	if !value.IsUint64() || (upperBound != nil && !upperBound.IsUint64()) {
+		return scenmodel.JSONCheckUint64{}, errors.New("value is not uint64")
+	}
+
+	result := scenmodel.JSONCheckUint64{
+		Value:    value.Uint64(),
+		IsStar:   false,
+		Original: original,
+		Operator: operator,
+	}
+	if upperBound != nil {
+		result.UpperBound = upperBound.Uint64()
+	}
+	return result, nil
+}
+
 func (p *Parser) processUint64(obj oj.OJsonObject) (scenmodel.JSONUint64, error) {
 	bi, err := p.processBigInt(obj, bigIntUnsignedBytes)
 	if err != nil {
@@ -105,6 +154,23 @@ func (p *Parser) parseCheckBytes(obj oj.OJsonObject) (scenmodel.JSONCheckBytes,
 		return scenmodel.JSONCheckBytesStar(), nil
 	}
 
+	if str, isStr := obj.(*oj.OJsonString); isStr {
+		operator, operand := splitCheckOperator(str.Value, bytesCheckOperators)
+		if operator != scenmodel.CheckOperatorEqual {
+			value, regex, err := p.parseBytesCheckOperand(operator, operand)
+			if err != nil {
+				return scenmodel.JSONCheckBytes{}, err
+			}
+			return scenmodel.JSONCheckBytes{
+				Value:    value,
+				IsStar:   false,
+				Original: obj,
+				Operator: operator,
+				Regex:    regex,
+			}, nil
+		}
+	}
+
 	jb, err := p.processSubTreeAsByteArray(obj)
 	if err != nil {
 		return scenmodel.JSONCheckBytes{}, err
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	oj "github.com/multiversx/mx-chain-scenario-go/orderedjson"
+	scenmodel "github.com/multiversx/mx-chain-scenario-go/scenario/model"
 	"github.com/stretchr/testify/require"
 )
 
@@ -59,3 +60,82 @@ func TestParseBool(t *testing.T) {
 	_, err = p.parseBool(nil)
 	require.NotNil(t, err)
 }
+
+func TestCheckBigIntOperators(t *testing.T) {
+	p := Parser{}
+
+	check, err := p.processCheckBigInt(&oj.OJsonString{Value: ">= 1,000"}, bigIntUnsignedBytes)
+	require.Nil(t, err)
+	require.Equal(t, scenmodel.CheckOperatorGreaterOrEqual, check.Operator)
+	require.Equal(t, ">= 1,000", check.Original)
+	require.True(t, check.Check(big.NewInt(1000)))
+	require.False(t, check.Check(big.NewInt(999)))
+
+	check, err = p.processCheckBigInt(&oj.OJsonString{Value: "between 10 20"}, bigIntUnsignedBytes)
+	require.Nil(t, err)
+	require.True(t, check.Check(big.NewInt(10)))
+	require.True(t, check.Check(big.NewInt(20)))
+	require.False(t, check.Check(big.NewInt(21)))
+
+	check, err = p.processCheckBigInt(&oj.OJsonString{Value: "!= 0"}, bigIntUnsignedBytes)
+	require.Nil(t, err)
+	require.True(t, check.Check(big.NewInt(1)))
+	require.False(t, check.Check(big.NewInt(0)))
+
+	_, err = p.processCheckBigInt(&oj.OJsonString{Value: "between 20 10"}, bigIntUnsignedBytes)
+	require.NotNil(t, err)
+
+	_, err = p.processCheckBigInt(&oj.OJsonString{Value: "between 10"}, bigIntUnsignedBytes)
+	require.NotNil(t, err)
+}
+
+func TestCheckUint64Operators(t *testing.T) {
+	p := Parser{}
+
+	check, err := p.processCheckUint64(&oj.OJsonString{Value: "< 5"})
+	require.Nil(t, err)
+	require.True(t, check.Check(4))
+	require.False(t, check.Check(5))
+
+	check, err = p.processCheckUint64(&oj.OJsonString{Value: "between 1 3"})
+	require.Nil(t, err)
+	require.Equal(t, uint64(3), check.UpperBound)
+	require.True(t, check.Check(2))
+	require.False(t, check.Check(0))
+
+	_, err = p.processCheckUint64(&oj.OJsonString{Value: "> 0x010000000000000000"})
+	require.NotNil(t, err)
+}
+
+func TestCheckBytesOperators(t *testing.T) {
+	p := Parser{}
+
+	check, err := p.parseCheckBytes(&oj.OJsonString{Value: "prefix str:hello"})
+	require.Nil(t, err)
+	require.True(t, check.Check([]byte("hello world")))
+	require.False(t, check.Check([]byte("world")))
+
+	check, err = p.parseCheckBytes(&oj.OJsonString{Value: "suffix str:world"})
+	require.Nil(t, err)
+	require.True(t, check.Check([]byte("hello world")))
+
+	check, err = p.parseCheckBytes(&oj.OJsonString{Value: "contains 0x00ff"})
+	require.Nil(t, err)
+	require.True(t, check.Check([]byte{1, 0, 255, 2}))
+	require.False(t, check.Check([]byte{1, 0, 2}))
+
+	check, err = p.parseCheckBytes(&oj.OJsonString{Value: "!= str:initial"})
+	require.Nil(t, err)
+	require.False(t, check.Check([]byte("initial")))
+
+	check, err = p.parseCheckBytes(&oj.OJsonString{Value: "regex str:^level-[0-9]+$"})
+	require.Nil(t, err)
+	require.True(t, check.Check([]byte("level-12")))
+	require.False(t, check.Check([]byte("level-x")))
+
+	_, err = p.parseCheckBytes(&oj.OJsonString{Value: "regex 0x00"})
+	require.NotNil(t, err)
+
+	_, err = p.parseCheckBytes(&oj.OJsonString{Value: "regex str:("})
+	require.NotNil(t, err)
+}
@@ -27,3 +27,12 @@ func NewParser(fileResolver fr.FileResolver, vmType []byte) Parser {
 		AllowSingleValueInCheckValueList: true,
 	}
 }
+
+// Clone creates a copy of the parser, with its own file resolver.
+func (p Parser) Clone() Parser {
+	clone := p
+	if p.ExprInterpreter.FileResolver != nil {
+		clone.ExprInterpreter.FileResolver = p.ExprInterpreter.FileResolver.Clone()
+	}
+	return clone
+}
@@ -7,7 +7,8 @@ import (
 	scenmodel "github.com/multiversx/mx-chain-scenario-go/scenario/model"
 )
 
-func resultToOJ(res *scenmodel.TransactionResult) oj.OJsonObject {
+// TransactionResultToOJ converts the expected result of a transaction to an ordered JSON representation.
+func TransactionResultToOJ(res *scenmodel.TransactionResult) oj.OJsonObject {
 	resultOJ := oj.NewMap()
 
 	resultOJ.Put("out", checkValueListToOJ(res.Out))
@@ -26,6 +27,9 @@ func resultToOJ(res *scenmodel.TransactionResult) oj.OJsonObject {
 
 		}
 	}
+	if !res.Transfers.IsUnspecified {
+		resultOJ.Put("transfers", CheckTransfersToOJ(res.Transfers))
+	}
 	if !res.Gas.IsUnspecified() {
 		resultOJ.Put("gas", checkUint64ToOJ(res.Gas))
 	}
@@ -48,12 +52,21 @@ func logToOJ(logEntry *scenmodel.LogEntry) oj.OJsonObject {
 	logOJ.Put("endpoint", checkBytesToOJ(logEntry.Endpoint))
 	logOJ.Put("topics", checkValueListToOJ(logEntry.Topics))
 	logOJ.Put("data", checkValueListToOJ(logEntry.Data))
+	if logEntry.HasCount() {
+		logOJ.Put("count", checkUint64ToOJ(logEntry.Count))
+	}
 
 	return logOJ
 }
 
 func logsToOJ(logEntries scenmodel.LogList) oj.OJsonObject {
 	var logList []oj.OJsonObject
+	switch logEntries.Mode {
+	case scenmodel.LogCheckUnordered:
+		logList = append(logList, stringToOJ(scenmodel.LogCheckModeUnordered))
+	case scenmodel.LogCheckContains:
+		logList = append(logList, stringToOJ(scenmodel.LogCheckModeContains))
+	}
 	for _, logEntry := range logEntries.List {
 		logOJ := logToOJ(logEntry)
 		logList = append(logList, logOJ)
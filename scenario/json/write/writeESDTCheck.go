@@ -61,23 +61,29 @@ func appendCheckESDTInstanceToOJ(esdtInstance *scenmodel.CheckESDTInstance, targ
 	if len(esdtInstance.Balance.Original) > 0 {
 		targetOj.Put("balance", checkBigIntToOJ(esdtInstance.Balance))
 	}
-	if !esdtInstance.Creator.Unspecified && len(esdtInstance.Creator.Value) > 0 {
+	if !esdtInstance.Creator.Unspecified && checkBytesHasCondition(esdtInstance.Creator) {
 		targetOj.Put("creator", checkBytesToOJ(esdtInstance.Creator))
 	}
 	if !esdtInstance.Royalties.Unspecified && len(esdtInstance.Royalties.Original) > 0 {
 		targetOj.Put("royalties", checkUint64ToOJ(esdtInstance.Royalties))
 	}
-	if !esdtInstance.Hash.Unspecified && len(esdtInstance.Hash.Value) > 0 {
+	if !esdtInstance.Hash.Unspecified && checkBytesHasCondition(esdtInstance.Hash) {
 		targetOj.Put("hash", checkBytesToOJ(esdtInstance.Hash))
 	}
 	if !esdtInstance.Uris.IsUnspecified() {
 		targetOj.Put("uri", checkValueListToOJ(esdtInstance.Uris))
 	}
-	if !esdtInstance.Attributes.Unspecified && len(esdtInstance.Attributes.Value) > 0 {
+	if !esdtInstance.Attributes.Unspecified && checkBytesHasCondition(esdtInstance.Attributes) {
 		targetOj.Put("attributes", checkBytesToOJ(esdtInstance.Attributes))
 	}
 }
 
+// checkBytesHasCondition is false for checks against the empty value, which need not be written.
+// Checks with an operator always need to be written, some of them, like regex, having no value.
+func checkBytesHasCondition(checkBytes scenmodel.JSONCheckBytes) bool {
+	return len(checkBytes.Value) > 0 || checkBytes.Operator != scenmodel.CheckOperatorEqual
+}
+
 func isCompactCheckESDT(esdtItem *scenmodel.CheckESDTData) bool {
 	if len(esdtItem.Instances) != 1 {
 		return false
@@ -33,6 +33,15 @@ func ScenarioToOrderedJSON(scenario *scenmodel.Scenario) oj.OJsonObject {
 		scenarioOJ.Put("traceGas", &ojTrue)
 	}
 
+	if scenario.MultiShard {
+		ojTrue := oj.OJsonBool(true)
+		scenarioOJ.Put("multiShard", &ojTrue)
+	}
+
+	if scenario.BlockProduction != nil {
+		scenarioOJ.Put("blockProduction", blockProductionToOJ(scenario.BlockProduction))
+	}
+
 	if scenario.GasSchedule != scenmodel.GasScheduleDefault {
 		scenarioOJ.Put("gasSchedule", gasScheduleToOJ(scenario.GasSchedule))
 	}
@@ -82,6 +91,40 @@ func ScenarioToOrderedJSON(scenario *scenmodel.Scenario) oj.OJsonObject {
 			if len(step.Comment) > 0 {
 				stepOJ.Put("comment", stringToOJ(step.Comment))
 			}
+			if len(step.Path) > 0 {
+				stepOJ.Put("path", stringToOJ(step.Path))
+			}
+			if len(step.Format) > 0 {
+				stepOJ.Put("format", stringToOJ(step.Format))
+			}
+			if len(step.Accounts) > 0 {
+				var accountList []oj.OJsonObject
+				for _, address := range step.Accounts {
+					accountList = append(accountList, bytesFromStringToOJ(address))
+				}
+				accountsOJ := oj.OJsonList(accountList)
+				stepOJ.Put("accounts", &accountsOJ)
+			}
+		case *scenmodel.SaveStateStep:
+			if len(step.Comment) > 0 {
+				stepOJ.Put("comment", stringToOJ(step.Comment))
+			}
+			stepOJ.Put("name", stringToOJ(step.Name))
+		case *scenmodel.RestoreStateStep:
+			if len(step.Comment) > 0 {
+				stepOJ.Put("comment", stringToOJ(step.Comment))
+			}
+			stepOJ.Put("name", stringToOJ(step.Name))
+		case *scenmodel.AdvanceBlocksStep:
+			if len(step.Comment) > 0 {
+				stepOJ.Put("comment", stringToOJ(step.Comment))
+			}
+			if len(step.Blocks.Original) > 0 {
+				stepOJ.Put("blocks", uint64ToOJ(step.Blocks))
+			}
+			if len(step.Seconds.Original) > 0 {
+				stepOJ.Put("seconds", uint64ToOJ(step.Seconds))
+			}
 		case *scenmodel.TxStep:
 			if len(step.TxIdent) > 0 {
 				stepOJ.Put("id", stringToOJ(step.TxIdent))
@@ -92,9 +135,12 @@ func ScenarioToOrderedJSON(scenario *scenmodel.Scenario) oj.OJsonObject {
 			if step.DisplayLogs {
 				stepOJ.Put("displayLogs", boolToOJ(step.DisplayLogs))
 			}
+			if step.DisplayDiff {
+				stepOJ.Put("displayDiff", boolToOJ(step.DisplayDiff))
+			}
 			stepOJ.Put("tx", transactionToScenarioOJ(step.Tx))
 			if step.Tx.Type.IsSmartContractTx() && step.ExpectedResult != nil {
-				stepOJ.Put("expect", resultToOJ(step.ExpectedResult))
+				stepOJ.Put("expect", TransactionResultToOJ(step.ExpectedResult))
 			}
 		}
 
@@ -129,7 +175,9 @@ func transactionToScenarioOJ(tx *scenmodel.Transaction) oj.OJsonObject {
 		transactionOJ.Put("contractCode", bytesFromStringToOJ(tx.Code))
 	}
 
-	if tx.Type.HasFunction() || tx.Type == scenmodel.ScDeploy {
+	if tx.TypedArguments != nil {
+		transactionOJ.Put("typedArguments", typedArgumentsToOJ(tx.TypedArguments))
+	} else if tx.Type.HasFunction() || tx.Type == scenmodel.ScDeploy {
 		var argList []oj.OJsonObject
 		for _, arg := range tx.Arguments {
 			argList = append(argList, bytesFromTreeToOJ(arg))
@@ -186,6 +234,21 @@ func blockInfoToOJ(blockInfo *scenmodel.BlockInfo) oj.OJsonObject {
 	return blockInfoOJ
 }
 
+func blockProductionToOJ(blockProduction *scenmodel.BlockProduction) oj.OJsonObject {
+	blockProductionOJ := oj.NewMap()
+	if len(blockProduction.RoundDurationMs.Original) > 0 {
+		blockProductionOJ.Put("roundDurationMs", uint64ToOJ(blockProduction.RoundDurationMs))
+	}
+	if len(blockProduction.TxsPerBlock.Original) > 0 {
+		blockProductionOJ.Put("txsPerBlock", uint64ToOJ(blockProduction.TxsPerBlock))
+	}
+	if len(blockProduction.RoundsPerEpoch.Original) > 0 {
+		blockProductionOJ.Put("roundsPerEpoch", uint64ToOJ(blockProduction.RoundsPerEpoch))
+	}
+
+	return blockProductionOJ
+}
+
 func gasScheduleToOJ(gasSchedule scenmodel.GasSchedule) oj.OJsonObject {
 	switch gasSchedule {
 	case scenmodel.GasScheduleDefault:
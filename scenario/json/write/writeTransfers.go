@@ -0,0 +1,76 @@
+package scenjsonwrite
+
+import (
+	oj "github.com/multiversx/mx-chain-scenario-go/orderedjson"
+	scenmodel "github.com/multiversx/mx-chain-scenario-go/scenario/model"
+)
+
+// CheckTransfersToOJ converts the expected transfers of a transaction to an ordered JSON object.
+func CheckTransfersToOJ(transfers scenmodel.CheckTransfers) oj.OJsonObject {
+	if transfers.IsStar {
+		return stringToOJ("*")
+	}
+
+	transfersOJ := oj.NewMap()
+	for _, destination := range transfers.Destinations {
+		var transferList []oj.OJsonObject
+		for _, transfer := range destination.Transfers {
+			transferList = append(transferList, checkTransferToOJ(transfer))
+		}
+		transferListOJ := oj.OJsonList(transferList)
+		transfersOJ.Put(bytesFromStringToString(destination.To), &transferListOJ)
+	}
+	if transfers.MoreDestinationsAllowed {
+		transfersOJ.Put("+", stringToOJ(""))
+	}
+
+	return transfersOJ
+}
+
+func checkTransferToOJ(transfer *scenmodel.CheckTransfer) oj.OJsonObject {
+	transferOJ := oj.NewMap()
+	if !transfer.From.IsUnspecified() {
+		transferOJ.Put("from", checkBytesToOJ(transfer.From))
+	}
+	if !transfer.EGLDValue.IsUnspecified() {
+		transferOJ.Put("egldValue", checkBigIntToOJ(transfer.EGLDValue))
+	}
+	if transfer.ESDTValue != nil {
+		var esdtList []oj.OJsonObject
+		for _, esdtTransfer := range transfer.ESDTValue {
+			esdtList = append(esdtList, checkESDTTransferToOJ(esdtTransfer))
+		}
+		esdtListOJ := oj.OJsonList(esdtList)
+		transferOJ.Put("esdtValue", &esdtListOJ)
+	}
+	if !transfer.Function.IsUnspecified() {
+		transferOJ.Put("function", checkBytesToOJ(transfer.Function))
+	}
+	if !transfer.Arguments.IsUnspecified() {
+		transferOJ.Put("arguments", checkValueListToOJ(transfer.Arguments))
+	}
+	if len(transfer.CallType) > 0 {
+		transferOJ.Put("callType", stringToOJ(transfer.CallType))
+	}
+	if !transfer.GasLimit.IsUnspecified() {
+		transferOJ.Put("gasLimit", checkUint64ToOJ(transfer.GasLimit))
+	}
+	if !transfer.GasLocked.IsUnspecified() {
+		transferOJ.Put("gasLocked", checkUint64ToOJ(transfer.GasLocked))
+	}
+
+	return transferOJ
+}
+
+func checkESDTTransferToOJ(esdtTransfer *scenmodel.CheckESDTTransfer) oj.OJsonObject {
+	esdtOJ := oj.NewMap()
+	esdtOJ.Put("tokenIdentifier", bytesFromStringToOJ(esdtTransfer.TokenIdentifier))
+	if !esdtTransfer.Nonce.IsUnspecified() {
+		esdtOJ.Put("nonce", checkUint64ToOJ(esdtTransfer.Nonce))
+	}
+	if !esdtTransfer.Value.IsUnspecified() {
+		esdtOJ.Put("value", checkBigIntToOJ(esdtTransfer.Value))
+	}
+
+	return esdtOJ
+}
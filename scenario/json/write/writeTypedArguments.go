@@ -0,0 +1,37 @@
+package scenjsonwrite
+
+import (
+	oj "github.com/multiversx/mx-chain-scenario-go/orderedjson"
+	scenmodel "github.com/multiversx/mx-chain-scenario-go/scenario/model"
+)
+
+func typedArgumentsToOJ(typedArguments *scenmodel.TypedArguments) oj.OJsonObject {
+	if typedArguments.Named {
+		argMap := oj.NewMap()
+		for _, typedArgument := range typedArguments.List {
+			argMap.Put(typedArgument.Name, typedArgumentValueToOJ(typedArgument))
+		}
+		return argMap
+	}
+
+	var argList []oj.OJsonObject
+	for _, typedArgument := range typedArguments.List {
+		if typedArgument.Plain != nil {
+			argList = append(argList, bytesFromTreeToOJ(*typedArgument.Plain))
+		} else {
+			argList = append(argList, typedArgumentValueToOJ(typedArgument))
+		}
+	}
+	argOJ := oj.OJsonList(argList)
+	return &argOJ
+}
+
+func typedArgumentValueToOJ(typedArgument *scenmodel.TypedArgument) oj.OJsonObject {
+	if len(typedArgument.Type) == 0 {
+		return typedArgument.Value
+	}
+	valueOJ := oj.NewMap()
+	valueOJ.Put(scenmodel.TypedArgumentTypeKey, stringToOJ(typedArgument.Type))
+	valueOJ.Put(scenmodel.TypedArgumentValueKey, typedArgument.Value)
+	return valueOJ
+}
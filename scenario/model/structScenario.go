@@ -9,6 +9,25 @@ type Scenario struct {
 	IsNewTest   bool
 	GasSchedule GasSchedule
 	Steps       []Step
+
+	// MultiShard runs each account in its own shard: transfers and calls to accounts in other shards
+	// are delivered as cross-shard messages, in rounds after the transaction that produced them.
+	MultiShard bool
+
+	// BlockProduction, if set, makes transactions produce new blocks, advancing nonce, round, timestamp and epoch.
+	BlockProduction *BlockProduction
+
+	// ContinueOnCheckFailure is not part of the JSON format, it is set from the run options.
+	// If true, failed checks are collected and the scenario keeps running.
+	ContinueOnCheckFailure bool
+
+	// UpdateExpectations is not part of the JSON format either, it is set from the run options.
+	// If true, mismatched expectations are collected with their actual values instead of failing.
+	UpdateExpectations bool
+
+	// DecodeWithABI is not part of the JSON format either, it is set from the run options.
+	// If true, contract ABIs are loaded alongside their code and used to decode values in error messages.
+	DecodeWithABI bool
 }
 
 // Step is the basic block of a scenario.
@@ -33,6 +52,13 @@ type BlockInfo struct {
 	BlockRandomSeed  *JSONBytesFromTree
 }
 
+// BlockProduction configures the automatic production of blocks as transactions are executed.
+type BlockProduction struct {
+	RoundDurationMs JSONUint64
+	TxsPerBlock     JSONUint64
+	RoundsPerEpoch  JSONUint64
+}
+
 // TraceGasStatus defines the trace gas status
 type TraceGasStatus int
 
@@ -73,9 +99,47 @@ type CheckStateStep struct {
 	CheckAccounts   *CheckAccounts
 }
 
-// DumpStateStep is a step that simply prints the entire state to console. Useful for debugging.
+// DumpStateStep is a step that prints the entire state to console. Useful for debugging.
+// If a path is given, the state is instead saved to a file, as a setState or checkState step,
+// which other scenarios can include as externalSteps.
 type DumpStateStep struct {
 	Comment string
+
+	// Path is the file where the state is saved, relative to the scenario. The state is printed if empty.
+	Path string
+
+	// Format is the step the state is saved as, DumpFormatSetState if empty.
+	Format string
+
+	// Accounts, if not empty, restricts the dump to these accounts.
+	Accounts []JSONBytesFromString
+}
+
+// DumpFormatSetState saves the dumped state as a setState step.
+const DumpFormatSetState = "setState"
+
+// DumpFormatCheckState saves the dumped state as a checkState step.
+const DumpFormatCheckState = "checkState"
+
+// SaveStateStep is a step that keeps a copy of the entire state under a name, to be restored later.
+type SaveStateStep struct {
+	Comment string
+	Name    string
+}
+
+// RestoreStateStep is a step that brings the state back to the one kept by a previous saveState step.
+// The saved state can be restored several times, e.g. to test several alternatives after a shared setup.
+type RestoreStateStep struct {
+	Comment string
+	Name    string
+}
+
+// AdvanceBlocksStep is a step that produces empty blocks, to move time forward.
+// Exactly one of Blocks and Seconds is given: Seconds produces enough blocks for the timestamp to advance that much.
+type AdvanceBlocksStep struct {
+	Comment string
+	Blocks  JSONUint64
+	Seconds JSONUint64
 }
 
 // TxStep is a step where a transaction is executed.
@@ -83,6 +147,7 @@ type TxStep struct {
 	TxIdent        string
 	Comment        string
 	DisplayLogs    bool
+	DisplayDiff    bool
 	Tx             *Transaction
 	ExpectedResult *TransactionResult
 }
@@ -91,6 +156,9 @@ var _ Step = (*ExternalStepsStep)(nil)
 var _ Step = (*SetStateStep)(nil)
 var _ Step = (*CheckStateStep)(nil)
 var _ Step = (*DumpStateStep)(nil)
+var _ Step = (*SaveStateStep)(nil)
+var _ Step = (*RestoreStateStep)(nil)
+var _ Step = (*AdvanceBlocksStep)(nil)
 var _ Step = (*TxStep)(nil)
 
 // StepNameExternalSteps is a json step type name.
@@ -125,6 +193,30 @@ func (*DumpStateStep) StepTypeName() string {
 	return StepNameDumpState
 }
 
+// StepNameSaveState is a json step type name.
+const StepNameSaveState = "saveState"
+
+// StepTypeName type as string
+func (*SaveStateStep) StepTypeName() string {
+	return StepNameSaveState
+}
+
+// StepNameRestoreState is a json step type name.
+const StepNameRestoreState = "restoreState"
+
+// StepTypeName type as string
+func (*RestoreStateStep) StepTypeName() string {
+	return StepNameRestoreState
+}
+
+// StepNameAdvanceBlocks is a json step type name.
+const StepNameAdvanceBlocks = "advanceBlocks"
+
+// StepTypeName type as string
+func (*AdvanceBlocksStep) StepTypeName() string {
+	return StepNameAdvanceBlocks
+}
+
 // StepNameScCall is a json step type name.
 const StepNameScCall = "scCall"
 
@@ -79,6 +79,9 @@ type Transaction struct {
 	Arguments    []JSONBytesFromTree
 	GasPrice     JSONUint64
 	GasLimit     JSONUint64
+
+	// TypedArguments, if set, replace Arguments once encoded with the contract ABI.
+	TypedArguments *TypedArguments
 }
 
 // TransactionResult is a json object representing an expected transaction result.
@@ -89,6 +92,9 @@ type TransactionResult struct {
 	Gas     JSONCheckUint64
 	Refund  JSONCheckBigInt
 	Logs    LogList
+
+	// Transfers are the EGLD and ESDT transfers, calls and callbacks sent out by the transaction.
+	Transfers CheckTransfers
 }
 
 // LogList is a container struct that holds log information
@@ -96,13 +102,44 @@ type LogList struct {
 	IsUnspecified    bool
 	IsStar           bool
 	MoreAllowedAtEnd bool
+	Mode             LogCheckMode
 	List             []*LogEntry
 }
 
+// LogCheckMode tells how the expected logs are matched against the actual ones.
+type LogCheckMode int
+
+const (
+	// LogCheckOrdered matches logs by index. This is the default.
+	LogCheckOrdered LogCheckMode = iota
+
+	// LogCheckUnordered matches each expected log to a different actual log, in any order.
+	// There can be no other logs.
+	LogCheckUnordered
+
+	// LogCheckContains is like LogCheckUnordered, but allows any other logs.
+	LogCheckContains
+)
+
+// LogCheckModeUnordered is the marker of the unordered mode, the first item of the log list.
+const LogCheckModeUnordered = "unordered"
+
+// LogCheckModeContains is the marker of the contains mode, the first item of the log list.
+const LogCheckModeContains = "contains"
+
 // LogEntry is a json object representing an expected transaction result log entry.
 type LogEntry struct {
 	Address  JSONCheckBytes
 	Endpoint JSONCheckBytes
 	Topics   JSONCheckValueList
 	Data     JSONCheckValueList
+
+	// Count, if given, checks how many actual logs match the entry, instead of matching a single one.
+	// Only allowed when logs are not checked in order.
+	Count JSONCheckUint64
+}
+
+// HasCount yields true if the entry checks how many logs match it.
+func (logEntry *LogEntry) HasCount() bool {
+	return len(logEntry.Count.Original) > 0
 }
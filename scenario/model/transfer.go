@@ -0,0 +1,65 @@
+package scenmodel
+
+// CheckTransfers is the expectation on the transfers a transaction sends out, grouped by destination.
+// Transfers are only checked if specified.
+type CheckTransfers struct {
+	IsUnspecified           bool
+	IsStar                  bool
+	MoreDestinationsAllowed bool
+	Destinations            []*CheckTransferDestination
+}
+
+// CheckTransfersUnspecified yields CheckTransfers that accept any transfers, without being written.
+func CheckTransfersUnspecified() CheckTransfers {
+	return CheckTransfers{
+		IsUnspecified: true,
+		IsStar:        true,
+	}
+}
+
+// CheckTransferDestination lists the transfers expected to reach one destination, in order.
+type CheckTransferDestination struct {
+	To        JSONBytesFromString
+	Transfers []*CheckTransfer
+}
+
+// CheckTransfer checks one transfer. Unspecified fields are not checked.
+// Token transfers are decoded from the transfer data: the function and arguments are the ones called after the transfer,
+// and the destination the one that receives the tokens.
+type CheckTransfer struct {
+	From      JSONCheckBytes
+	EGLDValue JSONCheckBigInt
+
+	// ESDTValue is not checked if nil. An empty list checks that no tokens are transferred.
+	ESDTValue []*CheckESDTTransfer
+
+	Function  JSONCheckBytes
+	Arguments JSONCheckValueList
+
+	// CallType is one of the vm.CallType names, e.g. "asynchronousCall". Not checked if empty.
+	CallType string
+
+	GasLimit  JSONCheckUint64
+	GasLocked JSONCheckUint64
+}
+
+// NewCheckTransfer yields a CheckTransfer with all fields unspecified.
+func NewCheckTransfer() *CheckTransfer {
+	return &CheckTransfer{
+		From:      JSONCheckBytesUnspecified(),
+		EGLDValue: JSONCheckBigIntUnspecified(),
+		ESDTValue: nil,
+		Function:  JSONCheckBytesUnspecified(),
+		Arguments: JSONCheckValueListUnspecified(),
+		CallType:  "",
+		GasLimit:  JSONCheckUint64Unspecified(),
+		GasLocked: JSONCheckUint64Unspecified(),
+	}
+}
+
+// CheckESDTTransfer checks one of the tokens of a transfer.
+type CheckESDTTransfer struct {
+	TokenIdentifier JSONBytesFromString
+	Nonce           JSONCheckUint64
+	Value           JSONCheckBigInt
+}
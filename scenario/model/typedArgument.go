@@ -0,0 +1,36 @@
+package scenmodel
+
+import oj "github.com/multiversx/mx-chain-scenario-go/orderedjson"
+
+// TypedArguments are endpoint arguments given as typed JSON values instead of low-level expressions,
+// in the typedArguments field of a transaction.
+// They are encoded with the ABI of the called contract, when the transaction runs.
+type TypedArguments struct {
+	// Named is true if the arguments were given as a map from endpoint input names to values.
+	Named bool
+
+	List []*TypedArgument
+}
+
+// TypedArgument is a single endpoint argument.
+// Items of argument lists are either plain expressions, or values with an explicit ABI type.
+type TypedArgument struct {
+	// Name is the endpoint input, for named arguments.
+	Name string
+
+	// Type is the explicit ABI type of the value, if given.
+	Type string
+
+	// Value is the JSON value, encoded according to Type, or to the type of the endpoint input.
+	Value oj.OJsonObject
+
+	// Plain is set for list items given as plain expressions, which need no encoding.
+	Plain *JSONBytesFromTree
+}
+
+// TypedArgumentTypeKey and TypedArgumentValueKey are the keys of arguments given with an explicit type,
+// e.g. {"type": "MyStruct", "value": {...}}.
+const (
+	TypedArgumentTypeKey  = "type"
+	TypedArgumentValueKey = "value"
+)
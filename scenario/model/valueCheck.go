@@ -3,18 +3,21 @@ package scenmodel
 import (
 	"bytes"
 	"math/big"
+	"regexp"
 
 	oj "github.com/multiversx/mx-chain-scenario-go/orderedjson"
 )
 
 // JSONCheckBytes holds a byte slice condition.
-// Values are checked for equality.
+// Values are checked for equality, unless an operator is given: "!=", "prefix", "suffix", "contains" or "regex".
 // "*" allows all values.
 type JSONCheckBytes struct {
 	Value       []byte
 	IsStar      bool
 	Original    oj.OJsonObject
 	Unspecified bool
+	Operator    CheckOperator
+	Regex       *regexp.Regexp
 }
 
 // JSONCheckBytesUnspecified yields JSONCheckBytes that check that value is empty.
@@ -66,17 +69,34 @@ func (jcbytes JSONCheckBytes) Check(other []byte) bool {
 	if jcbytes.IsStar {
 		return true
 	}
-	return bytes.Equal(jcbytes.Value, other)
+	switch jcbytes.Operator {
+	case CheckOperatorNotEqual:
+		return !bytes.Equal(jcbytes.Value, other)
+	case CheckOperatorPrefix:
+		return bytes.HasPrefix(other, jcbytes.Value)
+	case CheckOperatorSuffix:
+		return bytes.HasSuffix(other, jcbytes.Value)
+	case CheckOperatorContains:
+		return bytes.Contains(other, jcbytes.Value)
+	case CheckOperatorRegex:
+		return jcbytes.Regex.Match(other)
+	default:
+		return bytes.Equal(jcbytes.Value, other)
+	}
 }
 
 // JSONCheckBigInt holds a big int condition.
-// Values are checked for equality.
+// Values are checked for equality, unless an operator is given: "!=", ">", ">=", "<", "<=" or "between".
 // "*" allows all values.
 type JSONCheckBigInt struct {
 	Value       *big.Int
 	IsStar      bool
 	Original    string
 	Unspecified bool
+	Operator    CheckOperator
+
+	// UpperBound is only set for "between", Value being the lower bound.
+	UpperBound *big.Int
 }
 
 // JSONCheckBigIntUnspecified yields JSONCheckBigInt default "*" value.
@@ -100,17 +120,27 @@ func (jcbi JSONCheckBigInt) Check(other *big.Int) bool {
 	if jcbi.IsStar {
 		return true
 	}
-	return jcbi.Value.Cmp(other) == 0
+	cmp := other.Cmp(jcbi.Value)
+	switch jcbi.Operator {
+	case CheckOperatorBetween:
+		return cmp >= 0 && other.Cmp(jcbi.UpperBound) <= 0
+	default:
+		return jcbi.Operator.holds(cmp)
+	}
 }
 
 // JSONCheckUint64 holds a uint64 condition.
-// Values are checked for equality.
+// Values are checked for equality, unless an operator is given: "!=", ">", ">=", "<", "<=" or "between".
 // "*" allows all values.
 type JSONCheckUint64 struct {
 	Value       uint64
 	IsStar      bool
 	Original    string
 	Unspecified bool
+	Operator    CheckOperator
+
+	// UpperBound is only set for "between", Value being the lower bound.
+	UpperBound uint64
 }
 
 // JSONCheckUint64Unspecified yields JSONCheckBigInt default "*" value.
@@ -134,7 +164,12 @@ func (jcu JSONCheckUint64) Check(other uint64) bool {
 	if jcu.IsStar {
 		return true
 	}
-	return jcu.Value == other
+	switch jcu.Operator {
+	case CheckOperatorBetween:
+		return other >= jcu.Value && other <= jcu.UpperBound
+	default:
+		return jcu.Operator.holds(compareUint64(other, jcu.Value))
+	}
 }
 
 // CheckBool interprets own value as bool (true = anything > 0, false = 0),
@@ -143,6 +178,13 @@ func (jcu JSONCheckUint64) CheckBool(other bool) bool {
 	if jcu.IsStar {
 		return true
 	}
+	if jcu.Operator != CheckOperatorEqual {
+		otherAsUint64 := uint64(0)
+		if other {
+			otherAsUint64 = 1
+		}
+		return jcu.Check(otherAsUint64)
+	}
 	return jcu.Value > 0 == other
 }
 
@@ -0,0 +1,70 @@
+package scenmodel
+
+// CheckOperator is the comparison a check performs between the expected and the actual value.
+// The zero value is equality, so checks written without an operator compare values exactly.
+type CheckOperator int
+
+const (
+	// CheckOperatorEqual requires the actual value to equal the expected one.
+	CheckOperatorEqual CheckOperator = iota
+
+	// CheckOperatorNotEqual, written "!= x", requires the actual value to differ from x.
+	CheckOperatorNotEqual
+
+	// CheckOperatorGreater, written "> x", only applies to numbers.
+	CheckOperatorGreater
+
+	// CheckOperatorGreaterOrEqual, written ">= x", only applies to numbers.
+	CheckOperatorGreaterOrEqual
+
+	// CheckOperatorLess, written "< x", only applies to numbers.
+	CheckOperatorLess
+
+	// CheckOperatorLessOrEqual, written "<= x", only applies to numbers.
+	CheckOperatorLessOrEqual
+
+	// CheckOperatorBetween, written "between x y", requires x <= value <= y. Only applies to numbers.
+	CheckOperatorBetween
+
+	// CheckOperatorPrefix, written "prefix x", only applies to bytes.
+	CheckOperatorPrefix
+
+	// CheckOperatorSuffix, written "suffix x", only applies to bytes.
+	CheckOperatorSuffix
+
+	// CheckOperatorContains, written "contains x", only applies to bytes.
+	CheckOperatorContains
+
+	// CheckOperatorRegex, written "regex str:pattern", matches the bytes against a regular expression.
+	CheckOperatorRegex
+)
+
+// holds tells whether the comparison between the actual and the expected value satisfies the operator.
+// cmp is -1, 0 or +1, as returned by actual.Cmp(expected).
+func (operator CheckOperator) holds(cmp int) bool {
+	switch operator {
+	case CheckOperatorNotEqual:
+		return cmp != 0
+	case CheckOperatorGreater:
+		return cmp > 0
+	case CheckOperatorGreaterOrEqual:
+		return cmp >= 0
+	case CheckOperatorLess:
+		return cmp < 0
+	case CheckOperatorLessOrEqual:
+		return cmp <= 0
+	default:
+		return cmp == 0
+	}
+}
+
+func compareUint64(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -23,6 +23,9 @@ type BuiltinFunctionsWrapper struct {
 	MapDNSAddresses map[string]struct{}
 	World           *MockWorld
 	Marshalizer     vmcommon.Marshalizer
+
+	// GasMap is the gas schedule the builtin functions were created with.
+	GasMap GasScheduleMap
 }
 
 // NewBuiltinFunctionsWrapper creates a new BuiltinFunctionsWrapper with
@@ -65,6 +68,7 @@ func NewBuiltinFunctionsWrapper(
 		Container:       builtinFuncFactory.BuiltInFunctionContainer(),
 		MapDNSAddresses: argsBuiltIn.MapDNSAddresses,
 		World:           world,
+		GasMap:          gasMap,
 	}
 
 	return builtinFuncsWrapper, nil
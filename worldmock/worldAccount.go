@@ -244,6 +244,37 @@ func (a *Account) DirtyData() map[string][]byte {
 	return a.Storage
 }
 
+// SameState yields true if both accounts have the same nonce, balances, storage, code, owner and username.
+// Transient fields, such as the balance delta, are ignored.
+func (a *Account) SameState(other *Account) bool {
+	if a.Nonce != other.Nonce ||
+		!sameBigInt(a.Balance, other.Balance) ||
+		!sameBigInt(a.DeveloperReward, other.DeveloperReward) ||
+		!bytes.Equal(a.Code, other.Code) ||
+		!bytes.Equal(a.CodeMetadata, other.CodeMetadata) ||
+		!bytes.Equal(a.OwnerAddress, other.OwnerAddress) ||
+		!bytes.Equal(a.Username, other.Username) ||
+		a.AsyncCallData != other.AsyncCallData ||
+		a.ShardID != other.ShardID ||
+		len(a.Storage) != len(other.Storage) {
+		return false
+	}
+	for key, value := range a.Storage {
+		otherValue, found := other.Storage[key]
+		if !found || !bytes.Equal(value, otherValue) {
+			return false
+		}
+	}
+	return true
+}
+
+func sameBigInt(first *big.Int, second *big.Int) bool {
+	if first == nil || second == nil {
+		return first == second
+	}
+	return first.Cmp(second) == 0
+}
+
 // Clone -
 func (a *Account) Clone() *Account {
 	return &Account{
@@ -0,0 +1,135 @@
+package worldmock
+
+import (
+	"encoding/binary"
+)
+
+// DefaultRoundDurationMs is the duration of a round when block production does not configure one, as on mainnet.
+const DefaultRoundDurationMs = 6000
+
+// maxProducedBlockhashes is how many block hashes block production keeps, the most recent first.
+const maxProducedBlockhashes = 256
+
+// BlockProduction configures the automatic production of blocks.
+// Each transaction goes into the current block, a new block being produced once it holds TxsPerBlock transactions.
+// Every block is one round after the previous one.
+type BlockProduction struct {
+	// RoundDurationMs is how much the timestamp advances with each block, DefaultRoundDurationMs if 0.
+	RoundDurationMs uint64
+
+	// TxsPerBlock is how many transactions fit in a block, 1 if 0.
+	TxsPerBlock uint64
+
+	// RoundsPerEpoch is the length of an epoch. The epoch never changes if 0.
+	RoundsPerEpoch uint64
+}
+
+// IncludeTxInBlock counts a transaction in the current block, producing a new block first if the current one is full.
+// It does nothing, unless block production is enabled.
+func (b *MockWorld) IncludeTxInBlock() {
+	if b.BlockProduction == nil {
+		return
+	}
+	txsPerBlock := b.BlockProduction.TxsPerBlock
+	if txsPerBlock == 0 {
+		txsPerBlock = 1
+	}
+	if b.TxsInCurrentBlock >= txsPerBlock {
+		b.ProduceBlocks(1)
+	}
+	b.TxsInCurrentBlock++
+}
+
+// ProduceBlocks advances the chain by a number of empty blocks: nonce, round, timestamp, epoch,
+// random seed and block hashes. The previous block info becomes the one just before the new current block.
+// Works the same whether block production is enabled or not, the defaults being used if it is not configured.
+//
+// Random seeds and block hashes only depend on the block, so producing blocks in one go or in several
+// yields the same chain.
+func (b *MockWorld) ProduceBlocks(count uint64) {
+	if count == 0 {
+		return
+	}
+	if b.CurrentBlockInfo == nil {
+		b.CurrentBlockInfo = &BlockInfo{}
+	}
+	start := b.CurrentBlockInfo.clone()
+
+	firstHashed := uint64(1)
+	if count > maxProducedBlockhashes {
+		firstHashed = count - maxProducedBlockhashes + 1
+		b.Blockhashes = nil
+	}
+	for i := firstHashed; i <= count; i++ {
+		b.Blockhashes = append([][]byte{producedBlockHash(b.blockAfter(start, i))}, b.Blockhashes...)
+	}
+	if len(b.Blockhashes) > maxProducedBlockhashes {
+		b.Blockhashes = b.Blockhashes[:maxProducedBlockhashes]
+	}
+
+	b.PreviousBlockInfo = b.blockAfter(start, count-1)
+	b.CurrentBlockInfo = b.blockAfter(start, count)
+	if b.CurrentBlockInfo.BlockEpoch != start.BlockEpoch {
+		roundsPerEpoch := b.BlockProduction.RoundsPerEpoch
+		epochStartRound := b.CurrentBlockInfo.BlockRound / roundsPerEpoch * roundsPerEpoch
+		b.EpochStartBlockInfo = b.blockAfter(start, epochStartRound-start.BlockRound)
+	}
+	b.TxsInCurrentBlock = 0
+}
+
+// BlocksForDuration yields how many blocks are needed for the timestamp to advance by at least the given duration.
+func (b *MockWorld) BlocksForDuration(durationMs uint64) uint64 {
+	roundDurationMs := b.roundDurationMs()
+	return (durationMs + roundDurationMs - 1) / roundDurationMs
+}
+
+// blockAfter yields the block info a number of blocks after the given one, with a derived random seed.
+func (b *MockWorld) blockAfter(start *BlockInfo, numBlocks uint64) *BlockInfo {
+	if numBlocks == 0 {
+		return start.clone()
+	}
+
+	block := &BlockInfo{
+		BlockTimestampMs: start.BlockTimestampMs + numBlocks*b.roundDurationMs(),
+		BlockNonce:       start.BlockNonce + numBlocks,
+		BlockRound:       start.BlockRound + numBlocks,
+		BlockEpoch:       start.BlockEpoch,
+	}
+	if b.BlockProduction != nil && b.BlockProduction.RoundsPerEpoch > 0 {
+		roundsPerEpoch := b.BlockProduction.RoundsPerEpoch
+		block.BlockEpoch += uint32(block.BlockRound/roundsPerEpoch - start.BlockRound/roundsPerEpoch)
+	}
+	block.RandomSeed = producedRandomSeed(block.BlockNonce)
+	return block
+}
+
+func (b *MockWorld) roundDurationMs() uint64 {
+	if b.BlockProduction == nil || b.BlockProduction.RoundDurationMs == 0 {
+		return DefaultRoundDurationMs
+	}
+	return b.BlockProduction.RoundDurationMs
+}
+
+// producedRandomSeed derives the 48 byte random seed of a produced block from its nonce.
+func producedRandomSeed(nonce uint64) *[48]byte {
+	nonceBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(nonceBytes, nonce)
+	firstPart := DefaultHasher.Compute(string(append([]byte("randomSeed"), nonceBytes...)))
+	secondPart := DefaultHasher.Compute(string(firstPart))
+
+	var randomSeed [48]byte
+	copy(randomSeed[:], firstPart)
+	copy(randomSeed[len(firstPart):], secondPart)
+	return &randomSeed
+}
+
+// producedBlockHash derives the hash of a produced block from its header fields.
+func producedBlockHash(block *BlockInfo) []byte {
+	header := make([]byte, 0, 28+len(block.RandomSeed))
+	header = binary.BigEndian.AppendUint64(header, block.BlockNonce)
+	header = binary.BigEndian.AppendUint64(header, block.BlockRound)
+	header = binary.BigEndian.AppendUint64(header, block.BlockTimestampMs)
+	header = binary.BigEndian.AppendUint32(header, block.BlockEpoch)
+	header = append(header, block.RandomSeed[:]...)
+	return DefaultHasher.Compute(string(header))
+}
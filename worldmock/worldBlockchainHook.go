@@ -164,47 +164,65 @@ func (b *MockWorld) CurrentRound() uint64 {
 	return b.CurrentBlockInfo.BlockRound
 }
 
-// RoundTime returns the duration of a round
+// RoundTime returns the duration of a round, if block production is enabled
 func (b *MockWorld) RoundTime() uint64 {
-	return 0
+	if b.BlockProduction == nil {
+		return 0
+	}
+	return b.roundDurationMs()
 }
 
 // EpochStartBlockTimeStamp returns the timestamp of the first block of the current epoch
 func (b *MockWorld) EpochStartBlockTimeStamp() uint64 {
-	if b.CurrentBlockInfo == nil {
+	epochStartBlockInfo := b.epochStartBlockInfo()
+	if epochStartBlockInfo == nil {
 		return 0
 	}
 
-	// TODO: add epoch start block field in setState, instead of using current block
-	return ConvertTimeStampMsToSeconds(b.CurrentBlockInfo.BlockTimestampMs)
+	return ConvertTimeStampMsToSeconds(epochStartBlockInfo.BlockTimestampMs)
 }
 
 // EpochStartBlockTimeStampMs returns the timestamp in milliseconds of the first block of the current epoch
 func (b *MockWorld) EpochStartBlockTimeStampMs() uint64 {
-	if b.CurrentBlockInfo == nil {
+	epochStartBlockInfo := b.epochStartBlockInfo()
+	if epochStartBlockInfo == nil {
 		return 0
 	}
 
-	// TODO: add epoch start block field in setState, instead of using current block
-	return b.CurrentBlockInfo.BlockTimestampMs
+	return epochStartBlockInfo.BlockTimestampMs
 }
 
 // EpochStartBlockNonce returns the nonce of the first block of the current epoch
 func (b *MockWorld) EpochStartBlockNonce() uint64 {
-	if b.CurrentBlockInfo == nil {
+	epochStartBlockInfo := b.epochStartBlockInfo()
+	if epochStartBlockInfo == nil {
 		return 0
 	}
 
-	return b.CurrentBlockInfo.BlockNonce
+	return epochStartBlockInfo.BlockNonce
 }
 
 // EpochStartBlockRound returns the round of the first block of the current epoch
 func (b *MockWorld) EpochStartBlockRound() uint64 {
-	if b.CurrentBlockInfo == nil {
+	epochStartBlockInfo := b.epochStartBlockInfo()
+	if epochStartBlockInfo == nil {
 		return 0
 	}
 
-	return b.CurrentBlockInfo.BlockRound
+	return epochStartBlockInfo.BlockRound
+}
+
+// epochStartBlockInfo yields the first block of the current epoch, if block production reached it,
+// or the current block otherwise.
+func (b *MockWorld) epochStartBlockInfo() *BlockInfo {
+	if b.CurrentBlockInfo == nil {
+		return nil
+	}
+	// TODO: add epoch start block field in setState, instead of using current block
+	if b.EpochStartBlockInfo == nil || b.EpochStartBlockInfo.BlockEpoch != b.CurrentBlockInfo.BlockEpoch {
+		return b.CurrentBlockInfo
+	}
+	return b.EpochStartBlockInfo
 }
 
 // CurrentTimeStamp return the timestamp from the current block
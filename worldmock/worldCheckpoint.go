@@ -0,0 +1,117 @@
+package worldmock
+
+import "math/big"
+
+// WorldCheckpoint is an in-memory copy of the state of a MockWorld.
+// Unlike the snapshots of the accounts adapter, it survives commits and can be restored any number of times.
+type WorldCheckpoint struct {
+	accounts          AccountMap
+	previousBlockInfo *BlockInfo
+	currentBlockInfo  *BlockInfo
+	blockhashes       [][]byte
+	newAddressMocks   []*NewAddressMock
+
+	epochStartBlockInfo *BlockInfo
+	txsInCurrentBlock   uint64
+	crossShardMessages  []*CrossShardMessage
+}
+
+// SaveCheckpoint copies the accounts, block infos, block hashes, new address mocks
+// and pending cross-shard messages of the world.
+func (b *MockWorld) SaveCheckpoint() *WorldCheckpoint {
+	return &WorldCheckpoint{
+		accounts:          b.AcctMap.Clone(),
+		previousBlockInfo: b.PreviousBlockInfo.clone(),
+		currentBlockInfo:  b.CurrentBlockInfo.clone(),
+		blockhashes:       cloneByteSlices(b.Blockhashes),
+		newAddressMocks:   cloneNewAddressMocks(b.NewAddressMocks),
+
+		epochStartBlockInfo: b.EpochStartBlockInfo.clone(),
+		txsInCurrentBlock:   b.TxsInCurrentBlock,
+		crossShardMessages:  cloneCrossShardMessages(b.CrossShardMessages),
+	}
+}
+
+// RestoreCheckpoint brings the world back to the state of the checkpoint.
+// The checkpoint itself is not changed, so it can be restored again later.
+// The builtin functions, if initialized, are created again, so that they use the new accounts adapter.
+func (b *MockWorld) RestoreCheckpoint(checkpoint *WorldCheckpoint) error {
+	b.AcctMap = checkpoint.accounts.Clone()
+	for _, account := range b.AcctMap {
+		account.MockWorld = b
+	}
+	b.AccountsAdapter = NewMockAccountsAdapter(b)
+	b.PreviousBlockInfo = checkpoint.previousBlockInfo.clone()
+	b.CurrentBlockInfo = checkpoint.currentBlockInfo.clone()
+	b.Blockhashes = cloneByteSlices(checkpoint.blockhashes)
+	b.NewAddressMocks = cloneNewAddressMocks(checkpoint.newAddressMocks)
+	b.EpochStartBlockInfo = checkpoint.epochStartBlockInfo.clone()
+	b.TxsInCurrentBlock = checkpoint.txsInCurrentBlock
+	b.CrossShardMessages = cloneCrossShardMessages(checkpoint.crossShardMessages)
+
+	if b.BuiltinFuncs == nil {
+		return nil
+	}
+	return b.InitBuiltinFunctions(b.BuiltinFuncs.GasMap)
+}
+
+func (bi *BlockInfo) clone() *BlockInfo {
+	if bi == nil {
+		return nil
+	}
+
+	clone := *bi
+	if bi.RandomSeed != nil {
+		randomSeed := *bi.RandomSeed
+		clone.RandomSeed = &randomSeed
+	}
+	return &clone
+}
+
+func cloneByteSlices(slices [][]byte) [][]byte {
+	if slices == nil {
+		return nil
+	}
+
+	clone := make([][]byte, len(slices))
+	for i, slice := range slices {
+		clone[i] = cloneBytes(slice)
+	}
+	return clone
+}
+
+func cloneCrossShardMessages(messages []*CrossShardMessage) []*CrossShardMessage {
+	if messages == nil {
+		return nil
+	}
+
+	clone := make([]*CrossShardMessage, len(messages))
+	for i, message := range messages {
+		messageClone := *message
+		messageClone.Sender = cloneBytes(message.Sender)
+		messageClone.Receiver = cloneBytes(message.Receiver)
+		messageClone.Data = cloneBytes(message.Data)
+		messageClone.OriginalTxHash = cloneBytes(message.OriginalTxHash)
+		if message.Value != nil {
+			messageClone.Value = big.NewInt(0).Set(message.Value)
+		}
+		clone[i] = &messageClone
+	}
+	return clone
+}
+
+func cloneNewAddressMocks(newAddressMocks []*NewAddressMock) []*NewAddressMock {
+	if newAddressMocks == nil {
+		return nil
+	}
+
+	clone := make([]*NewAddressMock, len(newAddressMocks))
+	for i, newAddressMock := range newAddressMocks {
+		clone[i] = &NewAddressMock{
+			CreatorAddress: cloneBytes(newAddressMock.CreatorAddress),
+			CreatorNonce:   newAddressMock.CreatorNonce,
+			NewAddress:     cloneBytes(newAddressMock.NewAddress),
+		}
+	}
+	return clone
+}
@@ -0,0 +1,51 @@
+package worldmock
+
+import (
+	"math/big"
+
+	"github.com/multiversx/mx-chain-core-go/data/vm"
+)
+
+// CrossShardMessage is a transfer or a call between accounts in different shards,
+// waiting to be executed in the shard of the receiver.
+type CrossShardMessage struct {
+	SourceShard      uint32
+	DestinationShard uint32
+	Sender           []byte
+	Receiver         []byte
+	Value            *big.Int
+	Data             []byte
+	GasLimit         uint64
+	GasLocked        uint64
+	CallType         vm.CallType
+	OriginalTxHash   []byte
+
+	// IsRefund marks messages that return value or tokens after a failure. They are not refunded in turn.
+	IsRefund bool
+}
+
+// ShardOf yields the shard of an account. Accounts that do not exist yet belong to the current shard.
+func (b *MockWorld) ShardOf(address []byte) uint32 {
+	account := b.AcctMap.GetAccount(address)
+	if account == nil {
+		return b.SelfShardID
+	}
+	return account.ShardID
+}
+
+// IsInOtherShard yields true if the account belongs to a shard other than the current one.
+func (b *MockWorld) IsInOtherShard(address []byte) bool {
+	return b.ShardOf(address) != b.SelfShardID
+}
+
+// QueueCrossShardMessage adds a message to be executed in a subsequent round.
+func (b *MockWorld) QueueCrossShardMessage(message *CrossShardMessage) {
+	b.CrossShardMessages = append(b.CrossShardMessages, message)
+}
+
+// TakeCrossShardMessages empties the queue, yielding the messages that were waiting in it.
+func (b *MockWorld) TakeCrossShardMessages() []*CrossShardMessage {
+	messages := b.CrossShardMessages
+	b.CrossShardMessages = nil
+	return messages
+}
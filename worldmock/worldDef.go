@@ -55,6 +55,16 @@ type MockWorld struct {
 	ProvidedBlockchainHook     vmcommon.BlockchainHook
 	EnableEpochsHandler        vmcommon.EnableEpochsHandler
 	OtherVMOutputMap           map[string]*vmcommon.VMOutput
+
+	// MultiShard keeps the changes to accounts in other shards than the current one out of the world,
+	// so that they can be delivered as cross-shard messages instead.
+	MultiShard         bool
+	CrossShardMessages []*CrossShardMessage
+
+	// BlockProduction, if set, produces new blocks as transactions fill them.
+	BlockProduction     *BlockProduction
+	TxsInCurrentBlock   uint64
+	EpochStartBlockInfo *BlockInfo
 }
 
 // NewMockWorld creates a new MockWorld instance
@@ -100,11 +110,15 @@ func (b *MockWorld) InitBuiltinFunctions(gasMap GasScheduleMap) error {
 func (b *MockWorld) Clear() {
 	b.AcctMap = NewAccountMap()
 	b.AccountsAdapter = NewMockAccountsAdapter(b)
+	b.SelfShardID = 0
 	b.PreviousBlockInfo = nil
 	b.CurrentBlockInfo = nil
 	b.Blockhashes = nil
 	b.NewAddressMocks = nil
 	b.CompiledCode = make(map[string][]byte)
+	b.CrossShardMessages = nil
+	b.TxsInCurrentBlock = 0
+	b.EpochStartBlockInfo = nil
 }
 
 // SetCurrentBlockHash -
@@ -129,7 +143,7 @@ func (b *MockWorld) NumberOfShards() uint32 {
 
 // ComputeId -
 func (b *MockWorld) ComputeId(address []byte) uint32 {
-	return b.AcctMap.GetAccount(address).ShardID
+	return b.ShardOf(address)
 }
 
 // SelfId -
@@ -139,9 +153,7 @@ func (b *MockWorld) SelfId() uint32 {
 
 // SameShard -
 func (b *MockWorld) SameShard(firstAddress []byte, secondAddress []byte) bool {
-	firstAccount := b.AcctMap.GetAccount(firstAddress)
-	secondAccount := b.AcctMap.GetAccount(secondAddress)
-	return firstAccount.ShardID == secondAccount.ShardID
+	return b.ShardOf(firstAddress) == b.ShardOf(secondAddress)
 }
 
 // CommunicationIdentifier -
@@ -0,0 +1,310 @@
+package worldmock
+
+import (
+	"bytes"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	"github.com/multiversx/mx-chain-scenario-go/worldmock/esdtconvert"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+// WorldDiff holds the changes between two states of the world, for the accounts that changed, sorted by address.
+type WorldDiff struct {
+	Accounts []*AccountDiff
+}
+
+// AccountDiff holds the changes of a single account.
+// Only the changed fields are relevant; a created or deleted account is compared to an empty one.
+type AccountDiff struct {
+	Address       []byte
+	Created       bool
+	Deleted       bool
+	NonceBefore   uint64
+	NonceAfter    uint64
+	BalanceBefore *big.Int
+	BalanceAfter  *big.Int
+	Storage       []*StorageDiff
+	ESDT          []*ESDTDiff
+}
+
+// StorageDiff is a changed storage entry. Before is nil for added keys, After is nil for removed keys.
+type StorageDiff struct {
+	Key    []byte
+	Before []byte
+	After  []byte
+}
+
+// ESDTDiff is a changed ESDT token instance. A missing instance has a zero balance and no attributes.
+type ESDTDiff struct {
+	TokenIdentifier  []byte
+	Nonce            uint64
+	BalanceBefore    *big.Int
+	BalanceAfter     *big.Int
+	AttributesBefore []byte
+	AttributesAfter  []byte
+}
+
+// IsEmpty yields true if no account changed.
+func (wd *WorldDiff) IsEmpty() bool {
+	return len(wd.Accounts) == 0
+}
+
+// NonceChanged yields true if the nonce of the account changed.
+func (ad *AccountDiff) NonceChanged() bool {
+	return ad.NonceBefore != ad.NonceAfter
+}
+
+// BalanceChanged yields true if the EGLD balance of the account changed.
+func (ad *AccountDiff) BalanceChanged() bool {
+	return ad.BalanceBefore.Cmp(ad.BalanceAfter) != 0
+}
+
+// BalanceChanged yields true if the balance of the token instance changed.
+func (ed *ESDTDiff) BalanceChanged() bool {
+	return ed.BalanceBefore.Cmp(ed.BalanceAfter) != 0
+}
+
+// AttributesChanged yields true if the attributes of the token instance changed.
+func (ed *ESDTDiff) AttributesChanged() bool {
+	return !bytes.Equal(ed.AttributesBefore, ed.AttributesAfter)
+}
+
+// DiffAccountMaps compares two states of the world: accounts, nonces, EGLD balances, storage and ESDT token instances.
+// Protected storage keys are not compared directly, the ESDT tokens they hold are compared instead.
+// The system account is skipped, since it only holds token metadata.
+func DiffAccountMaps(before AccountMap, after AccountMap) (*WorldDiff, error) {
+	addresses := make(map[string]bool)
+	for address := range before {
+		addresses[address] = true
+	}
+	for address := range after {
+		addresses[address] = true
+	}
+	delete(addresses, string(vmcommon.SystemAccountAddress))
+	sortedAddresses := make([]string, 0, len(addresses))
+	for address := range addresses {
+		sortedAddresses = append(sortedAddresses, address)
+	}
+	sort.Strings(sortedAddresses)
+
+	diff := &WorldDiff{}
+	for _, address := range sortedAddresses {
+		accountDiff, err := diffAccounts([]byte(address), before, after)
+		if err != nil {
+			return nil, err
+		}
+		if accountDiff != nil {
+			diff.Accounts = append(diff.Accounts, accountDiff)
+		}
+	}
+	return diff, nil
+}
+
+// SameAccountState yields true if the account exists in both worlds and has the same state in each.
+// Unlike Account.SameState, it also compares the ESDT token instances, with the metadata and attributes
+// read from the system account of the respective world.
+func SameAccountState(address []byte, before AccountMap, after AccountMap) (bool, error) {
+	beforeAccount, foundBefore := before[string(address)]
+	afterAccount, foundAfter := after[string(address)]
+	if !foundBefore || !foundAfter || !beforeAccount.SameState(afterAccount) {
+		return false, nil
+	}
+
+	beforeInstances, err := esdtInstances(beforeAccount, systemAccountStorage(before))
+	if err != nil {
+		return false, err
+	}
+	afterInstances, err := esdtInstances(afterAccount, systemAccountStorage(after))
+	if err != nil {
+		return false, err
+	}
+	if len(beforeInstances) != len(afterInstances) {
+		return false, nil
+	}
+	for key, beforeInstance := range beforeInstances {
+		afterInstance, found := afterInstances[key]
+		if !found || !beforeInstance.Equal(afterInstance) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// diffAccounts yields nil if the account did not change.
+func diffAccounts(address []byte, before AccountMap, after AccountMap) (*AccountDiff, error) {
+	beforeAccount := before[string(address)]
+	afterAccount := after[string(address)]
+	accountDiff := &AccountDiff{
+		Address: address,
+		Created: beforeAccount == nil,
+		Deleted: afterAccount == nil,
+	}
+	if beforeAccount == nil {
+		beforeAccount = &Account{}
+	}
+	if afterAccount == nil {
+		afterAccount = &Account{}
+	}
+
+	accountDiff.NonceBefore = beforeAccount.Nonce
+	accountDiff.NonceAfter = afterAccount.Nonce
+	accountDiff.BalanceBefore = bigIntOrZero(beforeAccount.Balance)
+	accountDiff.BalanceAfter = bigIntOrZero(afterAccount.Balance)
+	accountDiff.Storage = diffStorage(beforeAccount.Storage, afterAccount.Storage)
+
+	var err error
+	accountDiff.ESDT, err = diffESDT(beforeAccount, systemAccountStorage(before), afterAccount, systemAccountStorage(after))
+	if err != nil {
+		return nil, err
+	}
+
+	if !accountDiff.Created && !accountDiff.Deleted && !accountDiff.NonceChanged() && !accountDiff.BalanceChanged() &&
+		len(accountDiff.Storage) == 0 && len(accountDiff.ESDT) == 0 {
+		return nil, nil
+	}
+	return accountDiff, nil
+}
+
+func diffStorage(before map[string][]byte, after map[string][]byte) []*StorageDiff {
+	keys := make(map[string]bool)
+	for key := range before {
+		keys[key] = true
+	}
+	for key := range after {
+		keys[key] = true
+	}
+	sortedKeys := make([]string, 0, len(keys))
+	for key := range keys {
+		if !strings.HasPrefix(key, core.ProtectedKeyPrefix) {
+			sortedKeys = append(sortedKeys, key)
+		}
+	}
+	sort.Strings(sortedKeys)
+
+	var storageDiffs []*StorageDiff
+	for _, key := range sortedKeys {
+		beforeValue := emptyAsNil(before[key])
+		afterValue := emptyAsNil(after[key])
+		if !bytes.Equal(beforeValue, afterValue) {
+			storageDiffs = append(storageDiffs, &StorageDiff{
+				Key:    []byte(key),
+				Before: beforeValue,
+				After:  afterValue,
+			})
+		}
+	}
+	return storageDiffs
+}
+
+func diffESDT(
+	beforeAccount *Account,
+	beforeSystemStorage map[string][]byte,
+	afterAccount *Account,
+	afterSystemStorage map[string][]byte,
+) ([]*ESDTDiff, error) {
+	beforeInstances, err := esdtInstances(beforeAccount, beforeSystemStorage)
+	if err != nil {
+		return nil, err
+	}
+	afterInstances, err := esdtInstances(afterAccount, afterSystemStorage)
+	if err != nil {
+		return nil, err
+	}
+
+	allInstances := make(map[esdtInstanceKey]bool)
+	for key := range beforeInstances {
+		allInstances[key] = true
+	}
+	for key := range afterInstances {
+		allInstances[key] = true
+	}
+	sortedInstances := make([]esdtInstanceKey, 0, len(allInstances))
+	for key := range allInstances {
+		sortedInstances = append(sortedInstances, key)
+	}
+	sort.Slice(sortedInstances, func(i, j int) bool {
+		if sortedInstances[i].tokenIdentifier != sortedInstances[j].tokenIdentifier {
+			return sortedInstances[i].tokenIdentifier < sortedInstances[j].tokenIdentifier
+		}
+		return sortedInstances[i].nonce < sortedInstances[j].nonce
+	})
+
+	var esdtDiffs []*ESDTDiff
+	for _, key := range sortedInstances {
+		esdtDiff := &ESDTDiff{
+			TokenIdentifier: []byte(key.tokenIdentifier),
+			Nonce:           key.nonce,
+			BalanceBefore:   big.NewInt(0),
+			BalanceAfter:    big.NewInt(0),
+		}
+		if instance, found := beforeInstances[key]; found {
+			esdtDiff.BalanceBefore = bigIntOrZero(instance.Value)
+			esdtDiff.AttributesBefore = instanceAttributes(instance)
+		}
+		if instance, found := afterInstances[key]; found {
+			esdtDiff.BalanceAfter = bigIntOrZero(instance.Value)
+			esdtDiff.AttributesAfter = instanceAttributes(instance)
+		}
+		if esdtDiff.BalanceChanged() || esdtDiff.AttributesChanged() {
+			esdtDiffs = append(esdtDiffs, esdtDiff)
+		}
+	}
+	return esdtDiffs, nil
+}
+
+type esdtInstanceKey struct {
+	tokenIdentifier string
+	nonce           uint64
+}
+
+func esdtInstances(account *Account, systemAccStorage map[string][]byte) (map[esdtInstanceKey]*esdt.ESDigitalToken, error) {
+	tokenData, err := esdtconvert.GetFullMockESDTData(account.Storage, systemAccStorage)
+	if err != nil {
+		return nil, err
+	}
+
+	instances := make(map[esdtInstanceKey]*esdt.ESDigitalToken)
+	for tokenIdentifier, tokenObj := range tokenData {
+		for _, instance := range tokenObj.Instances {
+			nonce := uint64(0)
+			if instance.TokenMetaData != nil {
+				nonce = instance.TokenMetaData.Nonce
+			}
+			instances[esdtInstanceKey{tokenIdentifier: tokenIdentifier, nonce: nonce}] = instance
+		}
+	}
+	return instances, nil
+}
+
+func instanceAttributes(instance *esdt.ESDigitalToken) []byte {
+	if instance.TokenMetaData == nil {
+		return nil
+	}
+	return emptyAsNil(instance.TokenMetaData.Attributes)
+}
+
+func systemAccountStorage(accounts AccountMap) map[string][]byte {
+	systemAccount, found := accounts[string(vmcommon.SystemAccountAddress)]
+	if !found {
+		return make(map[string][]byte)
+	}
+	return systemAccount.Storage
+}
+
+func bigIntOrZero(value *big.Int) *big.Int {
+	if value == nil {
+		return big.NewInt(0)
+	}
+	return value
+}
+
+func emptyAsNil(value []byte) []byte {
+	if len(value) == 0 {
+		return nil
+	}
+	return value
+}
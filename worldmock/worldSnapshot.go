@@ -0,0 +1,351 @@
+package worldmock
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// WorldSnapshotVersion is the version of the world snapshot file format.
+const WorldSnapshotVersion = 1
+
+// SnapshotBytes is a byte slice that is saved as a hex string in world snapshots.
+type SnapshotBytes []byte
+
+// MarshalText encodes the bytes as hex.
+func (sb SnapshotBytes) MarshalText() ([]byte, error) {
+	return []byte(hex.EncodeToString(sb)), nil
+}
+
+// UnmarshalText decodes the bytes from hex.
+func (sb *SnapshotBytes) UnmarshalText(text []byte) error {
+	decoded, err := hex.DecodeString(string(text))
+	if err != nil {
+		return err
+	}
+	*sb = decoded
+	return nil
+}
+
+// WorldSnapshot is the serializable form of a complete MockWorld.
+// The ESDT data lives in the storage of the accounts, including the system account, so it is saved along with them.
+type WorldSnapshot struct {
+	Version           int                       `json:"version"`
+	SelfShardID       uint32                    `json:"selfShardId"`
+	Accounts          []*AccountSnapshot        `json:"accounts"`
+	PreviousBlockInfo *BlockInfoSnapshot        `json:"previousBlockInfo,omitempty"`
+	CurrentBlockInfo  *BlockInfoSnapshot        `json:"currentBlockInfo,omitempty"`
+	EpochStartBlock   *BlockInfoSnapshot        `json:"epochStartBlockInfo,omitempty"`
+	Blockhashes       []SnapshotBytes           `json:"blockHashes,omitempty"`
+	NewAddressMocks   []*NewAddressMockSnapshot `json:"newAddressMocks,omitempty"`
+	StateRootHash     SnapshotBytes             `json:"stateRootHash,omitempty"`
+}
+
+// AccountSnapshot holds all the persistent fields of an account.
+// Storage keys and values are hex encoded, big integers are in decimal.
+type AccountSnapshot struct {
+	Address         SnapshotBytes     `json:"address"`
+	Nonce           uint64            `json:"nonce"`
+	Balance         string            `json:"balance"`
+	Storage         map[string]string `json:"storage,omitempty"`
+	RootHash        SnapshotBytes     `json:"rootHash,omitempty"`
+	Code            SnapshotBytes     `json:"code,omitempty"`
+	CodeHash        SnapshotBytes     `json:"codeHash,omitempty"`
+	CodeMetadata    SnapshotBytes     `json:"codeMetadata,omitempty"`
+	OwnerAddress    SnapshotBytes     `json:"owner,omitempty"`
+	AsyncCallData   string            `json:"asyncCallData,omitempty"`
+	Username        SnapshotBytes     `json:"username,omitempty"`
+	DeveloperReward string            `json:"developerReward"`
+	ShardID         uint32            `json:"shardId"`
+	IsSmartContract bool              `json:"isSmartContract"`
+}
+
+// BlockInfoSnapshot is the serializable form of a BlockInfo.
+type BlockInfoSnapshot struct {
+	BlockTimestampMs uint64        `json:"blockTimestampMs"`
+	BlockNonce       uint64        `json:"blockNonce"`
+	BlockRound       uint64        `json:"blockRound"`
+	BlockEpoch       uint32        `json:"blockEpoch"`
+	RandomSeed       SnapshotBytes `json:"randomSeed,omitempty"`
+}
+
+// NewAddressMockSnapshot is the serializable form of a NewAddressMock.
+type NewAddressMockSnapshot struct {
+	CreatorAddress SnapshotBytes `json:"creatorAddress"`
+	CreatorNonce   uint64        `json:"creatorNonce"`
+	NewAddress     SnapshotBytes `json:"newAddress"`
+}
+
+// ToWorldSnapshot captures the current state of the world.
+// Accounts are sorted by address, so that equal worlds yield equal snapshots.
+func (b *MockWorld) ToWorldSnapshot() *WorldSnapshot {
+	snapshot := &WorldSnapshot{
+		Version:           WorldSnapshotVersion,
+		SelfShardID:       b.SelfShardID,
+		Accounts:          make([]*AccountSnapshot, 0, len(b.AcctMap)),
+		PreviousBlockInfo: blockInfoToSnapshot(b.PreviousBlockInfo),
+		CurrentBlockInfo:  blockInfoToSnapshot(b.CurrentBlockInfo),
+		EpochStartBlock:   blockInfoToSnapshot(b.EpochStartBlockInfo),
+		Blockhashes:       nil,
+		NewAddressMocks:   nil,
+		StateRootHash:     b.StateRootHash,
+	}
+
+	addresses := make([]string, 0, len(b.AcctMap))
+	for address := range b.AcctMap {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+	for _, address := range addresses {
+		snapshot.Accounts = append(snapshot.Accounts, accountToSnapshot(b.AcctMap[address]))
+	}
+
+	for _, blockHash := range b.Blockhashes {
+		snapshot.Blockhashes = append(snapshot.Blockhashes, blockHash)
+	}
+	for _, newAddressMock := range b.NewAddressMocks {
+		snapshot.NewAddressMocks = append(snapshot.NewAddressMocks, &NewAddressMockSnapshot{
+			CreatorAddress: newAddressMock.CreatorAddress,
+			CreatorNonce:   newAddressMock.CreatorNonce,
+			NewAddress:     newAddressMock.NewAddress,
+		})
+	}
+
+	return snapshot
+}
+
+// LoadWorldSnapshot replaces the state of the world with the one in the snapshot.
+// The builtin functions and the VM related settings of the world are kept.
+func (b *MockWorld) LoadWorldSnapshot(snapshot *WorldSnapshot) error {
+	accounts, err := snapshot.AccountMap()
+	if err != nil {
+		return err
+	}
+	for _, account := range accounts {
+		account.MockWorld = b
+	}
+
+	previousBlockInfo, err := blockInfoFromSnapshot(snapshot.PreviousBlockInfo)
+	if err != nil {
+		return err
+	}
+	currentBlockInfo, err := blockInfoFromSnapshot(snapshot.CurrentBlockInfo)
+	if err != nil {
+		return err
+	}
+	epochStartBlockInfo, err := blockInfoFromSnapshot(snapshot.EpochStartBlock)
+	if err != nil {
+		return err
+	}
+
+	b.Clear()
+	b.SelfShardID = snapshot.SelfShardID
+	b.AcctMap = accounts
+	b.PreviousBlockInfo = previousBlockInfo
+	b.CurrentBlockInfo = currentBlockInfo
+	b.EpochStartBlockInfo = epochStartBlockInfo
+	for _, blockHash := range snapshot.Blockhashes {
+		b.Blockhashes = append(b.Blockhashes, blockHash)
+	}
+	for _, newAddressMock := range snapshot.NewAddressMocks {
+		b.NewAddressMocks = append(b.NewAddressMocks, &NewAddressMock{
+			CreatorAddress: newAddressMock.CreatorAddress,
+			CreatorNonce:   newAddressMock.CreatorNonce,
+			NewAddress:     newAddressMock.NewAddress,
+		})
+	}
+	b.StateRootHash = snapshot.StateRootHash
+
+	return nil
+}
+
+// SaveWorldSnapshotFile writes the current state of the world to a JSON file.
+func (b *MockWorld) SaveWorldSnapshotFile(toPath string) error {
+	data, err := json.MarshalIndent(b.ToWorldSnapshot(), "", "    ")
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(toPath), os.ModePerm)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(toPath, append(data, '\n'), 0644)
+}
+
+// LoadWorldSnapshotFile replaces the state of the world with the one saved in a JSON file.
+func (b *MockWorld) LoadWorldSnapshotFile(fromPath string) error {
+	snapshot, err := ReadWorldSnapshotFile(fromPath)
+	if err != nil {
+		return err
+	}
+
+	err = b.LoadWorldSnapshot(snapshot)
+	if err != nil {
+		return fmt.Errorf("bad world snapshot file %s: %w", fromPath, err)
+	}
+	return nil
+}
+
+// ReadWorldSnapshotFile reads a world snapshot from a JSON file, without loading it into a world.
+func ReadWorldSnapshotFile(fromPath string) (*WorldSnapshot, error) {
+	data, err := os.ReadFile(fromPath)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &WorldSnapshot{}
+	err = json.Unmarshal(data, snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("bad world snapshot file %s: %w", fromPath, err)
+	}
+	return snapshot, nil
+}
+
+// AccountMap yields the accounts of the snapshot, not attached to any world.
+func (ws *WorldSnapshot) AccountMap() (AccountMap, error) {
+	if ws.Version != WorldSnapshotVersion {
+		return nil, fmt.Errorf("unsupported world snapshot version %d, expected %d", ws.Version, WorldSnapshotVersion)
+	}
+
+	accounts := NewAccountMap()
+	for _, accountSnapshot := range ws.Accounts {
+		account, err := accountFromSnapshot(accountSnapshot, nil)
+		if err != nil {
+			return nil, err
+		}
+		accounts.PutAccount(account)
+	}
+	return accounts, nil
+}
+
+func accountToSnapshot(account *Account) *AccountSnapshot {
+	storage := make(map[string]string, len(account.Storage))
+	for key, value := range account.Storage {
+		storage[hex.EncodeToString([]byte(key))] = hex.EncodeToString(value)
+	}
+
+	return &AccountSnapshot{
+		Address:         account.Address,
+		Nonce:           account.Nonce,
+		Balance:         bigIntToSnapshot(account.Balance),
+		Storage:         storage,
+		RootHash:        account.RootHash,
+		Code:            account.Code,
+		CodeHash:        account.CodeHash,
+		CodeMetadata:    account.CodeMetadata,
+		OwnerAddress:    account.OwnerAddress,
+		AsyncCallData:   account.AsyncCallData,
+		Username:        account.Username,
+		DeveloperReward: bigIntToSnapshot(account.DeveloperReward),
+		ShardID:         account.ShardID,
+		IsSmartContract: account.IsSmartContract,
+	}
+}
+
+func accountFromSnapshot(accountSnapshot *AccountSnapshot, world *MockWorld) (*Account, error) {
+	balance, err := bigIntFromSnapshot(accountSnapshot.Balance)
+	if err != nil {
+		return nil, fmt.Errorf("bad balance of account %s: %w", hex.EncodeToString(accountSnapshot.Address), err)
+	}
+	developerReward, err := bigIntFromSnapshot(accountSnapshot.DeveloperReward)
+	if err != nil {
+		return nil, fmt.Errorf("bad developer reward of account %s: %w", hex.EncodeToString(accountSnapshot.Address), err)
+	}
+
+	storage := make(map[string][]byte, len(accountSnapshot.Storage))
+	for hexKey, hexValue := range accountSnapshot.Storage {
+		key, err := hex.DecodeString(hexKey)
+		if err != nil {
+			return nil, fmt.Errorf("bad storage key of account %s: %w", hex.EncodeToString(accountSnapshot.Address), err)
+		}
+		value, err := hex.DecodeString(hexValue)
+		if err != nil {
+			return nil, fmt.Errorf("bad storage value of account %s: %w", hex.EncodeToString(accountSnapshot.Address), err)
+		}
+		storage[string(key)] = value
+	}
+
+	return &Account{
+		Exists:          true,
+		Address:         accountSnapshot.Address,
+		Nonce:           accountSnapshot.Nonce,
+		Balance:         balance,
+		BalanceDelta:    big.NewInt(0),
+		Storage:         storage,
+		RootHash:        accountSnapshot.RootHash,
+		Code:            accountSnapshot.Code,
+		CodeHash:        accountSnapshot.CodeHash,
+		CodeMetadata:    accountSnapshot.CodeMetadata,
+		OwnerAddress:    accountSnapshot.OwnerAddress,
+		AsyncCallData:   accountSnapshot.AsyncCallData,
+		Username:        accountSnapshot.Username,
+		DeveloperReward: developerReward,
+		ShardID:         accountSnapshot.ShardID,
+		IsSmartContract: accountSnapshot.IsSmartContract,
+		MockWorld:       world,
+	}, nil
+}
+
+func blockInfoToSnapshot(blockInfo *BlockInfo) *BlockInfoSnapshot {
+	if blockInfo == nil {
+		return nil
+	}
+
+	var randomSeed SnapshotBytes
+	if blockInfo.RandomSeed != nil {
+		randomSeed = blockInfo.RandomSeed[:]
+	}
+	return &BlockInfoSnapshot{
+		BlockTimestampMs: blockInfo.BlockTimestampMs,
+		BlockNonce:       blockInfo.BlockNonce,
+		BlockRound:       blockInfo.BlockRound,
+		BlockEpoch:       blockInfo.BlockEpoch,
+		RandomSeed:       randomSeed,
+	}
+}
+
+func blockInfoFromSnapshot(blockInfoSnapshot *BlockInfoSnapshot) (*BlockInfo, error) {
+	if blockInfoSnapshot == nil {
+		return nil, nil
+	}
+
+	blockInfo := &BlockInfo{
+		BlockTimestampMs: blockInfoSnapshot.BlockTimestampMs,
+		BlockNonce:       blockInfoSnapshot.BlockNonce,
+		BlockRound:       blockInfoSnapshot.BlockRound,
+		BlockEpoch:       blockInfoSnapshot.BlockEpoch,
+		RandomSeed:       nil,
+	}
+	if len(blockInfoSnapshot.RandomSeed) > 0 {
+		var randomSeed [48]byte
+		if len(blockInfoSnapshot.RandomSeed) != len(randomSeed) {
+			return nil, fmt.Errorf("random seed must be %d bytes long, got %d", len(randomSeed), len(blockInfoSnapshot.RandomSeed))
+		}
+		copy(randomSeed[:], blockInfoSnapshot.RandomSeed)
+		blockInfo.RandomSeed = &randomSeed
+	}
+	return blockInfo, nil
+}
+
+func bigIntToSnapshot(value *big.Int) string {
+	if value == nil {
+		return "0"
+	}
+	return value.String()
+}
+
+func bigIntFromSnapshot(value string) (*big.Int, error) {
+	if len(value) == 0 {
+		return big.NewInt(0), nil
+	}
+	result, ok := big.NewInt(0).SetString(value, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid decimal number %s", value)
+	}
+	return result, nil
+}
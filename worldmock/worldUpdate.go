@@ -54,6 +54,9 @@ func (b *MockWorld) UpdateAccounts(
 	accountsToDelete [][]byte) error {
 
 	for _, modAcct := range outputAccounts {
+		if b.MultiShard && b.IsInOtherShard(modAcct.Address) {
+			continue
+		}
 		b.UpdateAccountFromOutputAccount(modAcct)
 	}
 